@@ -0,0 +1,39 @@
+package gominesweeper
+
+import (
+	"fmt"
+)
+
+// Actions returns the number of selections left that may reveal a block, or 0
+// if they are not limited.
+func (g *Game) Actions() uint {
+	return g.actions
+}
+
+// Revealed returns the number of blocks without mines that have been revealed.
+func (g *Game) Revealed() int {
+	return g.minefield.revealed()
+}
+
+// RevealFraction is won once at least the fraction of blocks without mines
+// has been revealed.
+func RevealFraction(fraction float64) WinCondition {
+	return namedWin{func(g *Game) bool {
+		return float64(g.minefield.revealed()) >= fraction*float64(g.minefield.safe())
+	}, fmt.Sprintf("fraction(%g)", fraction)}
+}
+
+// spendAction uses up one of the limited actions.
+func (g *Game) spendAction() {
+	if g.actions > 0 {
+		g.actions--
+	}
+}
+
+// runOut ends the game as lost if the limited actions have run out.
+func (g *Game) runOut() {
+	if g.state == Playing && g.config.Actions > 0 && g.actions == 0 {
+		g.minefield.revealMines()
+		g.state, g.reason = Lost, ActionsUsed
+	}
+}
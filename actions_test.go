@@ -0,0 +1,49 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_Actions(c *C) {
+	game := newTestGame(c, Config{Actions: 3, Win: RevealFraction(0.6)})
+	c.Check(game.Actions(), Equals, uint(3))
+
+	// an opening costs a single action
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(game.Actions(), Equals, uint(2))
+	c.Check(game.Revealed(), Equals, 6)
+
+	// selecting a revealed or flagged block is free
+	_, err = game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(game.Actions(), Equals, uint(2))
+
+	_, err = game.Select(0, 4)
+	c.Assert(err, IsNil)
+	c.Check(game.Revealed(), Equals, 12)
+	c.Check(game.State(), Equals, Won)
+	c.Check(game.Actions(), Equals, uint(1))
+}
+
+func (s *MSSuite) TestGame_ActionsRunOut(c *C) {
+	game := newTestGame(c, Config{Actions: 2, Win: RevealFraction(0.75)})
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	_, err = game.Select(0, 4)
+	c.Assert(err, IsNil)
+	c.Check(game.Actions(), Equals, uint(0))
+	c.Check(game.State(), Equals, Lost)
+	c.Check(game.Display()[Position{X: 0, Y: 0}], Equals, Mine)
+
+	// unlimited actions are never used up
+	game = newTestGame(c, Config{})
+	_, err = game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(game.Actions(), Equals, uint(0))
+	c.Check(game.State(), Equals, Playing)
+}
@@ -0,0 +1,257 @@
+package gominesweeper
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// ArchiveFormat identifies a file as an archive, and ArchiveVersion is the
+// version of the schema that archives are written with.
+const (
+	ArchiveFormat  = "go-minesweeper/archive"
+	ArchiveVersion = 1
+)
+
+// archiveSchema is the JSON Schema of an archive, embedded in every archive so
+// that it can be understood without this package.
+const archiveSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"title": "Minesweeper game archive",
+	"type": "object",
+	"required": ["format", "version", "rules", "board", "moves", "result"],
+	"properties": {
+		"format": {"const": "go-minesweeper/archive"},
+		"version": {"type": "integer", "description": "version of this schema"},
+		"schema": {"type": "object", "description": "this schema"},
+		"rules": {
+			"type": "object",
+			"description": "the rules the game was played by; durations are in nanoseconds",
+			"properties": {
+				"Width": {"type": "integer"},
+				"Height": {"type": "integer"},
+				"Mines": {"type": "integer"},
+				"Neighborhood": {"type": ["array", "null"], "description": "offsets of the neighbors of a block; null is the 8 surrounding blocks"},
+				"Win": {"type": "string", "description": "clear, flag, defuse, target(x,y), fraction(f) or survive(duration)"},
+				"Lives": {"type": "integer"},
+				"DefuseTime": {"type": "integer"},
+				"ScoreAttack": {"type": "boolean"},
+				"ComboTimeout": {"type": "integer"},
+				"Actions": {"type": "integer"},
+				"Blind": {"type": "boolean"},
+				"Splits": {"type": ["array", "null"], "items": {"type": "number"}},
+				"Strict": {"type": "boolean"},
+				"AutoFlag": {"type": "boolean"},
+				"OpeningSize": {"type": "integer", "description": "steps around the first selection cleared of mines"},
+				"FlagLimit": {"type": "boolean"}
+			}
+		},
+		"board": {
+			"type": "array",
+			"description": "rows from top to bottom; * is a mine, . has no mines nearby and a digit is the number of mines nearby",
+			"items": {"type": "string"}
+		},
+		"moves": {
+			"type": ["array", "null"],
+			"items": {
+				"type": "object",
+				"properties": {
+					"Action": {"enum": ["reveal", "flag", "defuse", "detonate", "chord", "question"]},
+					"X": {"type": "integer"},
+					"Y": {"type": "integer"},
+					"Elapsed": {"type": "integer", "description": "nanoseconds since the first move"}
+				}
+			}
+		},
+		"result": {
+			"type": "object",
+			"properties": {
+				"state": {"enum": ["playing", "won", "lost"]},
+				"lives": {"type": "integer"},
+				"score": {"type": "integer"},
+				"elapsed": {"type": "integer", "description": "nanoseconds"}
+			}
+		},
+		"signatures": {
+			"type": ["array", "null"],
+			"description": "ed25519 signatures of the archive encoded without its signatures",
+			"items": {
+				"type": "object",
+				"properties": {
+					"signer": {"type": "string"},
+					"key": {"type": "string", "contentEncoding": "base64"},
+					"signature": {"type": "string", "contentEncoding": "base64"}
+				}
+			}
+		}
+	}
+}`
+
+// Archive is a self-describing record of a finished game, meant to be kept
+// for a long time: the rules, the board, every move and the result, along
+// with the schema of the archive itself and any signatures vouching for it.
+type Archive struct {
+	Format  string          `json:"format"`
+	Version int             `json:"version"`
+	Schema  json.RawMessage `json:"schema,omitempty"`
+
+	Rules Rules    `json:"rules"`
+	Board []string `json:"board"`
+	Moves []Record `json:"moves"`
+
+	Result     ArchiveResult `json:"result"`
+	Signatures []Signature   `json:"signatures,omitempty"`
+}
+
+// ArchiveResult is how an archived game ended.
+type ArchiveResult struct {
+	State   State         `json:"state"`
+	Lives   uint          `json:"lives"`
+	Score   int           `json:"score"`
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// Signature vouches for an archive with an ed25519 key.
+type Signature struct {
+	Signer    string            `json:"signer"`
+	Key       ed25519.PublicKey `json:"key"`
+	Signature []byte            `json:"signature"`
+}
+
+// Archive returns the archive of the game so far.  Its Board shows where every
+// mine is, even while the game is still being played.  It returns ErrBadBoard
+// if a proximity is too large to be written on the board.
+func (g *Game) Archive() (Archive, error) {
+	var board strings.Builder
+	if err := WriteBoard(&board, g.minefield); err != nil {
+		return Archive{}, err
+	}
+	score, _ := g.Score()
+	replay := g.Replay()
+	return Archive{
+		Format:  ArchiveFormat,
+		Version: ArchiveVersion,
+		Schema:  json.RawMessage(archiveSchema),
+		Rules:   replay.Rules,
+		Board:   strings.Fields(board.String()),
+		Moves:   replay.Moves,
+		Result: ArchiveResult{
+			State:   g.state,
+			Lives:   g.lives,
+			Score:   score,
+			Elapsed: g.Elapsed(),
+		},
+	}, nil
+}
+
+// ReadArchive reads an archive that was written with Write.
+func ReadArchive(r io.Reader) (Archive, error) {
+	var archive Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return Archive{}, err
+	}
+	return archive, nil
+}
+
+// Write writes the archive as indented JSON.
+func (a Archive) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(a)
+}
+
+// Sign adds a signature of the archive by the key.  Any signatures already
+// added are not signed, so they stay valid.
+func (a *Archive) Sign(signer string, key ed25519.PrivateKey) error {
+	content, err := a.signed()
+	if err != nil {
+		return err
+	}
+	a.Signatures = append(a.Signatures, Signature{
+		Signer:    signer,
+		Key:       key.Public().(ed25519.PublicKey),
+		Signature: ed25519.Sign(key, content),
+	})
+	return nil
+}
+
+// signed returns the content of the archive that is signed, which is the
+// archive encoded without its signatures.
+func (a Archive) signed() ([]byte, error) {
+	a.Signatures = nil
+	return json.Marshal(a)
+}
+
+// Replay returns the replay of the archived game.  It returns ErrBadArchive if
+// the board does not have the size and mines given by the rules.
+func (a Archive) Replay() (Replay, error) {
+	if uint(len(a.Board)) != a.Rules.Height {
+		return Replay{}, ErrBadArchive
+	}
+	var layout []Position
+	for y, row := range a.Board {
+		if uint(len(row)) != a.Rules.Width {
+			return Replay{}, ErrBadArchive
+		}
+		for x, char := range row {
+			if char == '*' {
+				layout = append(layout, Position{X: x, Y: y})
+			}
+		}
+	}
+	if uint(len(layout)) != a.Rules.Mines {
+		return Replay{}, ErrBadArchive
+	}
+	return Replay{Version: ReplayVersion, Rules: a.Rules, Layout: layout, Moves: a.Moves}, nil
+}
+
+// Verify checks that the archive is one this package can read, that its board
+// is consistent with its rules, that playing its moves back at their recorded
+// times gives its result, and that every signature is valid.
+func (a Archive) Verify() error {
+	if a.Format != ArchiveFormat {
+		return ErrBadArchive
+	} else if a.Version < 1 || a.Version > ArchiveVersion {
+		return ErrArchiveVersion
+	}
+
+	replay, err := a.Replay()
+	if err != nil {
+		return err
+	}
+	game, err := replay.NewGame()
+	if err != nil {
+		return err
+	}
+	var board strings.Builder
+	if err := WriteBoard(&board, game.minefield); err != nil {
+		return err
+	} else if strings.Join(a.Board, "\n")+"\n" != board.String() {
+		return ErrBadProximity
+	}
+
+	if err := game.playback(a.Moves); err != nil {
+		return err
+	}
+	score, _ := game.Score()
+	result := ArchiveResult{State: game.state, Lives: game.lives, Score: score, Elapsed: a.Result.Elapsed}
+	if game.state != Playing {
+		result.Elapsed = game.Elapsed()
+	}
+	if result != a.Result {
+		return ErrResultMismatch
+	}
+
+	content, err := a.signed()
+	if err != nil {
+		return err
+	}
+	for _, signature := range a.Signatures {
+		if len(signature.Key) != ed25519.PublicKeySize || !ed25519.Verify(signature.Key, content, signature.Signature) {
+			return ErrBadSignature
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package gominesweeper
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestArchive(c *C) {
+	game := newTestGame(c, Config{Lives: 2, ScoreAttack: true})
+	game.clock = fakeClock()
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+
+	archive, err := game.Archive()
+	c.Assert(err, IsNil)
+	c.Check(archive.Board, DeepEquals, []string{"*212*", "23*21", "1*21.", "11211", "..1*1"})
+	c.Check(archive.Result.Lives, Equals, uint(1))
+	c.Check(json.Valid(archive.Schema), Equals, true)
+
+	// archives survive being written and signed
+	_, key, err := ed25519.GenerateKey(nil)
+	c.Assert(err, IsNil)
+	c.Assert(archive.Sign("referee", key), IsNil)
+	var buf bytes.Buffer
+	c.Assert(archive.Write(&buf), IsNil)
+	read, err := ReadArchive(&buf)
+	c.Assert(err, IsNil)
+	c.Check(read.Verify(), IsNil)
+
+	replay, err := read.Replay()
+	c.Assert(err, IsNil)
+	c.Check(replay.Matches(game), Equals, true)
+
+	// tampering is caught
+	tampered := read
+	tampered.Moves = tampered.Moves[:1]
+	c.Check(tampered.Verify(), Equals, ErrResultMismatch)
+
+	tampered = read
+	tampered.Board = []string{"*212*", "23*21", "1*21.", "11211", "..1*2"}
+	c.Check(tampered.Verify(), Equals, ErrBadProximity)
+
+	tampered = read
+	tampered.Result.Score++
+	tampered.Signatures = nil
+	c.Assert(tampered.Sign("cheat", key), IsNil)
+	tampered.Signatures[0].Key = read.Signatures[0].Key
+	c.Check(tampered.Verify(), Equals, ErrResultMismatch)
+
+	tampered = read
+	tampered.Rules.Splits = []float64{0.5}
+	c.Check(tampered.Verify(), Equals, ErrBadSignature)
+
+	tampered = read
+	tampered.Version = ArchiveVersion + 1
+	c.Check(tampered.Verify(), Equals, ErrArchiveVersion)
+}
@@ -0,0 +1,40 @@
+package gominesweeper
+
+// autoFlag flags the hidden neighbors of every revealed proximity that has as
+// many hidden neighbors as mines, counting exploded mines as hidden.  The
+// flags are placed as if by the player, so in defuse mode they start
+// defusals, and none are placed past the flag limit.
+func (g *Game) autoFlag() {
+	var flags []Position
+	g.minefield.each(func(pos Position, block *Block) {
+		if !block.checked() || block.proximity() <= 0 {
+			return
+		}
+		var hidden []Position
+		mines := 0
+		for _, neighbor := range g.minefield.neighborhood.Neighbors(pos) {
+			other, ok := g.minefield.peek(neighbor)
+			if !ok || other.checked() && !other.exploded() {
+				continue
+			}
+			mines++
+			if !other.checked() && !other.flagged() {
+				hidden = append(hidden, neighbor)
+			}
+		}
+		if mines == block.proximity() {
+			flags = append(flags, hidden...)
+		}
+	})
+
+	for _, pos := range flags {
+		block, _ := g.minefield.block(pos)
+		if block.flagged() || g.minefield.canFlag(block) != nil {
+			continue
+		}
+		block.ToggleFlag()
+		if g.config.DefuseTime > 0 {
+			g.startDefusal(pos, true)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package gominesweeper
+
+import (
+	"sort"
+)
+
+// openings returns the connected groups of 0s on the minefield, each ordered
+// by row and ordered by their first position.
+func (mf *Minefield) openings() [][]Position {
+	var openings [][]Position
+	seen := make(map[Position]bool)
+	mf.each(func(pos Position, block *Block) {
+		if block.proximity() != 0 || seen[pos] {
+			return
+		}
+
+		seen[pos] = true
+		opening := []Position{pos}
+		for i := 0; i < len(opening); i++ {
+			for _, neighbor := range mf.neighborhood.Neighbors(opening[i]) {
+				if block, ok := mf.peek(neighbor); ok && block.proximity() == 0 && !seen[neighbor] {
+					seen[neighbor] = true
+					opening = append(opening, neighbor)
+				}
+			}
+		}
+		sortPositions(opening)
+		openings = append(openings, opening)
+	})
+	sort.Slice(openings, func(i, j int) bool {
+		return less(openings[i][0], openings[j][0])
+	})
+	return openings
+}
+
+// bbbv returns the 3BV of the minefield, which is the least number of
+// selections needed to clear it, and how much of it has been solved so far.
+// Each opening counts once and so does every number that does not border an
+// opening.
+func (mf *Minefield) bbbv() (total, solved int) {
+	for _, opening := range mf.openings() {
+		total++
+		if block, _ := mf.peek(opening[0]); block.checked() {
+			solved++
+		}
+	}
+
+	mf.stored(func(pos Position, block *Block) {
+		if block.proximity() <= 0 || mf.bordersOpening(pos) {
+			return
+		}
+		total++
+		if block.checked() {
+			solved++
+		}
+	})
+	return total, solved
+}
+
+// bordersOpening returns true if the position neighbors a 0.
+func (mf *Minefield) bordersOpening(pos Position) bool {
+	for _, neighbor := range mf.neighborhood.Neighbors(pos) {
+		if block, ok := mf.peek(neighbor); ok && block.proximity() == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestMinefield_BBBV(c *C) {
+	minefield, err := newMinefield(Surrounding).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+	})
+	c.Assert(err, IsNil)
+
+	c.Check(minefield.openings(), DeepEquals, [][]Position{{{X: 4, Y: 2}}, {{X: 0, Y: 4}, {X: 1, Y: 4}}})
+
+	// 2 openings plus (1,0), (2,0), (3,0), (0,1), (1,1), (0,2), (2,2) and
+	// (4,4)
+	total, solved := minefield.bbbv()
+	c.Check(total, Equals, 10)
+	c.Check(solved, Equals, 0)
+
+	_, err = minefield.Select(4, 3)
+	c.Assert(err, IsNil)
+	_, err = minefield.Select(1, 4)
+	c.Assert(err, IsNil)
+	_, err = minefield.Select(1, 0)
+	c.Assert(err, IsNil)
+	total, solved = minefield.bbbv()
+	c.Check(total, Equals, 10)
+	c.Check(solved, Equals, 2)
+}
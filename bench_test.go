@@ -0,0 +1,142 @@
+package gominesweeper
+
+import (
+	"fmt"
+	"testing"
+)
+
+// The benchmarks here are plain Go benchmarks, named by their parameters so
+// that runs can be compared with benchstat:
+//
+//	go test -run '^$' -bench . -count 10 > old.txt
+//	go test -run '^$' -bench . -count 10 > new.txt
+//	benchstat old.txt new.txt
+
+// benchSizes are the sizes of board benchmarked, from beginner up to boards
+// far larger than the presets.
+var benchSizes = []struct{ width, height uint }{
+	{9, 9}, {30, 16}, {100, 100}, {250, 250},
+}
+
+// benchDensities are the percentages of blocks that are mines.
+var benchDensities = []uint{10, 20, 35}
+
+// benchMinefield returns a minefield of the size and density, with the same
+// mines every time.
+func benchMinefield(b *testing.B, width, height, density uint) *Minefield {
+	mf, err := NewMinefieldConfig(Config{
+		Width:    width,
+		Height:   height,
+		Mines:    max(1, width*height*density/100),
+		Selector: SeededSelector(1),
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return mf
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	for _, size := range benchSizes {
+		for _, density := range benchDensities {
+			cfg := Config{Width: size.width, Height: size.height, Mines: size.width * size.height * density / 100}
+			b.Run(fmt.Sprintf("size=%dx%d/density=%d", size.width, size.height, density), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					cfg.Selector = SeededSelector(int64(i))
+					if _, err := NewMinefieldConfig(cfg); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkFloodFill reveals the whole of a board with a single mine in its
+// corner from the opposite corner, which is the most a single reveal can
+// flood.
+func BenchmarkFloodFill(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("size=%dx%d", size.width, size.height), func(b *testing.B) {
+			mf, err := FromLayout(size.width, size.height, []Position{{X: 0, Y: 0}})
+			if err != nil {
+				b.Fatal(err)
+			}
+			far := Position{X: int(size.width) - 1, Y: int(size.height) - 1}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				mf.Reset()
+				b.StartTimer()
+				if _, revealed, err := mf.reveal(far); err != nil {
+					b.Fatal(err)
+				} else if revealed != int(size.width*size.height)-1 {
+					b.Fatalf("revealed %d blocks", revealed)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkDisplay shows a board that is half revealed, both on its own and
+// as part of a game's snapshot.
+func BenchmarkDisplay(b *testing.B) {
+	for _, size := range benchSizes {
+		mf := benchMinefield(b, size.width, size.height, 20)
+		mf.each(func(pos Position, block *Block) {
+			if pos.Y < int(size.height)/2 && block.proximity() != Mine {
+				block.Select()
+			}
+		})
+		game := newGame(mf, Config{})
+		name := fmt.Sprintf("size=%dx%d", size.width, size.height)
+
+		b.Run("Minefield/"+name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				mf.Display()
+			}
+		})
+		b.Run("Snapshot/"+name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				game.Snapshot()
+			}
+		})
+	}
+}
+
+// BenchmarkSolver solves the boards of the presets from their largest
+// opening, as the bot sees them after its first move.
+func BenchmarkSolver(b *testing.B) {
+	for _, preset := range []Preset{Beginner, Intermediate, Expert} {
+		cfg, err := preset.Config()
+		if err != nil {
+			b.Fatal(err)
+		}
+		cfg.Selector = SeededSelector(1)
+		game, err := NewGame(cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if opening, ok := game.minefield.LargestOpening(); ok {
+			game.Select(opening.Zeros[0].X, opening.Zeros[0].Y)
+		}
+		snapshot := game.Snapshot()
+
+		b.Run("Deduce/"+string(preset), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				NewSolver(snapshot)
+			}
+		})
+		b.Run("Probabilities/"+string(preset), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				NewSolver(snapshot).Probabilities()
+			}
+		})
+	}
+}
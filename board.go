@@ -0,0 +1,172 @@
+package gominesweeper
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"strings"
+)
+
+// FromLayout returns the minefield with mines in the positions.  It returns
+// ErrExceedDimensions unless there are fewer mines than blocks, and an
+// OutOfBoundsError or DuplicatePointError if a position is off the minefield
+// or repeated.
+func FromLayout(width, height uint, mines []Position) (*Minefield, error) {
+	if uint(len(mines)) >= width*height {
+		return nil, ErrExceedDimensions
+	}
+	return newMinefield(Surrounding).init(width, height, uint(len(mines)), func(width, height, max uint) ([]Position, error) {
+		return mines, nil
+	})
+}
+
+// FromGrid returns the minefield given as rows of blocks, from top to bottom,
+// where each block is either Mine or its proximity.  It returns ErrBadBoard if
+// the rows are empty or of different lengths or a block is neither, and
+// ErrBadProximity if a proximity does not match the mines.
+func FromGrid(grid [][]int) (*Minefield, error) {
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return nil, ErrBadBoard
+	}
+	var mines []Position
+	for y, row := range grid {
+		if len(row) != len(grid[0]) {
+			return nil, ErrBadBoard
+		}
+		for x, value := range row {
+			if value == Mine {
+				mines = append(mines, Position{X: x, Y: y})
+			} else if value < 0 || value > 8 {
+				return nil, ErrBadBoard
+			}
+		}
+	}
+
+	minefield, err := FromLayout(uint(len(grid[0])), uint(len(grid)), mines)
+	if err != nil {
+		return nil, err
+	}
+	for y, row := range grid {
+		for x, value := range row {
+			if block, _ := minefield.peek(Position{X: x, Y: y}); block.proximity() != value {
+				return nil, ErrBadProximity
+			}
+		}
+	}
+	return minefield, nil
+}
+
+// ParseBoard reads a minefield from a grid of text, one row per line, where
+// '*' is a mine and any other block is either '.' or its proximity.  Any
+// proximities given must match the mines.
+func ParseBoard(r io.Reader) (*Minefield, error) {
+	var rows []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if row := strings.TrimRight(scanner.Text(), "\r"); row != "" {
+			rows = append(rows, row)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	} else if len(rows) == 0 {
+		return nil, ErrBadBoard
+	}
+
+	var mines []Position
+	proximities := make(map[Position]int)
+	for y, row := range rows {
+		if len(row) != len(rows[0]) {
+			return nil, ErrBadBoard
+		}
+		for x, char := range row {
+			switch {
+			case char == '*':
+				mines = append(mines, Position{X: x, Y: y})
+			case char >= '0' && char <= '9':
+				proximities[Position{X: x, Y: y}] = int(char - '0')
+			case char != '.':
+				return nil, ErrBadBoard
+			}
+		}
+	}
+
+	minefield, err := FromLayout(uint(len(rows[0])), uint(len(rows)), mines)
+	if err != nil {
+		return nil, err
+	}
+	for pos, proximity := range proximities {
+		if block, _ := minefield.peek(pos); block.proximity() != proximity {
+			return nil, ErrBadProximity
+		}
+	}
+	return minefield, nil
+}
+
+// WriteBoard writes the minefield as a grid of text that can be read by
+// ParseBoard, with '.' for blocks without any mines in their proximity.
+func WriteBoard(w io.Writer, mf *Minefield) error {
+	width, height := mf.size()
+	buf := bufio.NewWriter(w)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			block, _ := mf.peek(Position{X: x, Y: y})
+			switch proximity := block.proximity(); {
+			case proximity == Mine:
+				buf.WriteByte('*')
+			case proximity == 0:
+				buf.WriteByte('.')
+			case proximity <= 9:
+				buf.WriteByte('0' + byte(proximity))
+			default:
+				return ErrBadBoard
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Flush()
+}
+
+// ParseMBF reads a minefield in the binary Minesweeper Board Format: a byte
+// each for the width and height, the number of mines as a big-endian uint16,
+// and then a byte each for the x and y of every mine.
+func ParseMBF(r io.Reader) (*Minefield, error) {
+	var header struct {
+		Width, Height uint8
+		Mines         uint16
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, ErrBadBoard
+	}
+
+	coords := make([]uint8, 2*int(header.Mines))
+	if _, err := io.ReadFull(r, coords); err != nil {
+		return nil, ErrBadBoard
+	}
+	mines := make([]Position, header.Mines)
+	for i := range mines {
+		mines[i] = Position{X: int(coords[2*i]), Y: int(coords[2*i+1])}
+	}
+
+	return newMinefield(Surrounding).init(uint(header.Width), uint(header.Height), uint(header.Mines), func(width, height, max uint) ([]Position, error) {
+		return mines, nil
+	})
+}
+
+// WriteMBF writes the minefield in the binary Minesweeper Board Format, which
+// is limited to 255 blocks across and down.
+func WriteMBF(w io.Writer, mf *Minefield) error {
+	width, height := mf.size()
+	if width > 255 || height > 255 {
+		return ErrExceedDimensions
+	}
+
+	mines := mf.mines()
+	data := []byte{byte(width), byte(height), 0, 0}
+	binary.BigEndian.PutUint16(data[2:], uint16(len(mines)))
+	for _, mine := range mines {
+		data = append(data, byte(mine.X), byte(mine.Y))
+	}
+	_, err := w.Write(data)
+	return err
+}
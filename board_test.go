@@ -0,0 +1,105 @@
+package gominesweeper
+
+import (
+	"bytes"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+const testBoard = `*212*
+23*21
+1*21.
+11211
+..1*1
+`
+
+func (s *MSSuite) TestParseBoard(c *C) {
+	minefield, err := ParseBoard(strings.NewReader(testBoard))
+	c.Assert(err, IsNil)
+
+	expected, err := newMinefield(Surrounding).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+	})
+	c.Assert(err, IsNil)
+	c.Check(allBlocks(minefield), DeepEquals, allBlocks(expected))
+
+	var buf bytes.Buffer
+	c.Assert(WriteBoard(&buf, minefield), IsNil)
+	c.Check(buf.String(), Equals, testBoard)
+
+	// proximities may be left out
+	minefield, err = ParseBoard(strings.NewReader("*....\n..*..\r\n.*...\n.....\n...*.\n\n"))
+	c.Assert(err, IsNil)
+	c.Check(allBlocks(minefield)[Position{X: 1, Y: 1}].proximity(), Equals, 3)
+
+	for _, board := range []string{"", "*..\n..\n", "*.x\n...\n"} {
+		_, err = ParseBoard(strings.NewReader(board))
+		c.Check(err, Equals, ErrBadBoard, Commentf("%q", board))
+	}
+	_, err = ParseBoard(strings.NewReader("*2\n..\n"))
+	c.Check(err, Equals, ErrBadProximity)
+}
+
+func (s *MSSuite) TestFromLayout(c *C) {
+	mines := []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}
+	minefield, err := FromLayout(5, 5, mines)
+	c.Assert(err, IsNil)
+	expected, err := ParseBoard(strings.NewReader(testBoard))
+	c.Assert(err, IsNil)
+	c.Check(allBlocks(minefield), DeepEquals, allBlocks(expected))
+
+	_, err = FromLayout(2, 2, []Position{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}, {X: 1, Y: 1}})
+	c.Check(err, Equals, ErrExceedDimensions)
+	_, err = FromLayout(0, 0, nil)
+	c.Check(err, Equals, ErrExceedDimensions)
+	_, err = FromLayout(5, 5, []Position{{X: 5, Y: 0}})
+	c.Check(err, DeepEquals, &OutOfBoundsError{Position{X: 5, Y: 0}, 5, 5})
+	_, err = FromLayout(5, 5, []Position{{X: 1, Y: 1}, {X: 1, Y: 1}})
+	c.Check(err, DeepEquals, &DuplicatePointError{Position{X: 1, Y: 1}})
+}
+
+func (s *MSSuite) TestFromGrid(c *C) {
+	grid := [][]int{
+		{Mine, 2, 1, 2, Mine},
+		{2, 3, Mine, 2, 1},
+		{1, Mine, 2, 1, 0},
+		{1, 1, 2, 1, 1},
+		{0, 0, 1, Mine, 1},
+	}
+	minefield, err := FromGrid(grid)
+	c.Assert(err, IsNil)
+	var buf bytes.Buffer
+	c.Assert(WriteBoard(&buf, minefield), IsNil)
+	c.Check(buf.String(), Equals, testBoard)
+
+	for _, bad := range [][][]int{nil, {{}}, {{0, 0}, {0}}, {{0, Flagged}}, {{9}}} {
+		_, err = FromGrid(bad)
+		c.Check(err, Equals, ErrBadBoard, Commentf("%v", bad))
+	}
+	grid[0][1] = 1
+	_, err = FromGrid(grid)
+	c.Check(err, Equals, ErrBadProximity)
+}
+
+func (s *MSSuite) TestParseMBF(c *C) {
+	expected, err := ParseBoard(strings.NewReader(testBoard))
+	c.Assert(err, IsNil)
+
+	var buf bytes.Buffer
+	c.Assert(WriteMBF(&buf, expected), IsNil)
+	c.Check(buf.Bytes(), DeepEquals, []byte{5, 5, 0, 5, 0, 0, 4, 0, 2, 1, 1, 2, 3, 4})
+
+	minefield, err := ParseMBF(&buf)
+	c.Assert(err, IsNil)
+	c.Check(allBlocks(minefield), DeepEquals, allBlocks(expected))
+
+	_, err = ParseMBF(bytes.NewReader([]byte{5, 5, 0, 2, 0, 0}))
+	c.Check(err, Equals, ErrBadBoard)
+	_, err = ParseMBF(bytes.NewReader([]byte{5, 5, 0, 1, 5, 0}))
+	c.Check(err, DeepEquals, &OutOfBoundsError{Position{X: 5, Y: 0}, 5, 5})
+
+	wide, err := ParseBoard(strings.NewReader(strings.Repeat(".", 256) + "\n"))
+	c.Assert(err, IsNil)
+	c.Check(WriteMBF(&buf, wide), Equals, ErrExceedDimensions)
+}
@@ -0,0 +1,184 @@
+// Package boardtest builds minefields for tests from explicit mines, diagrams
+// and fuzz input, so tests can lay out a board the way they would draw it:
+//
+//	game := boardtest.Game(t, `
+//		*.
+//		1.
+//	`, gominesweeper.Config{})
+//
+// Diagrams are either the grids of gominesweeper.ParseBoard, indented as they
+// like, or drawn as tables with the blocks between '|', like Example.
+package boardtest
+
+import (
+	"strings"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+// Example is the 5x5 minefield with 5 mines used throughout the tests of
+// gominesweeper.
+const Example = `
+	 -------------------
+	| * | 2 | 1 | 2 | * |
+	|---+---+---+---+---|
+	| 2 | 3 | * | 2 | 1 |
+	|---+---+---+---+---|
+	| 1 | * | 2 | 1 | 0 |
+	|---+---+---+---+---|
+	| 1 | 1 | 2 | 1 | 1 |
+	|---+---+---+---+---|
+	| 0 | 0 | 1 | * | 1 |
+	 -------------------
+`
+
+// Golden holds minefields worth testing against, by name.
+var Golden = map[string]string{
+	// example is Example
+	"example": Example,
+
+	// open is one opening, cleared by a single selection
+	"open": `
+		....
+		..11
+		..1*
+	`,
+
+	// guess has no opening, so the first selection is a guess
+	"guess": `
+		*1
+		11
+	`,
+}
+
+// TB is the part of testing.TB that the helpers use to fail a test, which
+// gocheck's *C has as well.
+type TB interface {
+	Fatalf(format string, args ...interface{})
+}
+
+// BuilderSelector places exactly the mines given, whatever the size of the
+// minefield.  The number of mines must match the config's Mines.
+func BuilderSelector(mines ...gominesweeper.Position) gominesweeper.Selector {
+	return func(width, height, max uint) ([]gominesweeper.Position, error) {
+		return append([]gominesweeper.Position(nil), mines...), nil
+	}
+}
+
+// Grid returns the diagram as a grid that gominesweeper.ParseBoard can read,
+// or gominesweeper.ErrBadBoard if it has no blocks.
+func Grid(diagram string) (string, error) {
+	var grid strings.Builder
+	for _, line := range strings.Split(diagram, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.Trim(line, "-+| ") == "" {
+			continue
+		}
+		if strings.Contains(line, "|") {
+			for _, cell := range strings.Split(strings.Trim(line, "|"), "|") {
+				cell = strings.TrimSpace(cell)
+				if len(cell) != 1 {
+					return "", gominesweeper.ErrBadBoard
+				}
+				grid.WriteString(cell)
+			}
+		} else {
+			grid.WriteString(line)
+		}
+		grid.WriteByte('\n')
+	}
+	if grid.Len() == 0 {
+		return "", gominesweeper.ErrBadBoard
+	}
+	return grid.String(), nil
+}
+
+// Config returns a config of the diagram's size, with a Selector that places
+// its mines.  Any proximities drawn must match the mines.
+func Config(diagram string) (gominesweeper.Config, error) {
+	grid, err := Grid(diagram)
+	if err != nil {
+		return gominesweeper.Config{}, err
+	}
+	// parse the grid to check it
+	if _, err := gominesweeper.ParseBoard(strings.NewReader(grid)); err != nil {
+		return gominesweeper.Config{}, err
+	}
+
+	rows := strings.Fields(grid)
+	var mines []gominesweeper.Position
+	for y, row := range rows {
+		for x, char := range row {
+			if char == '*' {
+				mines = append(mines, gominesweeper.Position{X: x, Y: y})
+			}
+		}
+	}
+	return gominesweeper.Config{
+		Width:    uint(len(rows[0])),
+		Height:   uint(len(rows)),
+		Mines:    uint(len(mines)),
+		Selector: BuilderSelector(mines...),
+	}, nil
+}
+
+// Minefield returns the minefield of the diagram, failing the test if the
+// diagram is malformed.
+func Minefield(tb TB, diagram string) *gominesweeper.Minefield {
+	if h, ok := tb.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	grid, err := Grid(diagram)
+	if err != nil {
+		tb.Fatalf("boardtest: %v", err)
+	}
+	mf, err := gominesweeper.ParseBoard(strings.NewReader(grid))
+	if err != nil {
+		tb.Fatalf("boardtest: %v", err)
+	}
+	return mf
+}
+
+// Game starts a game on the minefield of the diagram, playing by the rest of
+// the config, and fails the test if the diagram is malformed.
+func Game(tb TB, diagram string, cfg gominesweeper.Config) *gominesweeper.Game {
+	if h, ok := tb.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	board, err := Config(diagram)
+	if err != nil {
+		tb.Fatalf("boardtest: %v", err)
+	}
+	cfg.Width, cfg.Height, cfg.Mines, cfg.Selector = board.Width, board.Height, board.Mines, board.Selector
+	game, err := gominesweeper.NewGame(cfg)
+	if err != nil {
+		tb.Fatalf("boardtest: %v", err)
+	}
+	return game
+}
+
+// FromBytes returns the config of a small minefield made from fuzz input, so
+// that any input is a valid minefield: the first two bytes are its width and
+// height, from 1 to 16, and each bit of the rest places a mine, row by row.
+func FromBytes(data []byte) gominesweeper.Config {
+	size := func(i int) uint {
+		if i >= len(data) {
+			return 1
+		}
+		return uint(data[i])%16 + 1
+	}
+	width, height := size(0), size(1)
+
+	var mines []gominesweeper.Position
+	for i := uint(0); i < width*height; i++ {
+		if b := 2 + int(i/8); b < len(data) && data[b]&(1<<(i%8)) != 0 {
+			mines = append(mines, gominesweeper.Position{X: int(i % width), Y: int(i / width)})
+		}
+	}
+	return gominesweeper.Config{
+		Width:    width,
+		Height:   height,
+		Mines:    uint(len(mines)),
+		Selector: BuilderSelector(mines...),
+	}
+}
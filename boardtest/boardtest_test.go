@@ -0,0 +1,97 @@
+package boardtest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type BoardSuite struct{}
+
+var _ = Suite(&BoardSuite{})
+
+func (s *BoardSuite) TestGrid(c *C) {
+	grid, err := Grid(Example)
+	c.Assert(err, IsNil)
+	c.Check(grid, Equals, "*212*\n23*21\n1*210\n11211\n001*1\n")
+
+	grid, err = Grid("\n\t\t*1\n\t\t11\n")
+	c.Assert(err, IsNil)
+	c.Check(grid, Equals, "*1\n11\n")
+
+	for _, bad := range []string{"", " --- ", "| * | 10 |"} {
+		_, err := Grid(bad)
+		c.Check(err, Equals, gominesweeper.ErrBadBoard, Commentf("diagram %q", bad))
+	}
+}
+
+func (s *BoardSuite) TestConfig(c *C) {
+	cfg, err := Config(Example)
+	c.Assert(err, IsNil)
+	c.Check([]uint{cfg.Width, cfg.Height, cfg.Mines}, DeepEquals, []uint{5, 5, 5})
+	mines, err := cfg.Selector(5, 5, 5)
+	c.Assert(err, IsNil)
+	c.Check(mines, DeepEquals, []gominesweeper.Position{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 4}})
+
+	_, err = Config("*2\n11")
+	c.Check(err, Equals, gominesweeper.ErrBadProximity)
+
+	// every golden minefield is consistent
+	for name, diagram := range Golden {
+		_, err := Config(diagram)
+		c.Check(err, IsNil, Commentf("golden %s", name))
+	}
+}
+
+func (s *BoardSuite) TestGame(c *C) {
+	game := Game(c, Golden["open"], gominesweeper.Config{Lives: 2})
+	c.Check(game.Lives(), Equals, uint(2))
+	_, err := game.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(game.State(), Equals, gominesweeper.Won)
+
+	var board strings.Builder
+	c.Assert(gominesweeper.WriteBoard(&board, Minefield(c, Example)), IsNil)
+	c.Check(board.String(), Equals, "*212*\n23*21\n1*21.\n11211\n..1*1\n")
+}
+
+func (s *BoardSuite) TestFromBytes(c *C) {
+	cfg := FromBytes([]byte{2, 1, 0x05})
+	c.Check([]uint{cfg.Width, cfg.Height, cfg.Mines}, DeepEquals, []uint{3, 2, 2})
+	mines, err := cfg.Selector(3, 2, 2)
+	c.Assert(err, IsNil)
+	c.Check(mines, DeepEquals, []gominesweeper.Position{{X: 0, Y: 0}, {X: 2, Y: 0}})
+
+	cfg = FromBytes(nil)
+	c.Check([]uint{cfg.Width, cfg.Height, cfg.Mines}, DeepEquals, []uint{1, 1, 0})
+}
+
+func FuzzFromBytes(f *testing.F) {
+	f.Add([]byte{4, 4, 0x21, 0x84})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// every input is a minefield, which reads back from its diagram
+		cfg := FromBytes(data)
+		mf, err := gominesweeper.NewMinefieldConfig(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var board strings.Builder
+		if err := gominesweeper.WriteBoard(&board, mf); err != nil {
+			t.Fatal(err)
+		}
+		again, err := Config(board.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := cfg.Selector(cfg.Width, cfg.Height, cfg.Mines)
+		got, _ := again.Selector(again.Width, again.Height, again.Mines)
+		if again.Width != cfg.Width || again.Height != cfg.Height || !reflect.DeepEqual(got, want) {
+			t.Fatalf("board %q read back as %v, want %v", board.String(), got, want)
+		}
+	})
+}
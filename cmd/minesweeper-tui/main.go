@@ -0,0 +1,109 @@
+// Command minesweeper-tui plays minesweeper full screen in the terminal.
+//
+// Usage:
+//
+//	minesweeper-tui [-rules NAME | -width W -height H -mines M] [-theme THEME]
+//
+// Click a block to reveal it, or a revealed number to chord it, and
+// right-click to flag it; shift right-click marks it with a question mark.
+// The arrow keys or hjkl move the cursor, space reveals or chords, f flags,
+// ? marks, p pauses, n starts a new game and q quits.  Boards larger than the
+// terminal scroll to follow the cursor, and the mouse wheel scrolls them.  The
+// status bar shows the mines left, the time and the lives.
+//
+// The themes are classic, dark and mono.  The screen is drawn with tcell, so it
+// runs in the terminals of every platform that tcell supports.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+// run plays a game described by the arguments and returns the exit code.
+func run(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("minesweeper-tui", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	rules := fs.String("rules", string(gominesweeper.Beginner), "the registered rules to play")
+	width := fs.Uint("width", 0, "the width of a custom board")
+	height := fs.Uint("height", 0, "the height of a custom board")
+	mines := fs.Uint("mines", 0, "the mines of a custom board")
+	themeName := fs.String("theme", "classic", "the colors: classic, dark or mono")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := gominesweeper.Preset(*rules).Config()
+	if *width > 0 || *height > 0 || *mines > 0 {
+		cfg, err = gominesweeper.Config{Width: *width, Height: *height, Mines: *mines}, nil
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "minesweeper-tui:", err)
+		return 2
+	}
+	theme, ok := themes[*themeName]
+	if !ok {
+		fmt.Fprintf(stderr, "minesweeper-tui: unknown theme %q\n", *themeName)
+		return 2
+	}
+
+	u, err := newUI(cfg, theme)
+	if err == nil {
+		err = play(u)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "minesweeper-tui:", err)
+		return 1
+	}
+	return 0
+}
+
+// play runs the ui on the terminal's screen until the player quits.
+func play(u *ui) error {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return err
+	}
+	if err := screen.Init(); err != nil {
+		return err
+	}
+	defer screen.Fini()
+	screen.EnableMouse()
+	screen.HideCursor()
+
+	events := make(chan tcell.Event)
+	quit := make(chan struct{})
+	defer close(quit)
+	go screen.ChannelEvents(events, quit)
+	ticker := time.NewTicker(time.Second / 4)
+	defer ticker.Stop()
+
+	u.resize(screen.Size())
+	for {
+		u.draw(screen)
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if _, ok := ev.(*tcell.EventResize); ok {
+				u.resize(screen.Size())
+				screen.Sync()
+			} else if u.handle(ev) {
+				return nil
+			}
+		case <-ticker.C:
+			u.game.Tick()
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+// cellWidth is the columns taken by each block, so that the board looks
+// square.
+const cellWidth = 2
+
+// theme holds the styles that color the board.
+type theme struct {
+	// hidden and open are the styles of hidden and revealed blocks, which the
+	// colors of the rest are drawn over.
+	hidden, open tcell.Style
+
+	flag, mine tcell.Color
+	exploded   tcell.Style
+
+	// numbers are the colors of each proximity, with 8 used for any more.
+	numbers [9]tcell.Color
+
+	status tcell.Style
+}
+
+var themes = map[string]theme{
+	"classic": {
+		hidden:   tcell.StyleDefault.Foreground(tcell.ColorSilver).Background(tcell.ColorGray),
+		open:     tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorSilver),
+		flag:     tcell.ColorRed,
+		mine:     tcell.ColorBlack,
+		exploded: tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorMaroon),
+		numbers: [9]tcell.Color{tcell.ColorDefault, tcell.ColorNavy, tcell.ColorGreen, tcell.ColorMaroon,
+			tcell.ColorPurple, tcell.ColorOlive, tcell.ColorTeal, tcell.ColorBlack, tcell.ColorGray},
+		status: tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorSilver),
+	},
+	"dark": {
+		hidden:   tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorBlack),
+		open:     tcell.StyleDefault.Foreground(tcell.ColorSilver).Background(tcell.ColorBlack),
+		flag:     tcell.ColorRed,
+		mine:     tcell.ColorWhite,
+		exploded: tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorMaroon),
+		numbers: [9]tcell.Color{tcell.ColorDefault, tcell.ColorBlue, tcell.ColorLime, tcell.ColorRed,
+			tcell.ColorFuchsia, tcell.ColorYellow, tcell.ColorAqua, tcell.ColorWhite, tcell.ColorSilver},
+		status: tcell.StyleDefault.Reverse(true),
+	},
+	"mono": {
+		status: tcell.StyleDefault.Reverse(true),
+	},
+}
+
+// cell returns the glyph and style of a block's value.
+func (t theme) cell(value int) (rune, tcell.Style) {
+	switch {
+	case value == gominesweeper.Unknown:
+		return '■', t.hidden
+	case value == gominesweeper.Questioned:
+		return '?', t.hidden
+	case value == gominesweeper.Flagged:
+		return '⚑', color(t.hidden, t.flag)
+	case value == gominesweeper.Mine:
+		return '*', color(t.open, t.mine)
+	case value == gominesweeper.Exploded:
+		return '*', t.exploded
+	case value == gominesweeper.WrongFlag:
+		return 'x', color(t.open, t.flag)
+	case value == gominesweeper.Defused:
+		return '✓', color(t.open, t.mine)
+	case value == gominesweeper.Revealed:
+		return '•', t.open
+	case value == 0:
+		return ' ', t.open
+	case value > 9:
+		return '+', color(t.open, t.numbers[8])
+	}
+	return rune('0' + value), color(t.open, t.numbers[min(value, 8)])
+}
+
+// color returns the style with the foreground color, unless it is the
+// default.
+func color(style tcell.Style, fg tcell.Color) tcell.Style {
+	if fg == tcell.ColorDefault {
+		return style
+	}
+	return style.Foreground(fg)
+}
+
+// ui is a game shown in a terminal of some size, through a viewport that
+// follows the cursor.
+type ui struct {
+	cfg   gominesweeper.Config
+	game  *gominesweeper.Game
+	theme theme
+
+	cols, rows     int
+	origin, cursor gominesweeper.Position
+
+	// message is the error of the last move, shown until the next event
+	message string
+
+	// held are the buttons that were down at the last mouse event, so that a
+	// click is only acted on when it is pressed and not while it is dragged.
+	held tcell.ButtonMask
+}
+
+// newUI starts a game of the config.
+func newUI(cfg gominesweeper.Config, theme theme) (*ui, error) {
+	game, err := gominesweeper.NewGame(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ui{cfg: cfg, game: game, theme: theme, cols: 80, rows: 24}, nil
+}
+
+// resize sets the size of the terminal.
+func (u *ui) resize(cols, rows int) {
+	u.cols, u.rows = cols, rows
+	u.follow()
+}
+
+// view returns the blocks that fit across and down the terminal, leaving the
+// last row for the status bar.
+func (u *ui) view() (width, height int) {
+	return max(1, u.cols/cellWidth), max(1, u.rows-1)
+}
+
+// handle acts on a key press or mouse event and reports whether to quit.
+// Other events are ignored.
+func (u *ui) handle(ev tcell.Event) bool {
+	switch ev := ev.(type) {
+	case *tcell.EventKey:
+		u.message = ""
+		return u.key(ev)
+	case *tcell.EventMouse:
+		u.mouse(ev)
+	}
+	return false
+}
+
+// key acts on a key press and reports whether to quit.
+func (u *ui) key(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyCtrlC:
+		return true
+	case tcell.KeyUp:
+		u.moveCursor(0, -1)
+	case tcell.KeyDown:
+		u.moveCursor(0, 1)
+	case tcell.KeyLeft:
+		u.moveCursor(-1, 0)
+	case tcell.KeyRight:
+		u.moveCursor(1, 0)
+	case tcell.KeyEnter:
+		u.open(u.cursor)
+	case tcell.KeyRune:
+		return u.command(ev.Rune())
+	}
+	return false
+}
+
+// command acts on a key typed as a rune and reports whether to quit.
+func (u *ui) command(r rune) bool {
+	switch r {
+	case 'q':
+		return true
+	case 'k':
+		u.moveCursor(0, -1)
+	case 'j':
+		u.moveCursor(0, 1)
+	case 'h':
+		u.moveCursor(-1, 0)
+	case 'l':
+		u.moveCursor(1, 0)
+	case ' ':
+		u.open(u.cursor)
+	case 'f':
+		u.apply(gominesweeper.Flag, u.cursor)
+	case '?':
+		u.apply(gominesweeper.Question, u.cursor)
+	case 'p':
+		u.pause()
+	case 'n':
+		game, err := gominesweeper.NewGame(u.cfg)
+		if err != nil {
+			u.message = err.Error()
+			break
+		}
+		u.game = game
+	}
+	return false
+}
+
+// mouse acts on the buttons of a mouse event that have just been pressed:
+// the wheel scrolls, and a click on the board opens, chords, flags or
+// questions the block under it.
+func (u *ui) mouse(ev *tcell.EventMouse) {
+	pressed := ev.Buttons() &^ u.held
+	u.held = ev.Buttons() & (tcell.Button1 | tcell.Button2 | tcell.Button3)
+	if pressed == tcell.ButtonNone {
+		return
+	}
+	u.message = ""
+	switch {
+	case pressed&tcell.WheelUp != 0:
+		u.scroll(-3)
+		return
+	case pressed&tcell.WheelDown != 0:
+		u.scroll(3)
+		return
+	}
+
+	col, row := ev.Position()
+	_, height := u.view()
+	if row < 0 || row >= height {
+		return
+	}
+	pos := gominesweeper.Position{
+		X: u.origin.X + col/cellWidth,
+		Y: u.origin.Y + row,
+	}
+	if pos.X >= int(u.cfg.Width) || pos.Y >= int(u.cfg.Height) {
+		return
+	}
+	u.cursor = pos
+	switch {
+	case pressed&tcell.Button1 != 0:
+		u.open(pos)
+	case pressed&tcell.Button3 != 0:
+		u.apply(gominesweeper.Chord, pos)
+	case pressed&tcell.Button2 != 0 && ev.Modifiers()&tcell.ModShift != 0:
+		u.apply(gominesweeper.Question, pos)
+	case pressed&tcell.Button2 != 0:
+		u.apply(gominesweeper.Flag, pos)
+	}
+}
+
+// open reveals the block at the position if it is hidden, or chords it if it
+// has been revealed.
+func (u *ui) open(pos gominesweeper.Position) {
+	switch u.game.Display()[pos] {
+	case gominesweeper.Unknown, gominesweeper.Questioned, gominesweeper.Flagged:
+		u.apply(gominesweeper.Reveal, pos)
+	default:
+		u.apply(gominesweeper.Chord, pos)
+	}
+}
+
+// apply makes a move, keeping its error as the message.
+func (u *ui) apply(action gominesweeper.Action, pos gominesweeper.Position) {
+	if _, err := u.game.Apply(gominesweeper.Move{Action: action, Position: pos}); err != nil {
+		u.message = err.Error()
+	}
+}
+
+// pause pauses the game, or resumes it if it is paused.
+func (u *ui) pause() {
+	if u.game.Paused() {
+		u.game.Resume()
+	} else if err := u.game.Pause(); err != nil {
+		u.message = err.Error()
+	}
+}
+
+// moveCursor moves the cursor within the board and scrolls to it.
+func (u *ui) moveCursor(dx, dy int) {
+	u.cursor.X = min(max(u.cursor.X+dx, 0), int(u.cfg.Width)-1)
+	u.cursor.Y = min(max(u.cursor.Y+dy, 0), int(u.cfg.Height)-1)
+	u.follow()
+}
+
+// follow scrolls the viewport to the cursor.
+func (u *ui) follow() {
+	width, height := u.view()
+	u.origin.X = min(u.origin.X, u.cursor.X)
+	u.origin.X = max(u.origin.X, u.cursor.X-width+1)
+	u.origin.Y = min(u.origin.Y, u.cursor.Y)
+	u.origin.Y = max(u.origin.Y, u.cursor.Y-height+1)
+	u.clamp()
+}
+
+// scroll moves the viewport down by the rows, or up if they are negative.
+func (u *ui) scroll(rows int) {
+	u.origin.Y += rows
+	u.clamp()
+}
+
+// clamp keeps the viewport on the board.
+func (u *ui) clamp() {
+	width, height := u.view()
+	u.origin.X = max(min(u.origin.X, int(u.cfg.Width)-width), 0)
+	u.origin.Y = max(min(u.origin.Y, int(u.cfg.Height)-height), 0)
+}
+
+// draw draws the viewport of the board and the status bar on the screen.
+func (u *ui) draw(screen tcell.Screen) {
+	screen.Clear()
+	display := u.game.Display()
+	width, height := u.view()
+	for y := u.origin.Y; y < min(u.origin.Y+height, int(u.cfg.Height)); y++ {
+		for x := u.origin.X; x < min(u.origin.X+width, int(u.cfg.Width)); x++ {
+			pos := gominesweeper.Position{X: x, Y: y}
+			glyph, style := u.theme.cell(display[pos])
+			if pos == u.cursor {
+				style = style.Reverse(true)
+			}
+			col, row := (x-u.origin.X)*cellWidth, y-u.origin.Y
+			screen.SetContent(col, row, glyph, nil, style)
+			screen.SetContent(col+1, row, ' ', nil, style)
+		}
+	}
+	text := []rune(fmt.Sprintf("%-*s", u.cols, u.status(display)))
+	for col := 0; col < u.cols; col++ {
+		screen.SetContent(col, u.rows-1, text[col], nil, u.theme.status)
+	}
+	screen.Show()
+}
+
+// status returns the text of the status bar: the mines left, the time, the
+// lives and either the message or the state of the game.
+func (u *ui) status(display map[gominesweeper.Position]int) string {
+	flags := 0
+	for _, value := range display {
+		if value == gominesweeper.Flagged {
+			flags++
+		}
+	}
+	state := u.game.State().String()
+	if u.message != "" {
+		state = u.message
+	} else if u.game.Paused() {
+		state = "paused"
+	}
+	text := fmt.Sprintf(" mines %d  time %s  lives %d  %s", int(u.cfg.Mines)-flags,
+		u.game.Elapsed().Truncate(time.Second), u.game.Lives(), state)
+	if len(text) > u.cols {
+		text = text[:u.cols]
+	}
+	return text
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type TUISuite struct{}
+
+var _ = Suite(&TUISuite{})
+
+// newTestUI starts a ui on the 5x5 board with mines at {0,0}, {4,0}, {2,1},
+// {1,2} and {3,4}.
+func newTestUI(c *C, cols, rows int) *ui {
+	mines := []gominesweeper.Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}
+	cfg := gominesweeper.Config{Width: 5, Height: 5, Mines: 5, Selector: func(width, height, max uint) ([]gominesweeper.Position, error) {
+		return mines, nil
+	}}
+	u, err := newUI(cfg, themes["mono"])
+	c.Assert(err, IsNil)
+	u.resize(cols, rows)
+	return u
+}
+
+// press returns the event of typing the rune.
+func press(r rune) *tcell.EventKey {
+	return tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)
+}
+
+// click returns the event of pressing the buttons at a column and row of the
+// screen.
+func click(col, row int, buttons tcell.ButtonMask) *tcell.EventMouse {
+	return tcell.NewEventMouse(col, row, buttons, tcell.ModNone)
+}
+
+func (s *TUISuite) TestHandle(c *C) {
+	u := newTestUI(c, 80, 24)
+
+	// click the 0 at {4,2}, two columns per block and the board from row 0
+	c.Check(u.handle(click(8, 2, tcell.Button1)), Equals, false)
+	c.Check(u.cursor, Equals, gominesweeper.Position{X: 4, Y: 2})
+	c.Check(u.game.Display()[gominesweeper.Position{X: 4, Y: 3}], Equals, 1)
+
+	// a click is acted on when it is pressed, not while it is dragged or
+	// released
+	u.handle(click(6, 2, tcell.Button1))
+	u.handle(click(6, 2, tcell.ButtonNone))
+	c.Check(u.cursor, Equals, gominesweeper.Position{X: 4, Y: 2})
+
+	// flag the mine at {3,4} with the keys, then chord the 1 at {4,3}
+	u.handle(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	u.handle(press('j'))
+	u.handle(press('h'))
+	u.handle(press('f'))
+	c.Check(u.game.Display()[gominesweeper.Position{X: 3, Y: 4}], Equals, gominesweeper.Flagged)
+	u.handle(click(8, 3, tcell.Button1))
+	u.handle(click(8, 3, tcell.ButtonNone))
+	c.Check(u.game.Display()[gominesweeper.Position{X: 4, Y: 4}], Equals, 1)
+
+	u.handle(tcell.NewEventMouse(0, 4, tcell.Button2, tcell.ModShift))
+	c.Check(u.game.Display()[gominesweeper.Position{X: 0, Y: 4}], Equals, gominesweeper.Questioned)
+
+	// the error of a move is shown until the next event
+	u.handle(click(4, 1, tcell.Button1))
+	u.handle(click(4, 1, tcell.ButtonNone))
+	c.Check(u.game.State(), Equals, gominesweeper.Lost)
+	u.handle(press('f'))
+	c.Check(u.message, Equals, gominesweeper.ErrGameOver.Error())
+	u.handle(press('x'))
+	c.Check(u.message, Equals, "")
+
+	c.Check(u.handle(tcell.NewEventKey(tcell.KeyF1, 0, tcell.ModNone)), Equals, false)
+	c.Check(u.handle(press('q')), Equals, true)
+	c.Check(u.handle(tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModCtrl)), Equals, true)
+}
+
+func (s *TUISuite) TestViewport(c *C) {
+	// three blocks across and two down, with the status bar
+	u := newTestUI(c, 7, 3)
+	c.Check(u.origin, Equals, gominesweeper.Position{})
+
+	for i := 0; i < 4; i++ {
+		u.handle(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone))
+		u.handle(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	}
+	c.Check(u.cursor, Equals, gominesweeper.Position{X: 4, Y: 4})
+	c.Check(u.origin, Equals, gominesweeper.Position{X: 2, Y: 3})
+
+	u.handle(click(0, 0, tcell.WheelUp))
+	c.Check(u.origin, Equals, gominesweeper.Position{X: 2, Y: 0})
+
+	// growing the terminal shows the whole board
+	u.resize(80, 24)
+	c.Check(u.origin, Equals, gominesweeper.Position{})
+}
+
+func (s *TUISuite) TestDraw(c *C) {
+	u := newTestUI(c, 60, 8)
+	u.handle(click(8, 2, tcell.Button1))
+	u.handle(press('f'))
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	c.Assert(screen.Init(), IsNil)
+	defer screen.Fini()
+	screen.SetSize(60, 8)
+	u.draw(screen)
+	c.Check(contents(screen, 4), Equals, "■ ■ ■ ■ ■")
+	c.Check(contents(screen, 5), Equals, "")
+	c.Check(contents(screen, 7), Equals, " mines 5  time 0s  lives 1  playing")
+
+	// the cursor is drawn in reverse
+	_, _, style, _ := screen.GetContent(8, 2)
+	_, _, attrs := style.Decompose()
+	c.Check(attrs&tcell.AttrReverse, Not(Equals), tcell.AttrMask(0))
+
+	u.handle(click(0, 0, tcell.Button2))
+	u.draw(screen)
+	c.Check(contents(screen, 0), Matches, "⚑ ■ ■ .*")
+	c.Check(contents(screen, 7), Matches, " mines 4 .*")
+}
+
+// contents returns the text of a row of the screen, without trailing spaces.
+func contents(screen tcell.SimulationScreen, row int) string {
+	cells, width, _ := screen.GetContents()
+	var b strings.Builder
+	for _, cell := range cells[row*width : (row+1)*width] {
+		b.WriteString(string(cell.Runes))
+	}
+	return strings.TrimRight(b.String(), " ")
+}
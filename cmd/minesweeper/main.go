@@ -0,0 +1,108 @@
+// Command minesweeper works with minesweeper games from the command line.
+//
+// Usage:
+//
+//	minesweeper verify ARCHIVE...
+//	minesweeper serve [-addr ADDR]
+//
+// verify checks that each archive is intact: that its board matches its rules,
+// that its moves give its result and that its signatures are valid.
+//
+// serve serves games over HTTP, along with a browser client to play them at
+// the root, on localhost:8080 unless another address is given.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	"github.com/smousa/go-minesweeper/server"
+)
+
+const usage = "usage: minesweeper verify ARCHIVE...\n       minesweeper serve [-addr ADDR]"
+
+// listen serves the handler at the address; it is replaced in tests.
+var listen = http.ListenAndServe
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the subcommand named by the first argument and returns the exit
+// code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "verify":
+		return verify(args[1:], stdout, stderr)
+	case "serve":
+		return serve(args[1:], stdout, stderr)
+	}
+	fmt.Fprintf(stderr, "minesweeper: unknown command %q\n", args[0])
+	return 2
+}
+
+// verify verifies each archive, reporting whether it is intact.
+func verify(paths []string, stdout, stderr io.Writer) int {
+	if len(paths) == 0 {
+		fmt.Fprintln(stderr, "usage: minesweeper verify ARCHIVE...")
+		return 2
+	}
+	code := 0
+	for _, path := range paths {
+		if err := verifyFile(path); err != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", path, err)
+			code = 1
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: ok\n", path)
+	}
+	return code
+}
+
+// verifyFile reads and verifies the archive at the path.
+func verifyFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	archive, err := gominesweeper.ReadArchive(f)
+	if err != nil {
+		return err
+	}
+	return archive.Verify()
+}
+
+// serve serves the games and the demo client until the server fails.
+func serve(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+
+	games := server.New()
+	mux := http.NewServeMux()
+	mux.Handle("/games", games)
+	mux.Handle("/games/", games)
+	mux.Handle("/profiles/", games)
+	mux.Handle("/", server.Demo())
+
+	fmt.Fprintf(stdout, "serving on http://%s\n", *addr)
+	if err := listen(*addr, mux); err != nil {
+		fmt.Fprintf(stderr, "minesweeper: %v\n", err)
+		return 1
+	}
+	return 0
+}
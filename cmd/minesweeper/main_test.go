@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type CmdSuite struct{}
+
+var _ = Suite(&CmdSuite{})
+
+func (s *CmdSuite) TestVerify(c *C) {
+	game, err := gominesweeper.NewGame(gominesweeper.Config{Width: 5, Height: 5, Mines: 5, Selector: gominesweeper.SeededSelector(42)})
+	c.Assert(err, IsNil)
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+	archive, err := game.Archive()
+	c.Assert(err, IsNil)
+
+	dir := c.MkDir()
+	good := filepath.Join(dir, "good.json")
+	var buf bytes.Buffer
+	c.Assert(archive.Write(&buf), IsNil)
+	c.Assert(os.WriteFile(good, buf.Bytes(), 0644), IsNil)
+
+	// a tampered result fails
+	bad := filepath.Join(dir, "bad.json")
+	archive.Result.Lives++
+	buf.Reset()
+	c.Assert(archive.Write(&buf), IsNil)
+	c.Assert(os.WriteFile(bad, buf.Bytes(), 0644), IsNil)
+
+	var stdout, stderr bytes.Buffer
+	c.Check(run([]string{"verify", good}, &stdout, &stderr), Equals, 0)
+	c.Check(stdout.String(), Equals, good+": ok\n")
+
+	stdout.Reset()
+	c.Check(run([]string{"verify", good, bad}, &stdout, &stderr), Equals, 1)
+	c.Check(stdout.String(), Equals, good+": ok\n"+bad+": "+gominesweeper.ErrResultMismatch.Error()+"\n")
+
+	c.Check(run([]string{"bogus"}, &stdout, &stderr), Equals, 2)
+}
+
+func (s *CmdSuite) TestServe(c *C) {
+	var handler http.Handler
+	defer func(orig func(string, http.Handler) error) { listen = orig }(listen)
+	listen = func(addr string, h http.Handler) error {
+		c.Check(addr, Equals, "localhost:9000")
+		handler = h
+		return nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	c.Assert(run([]string{"serve", "-addr", "localhost:9000"}, &stdout, &stderr), Equals, 0)
+	c.Check(stdout.String(), Equals, "serving on http://localhost:9000\n")
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/")
+	c.Assert(err, IsNil)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	c.Assert(err, IsNil)
+	c.Check(resp.StatusCode, Equals, http.StatusOK)
+	c.Check(strings.Contains(string(body), "<title>Minesweeper</title>"), Equals, true)
+
+	resp, err = http.Post(srv.URL+"/games", "application/json", strings.NewReader(`{"rules": "beginner"}`))
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	c.Check(resp.StatusCode, Equals, http.StatusCreated)
+
+	c.Check(run([]string{"serve", "extra"}, &stdout, &stderr), Equals, 2)
+}
@@ -0,0 +1,93 @@
+// Package compat keeps the original Minefield API of go-minesweeper working on
+// top of the current one, so that code written against it can move over a
+// piece at a time.  Core returns the current Minefield behind a compat
+// Minefield, to use alongside the old methods while upgrading.
+//
+// Deprecated: use the gominesweeper package directly.  Minefield.Select there
+// returns a SelectResult, and Game plays by rules such as lives and win
+// conditions.
+package compat
+
+import (
+	"errors"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+// Position represents an point on the X,Y axis.
+//
+// Deprecated: use gominesweeper.Position.
+type Position = gominesweeper.Position
+
+// The values returned by Select and shown by Display besides proximities.
+//
+// Deprecated: use the constants of the gominesweeper package.
+const (
+	Mine    = gominesweeper.Mine
+	Flagged = gominesweeper.Flagged
+	Checked = gominesweeper.Checked
+	Unknown = gominesweeper.Unknown
+)
+
+// Minefield describes the layout of all the blocks.
+//
+// Deprecated: use gominesweeper.Minefield.
+type Minefield struct {
+	core *gominesweeper.Minefield
+}
+
+// NewMinefield generates a new minefield using the random mine selector.
+//
+// Deprecated: use gominesweeper.NewMinefield.
+func NewMinefield(width, height, mines uint) (Minefield, error) {
+	core, err := gominesweeper.NewMinefield(width, height, mines)
+	if err != nil {
+		return Minefield{}, err
+	}
+	return Minefield{core}, nil
+}
+
+// Core returns the minefield being played.
+func (mf Minefield) Core() *gominesweeper.Minefield {
+	return mf.core
+}
+
+// Select will select an individual block and return the proximity to its
+// neighboring mines.  If the proximity is 0, then Select will recursively
+// reveal its neighbors as well.  It returns Checked if the block was already
+// revealed and Flagged if it is flagged, and the bare ErrOutOfBounds for a
+// position off the minefield, so that it can be compared with ==.
+//
+// Deprecated: use gominesweeper.Minefield.Select, which reports what was
+// revealed as a SelectResult.
+func (mf Minefield) Select(x, y int) (int, error) {
+	result, err := mf.core.Select(x, y)
+	if errors.Is(err, gominesweeper.ErrOutOfBounds) {
+		return 0, gominesweeper.ErrOutOfBounds
+	} else if err != nil {
+		return 0, err
+	}
+	switch result.Kind {
+	case gominesweeper.SelectMine:
+		return Mine, nil
+	case gominesweeper.SelectBlocked:
+		return Flagged, nil
+	case gominesweeper.SelectAlreadyRevealed:
+		return Checked, nil
+	}
+	return result.Value, nil
+}
+
+// ToggleFlag toggles the flag on a particular mine.
+//
+// Deprecated: use gominesweeper.Minefield.ToggleFlag.
+func (mf Minefield) ToggleFlag(x, y int) {
+	mf.core.ToggleFlag(x, y)
+}
+
+// Display returns the current state of all the blocks.
+//
+// Deprecated: use gominesweeper.Minefield.Display.
+func (mf Minefield) Display() map[Position]int {
+	return mf.core.Display()
+}
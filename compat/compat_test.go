@@ -0,0 +1,48 @@
+package compat
+
+import (
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type CompatSuite struct{}
+
+var _ = Suite(&CompatSuite{})
+
+func (s *CompatSuite) TestMinefield(c *C) {
+	mf, err := NewMinefield(5, 5, 5)
+	c.Assert(err, IsNil)
+	c.Check(mf.Display(), HasLen, 25)
+	_, err = NewMinefield(2, 2, 4)
+	c.Check(err, Equals, gominesweeper.ErrExceedDimensions)
+
+	core, err := gominesweeper.FromLayout(5, 5, []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}})
+	c.Assert(err, IsNil)
+	mf = Minefield{core}
+	c.Check(mf.Core(), Equals, core)
+
+	proximity, err := mf.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, 0)
+	proximity, err = mf.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, Checked)
+
+	mf.ToggleFlag(0, 4)
+	proximity, err = mf.Select(0, 4)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, Flagged)
+	c.Check(mf.Display()[Position{X: 0, Y: 4}], Equals, Flagged)
+
+	_, err = mf.Select(9, 9)
+	c.Check(err, Equals, gominesweeper.ErrOutOfBounds)
+
+	proximity, err = mf.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, Mine)
+	c.Check(mf.Display()[Position{X: 4, Y: 0}], Equals, Mine)
+}
@@ -0,0 +1,75 @@
+package gominesweeper
+
+import (
+	"github.com/smousa/go-minesweeper/minegen"
+)
+
+// PlacementRule decides whether a mine may be placed at the position, given
+// the mines placed so far.
+type PlacementRule = minegen.PlacementRule
+
+// NoClusters forbids any 2x2 square made up entirely of mines.
+func NoClusters() PlacementRule {
+	return minegen.NoClusters()
+}
+
+// MaxPerRow allows at most n mines in any row.
+func MaxPerRow(n int) PlacementRule {
+	return minegen.MaxPerRow(n)
+}
+
+// MaxPerColumn allows at most n mines in any column.
+func MaxPerColumn(n int) PlacementRule {
+	return minegen.MaxPerColumn(n)
+}
+
+// MinSpacing keeps mines at least d blocks apart in either direction, so that
+// d of 2 leaves every mine without a neighboring mine.
+func MinSpacing(d int) PlacementRule {
+	return minegen.MinSpacing(d)
+}
+
+// Symmetry mirrors the mines of a board, so that every mine has a mine at each
+// of its images.
+type Symmetry = minegen.Symmetry
+
+const (
+	// NoSymmetry places every mine on its own.
+	NoSymmetry = minegen.NoSymmetry
+
+	// Horizontal mirrors the mines from left to right.
+	Horizontal = minegen.Horizontal
+
+	// Vertical mirrors the mines from top to bottom.
+	Vertical = minegen.Vertical
+
+	// Rotational turns the mines half way around the center of the board.
+	Rotational = minegen.Rotational
+)
+
+// ConstrainedSelector returns a mine selector that places the mines at random,
+// as determined by the seed, while following every rule.  It returns
+// ErrUnplaceable if it cannot find such a placement.
+func ConstrainedSelector(seed int64, rules ...PlacementRule) Selector {
+	return minegen.Constrained(seed, rules...)
+}
+
+// ConstrainedSelectorFrom returns a mine selector like ConstrainedSelector
+// that draws from the source instead of a seed.
+func ConstrainedSelectorFrom(src RandSource, rules ...PlacementRule) Selector {
+	return minegen.ConstrainedFrom(src, rules...)
+}
+
+// SymmetricSelector returns a mine selector like ConstrainedSelector that
+// places the mines with the symmetry, so that every image of a mine follows
+// the rules too.  A board with no block that mirrors itself can only hold an
+// even number of mines.
+func SymmetricSelector(seed int64, symmetry Symmetry, rules ...PlacementRule) Selector {
+	return minegen.Symmetric(seed, symmetry, rules...)
+}
+
+// SymmetricSelectorFrom returns a mine selector like SymmetricSelector that
+// draws from the source instead of a seed.
+func SymmetricSelectorFrom(src RandSource, symmetry Symmetry, rules ...PlacementRule) Selector {
+	return minegen.SymmetricFrom(src, symmetry, rules...)
+}
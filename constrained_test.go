@@ -0,0 +1,30 @@
+package gominesweeper
+
+import (
+	"github.com/smousa/go-minesweeper/minegen"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestConstrainedSelector(c *C) {
+	// the mines are placed by the minegen package
+	want, err := minegen.Constrained(1, MaxPerRow(2), MaxPerColumn(2))(5, 5, 8)
+	c.Assert(err, IsNil)
+	got, err := ConstrainedSelector(1, MaxPerRow(2), MaxPerColumn(2))(5, 5, 8)
+	c.Assert(err, IsNil)
+	c.Check(got, DeepEquals, want)
+
+	// usable through the config
+	minefield, err := NewMinefieldConfig(Config{Width: 5, Height: 5, Mines: 12, Selector: ConstrainedSelector(3, NoClusters())})
+	c.Assert(err, IsNil)
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			mines := 0
+			for _, pos := range []Position{{X: x, Y: y}, {X: x + 1, Y: y}, {X: x, Y: y + 1}, {X: x + 1, Y: y + 1}} {
+				if block, _ := minefield.peek(pos); block.proximity() == Mine {
+					mines++
+				}
+			}
+			c.Check(mines < 4, Equals, true)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package gominesweeper
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+// The Monte Carlo estimates of the corpus are made with corpusSeed and
+// corpusIterations, and must be within corpusTolerance of the expected odds.
+const (
+	corpusSeed       = 1
+	corpusIterations = 20000
+	corpusTolerance  = 0.05
+)
+
+// position is a canonical solver position from testdata/solver.txt.
+type position struct {
+	name        string
+	snapshot    Snapshot
+	safe, mines []Position
+	risks       map[Position]float64
+}
+
+// readCorpus reads the canonical solver positions.
+func readCorpus(c *C) []position {
+	f, err := os.Open("testdata/solver.txt")
+	c.Assert(err, IsNil)
+	defer f.Close()
+
+	var corpus []position
+	var p *position
+	var rows []string
+	finish := func() {
+		if p == nil {
+			return
+		}
+		p.snapshot.Height, p.snapshot.Width = len(rows), len(rows[0])
+		for y, row := range rows {
+			for x, char := range row {
+				value := Unknown
+				switch {
+				case char == 'F':
+					value = Flagged
+				case char == '.':
+					value = 0
+				case char >= '0' && char <= '9':
+					value = int(char - '0')
+				}
+				p.snapshot.Blocks[Position{X: x, Y: y}] = value
+			}
+		}
+		corpus = append(corpus, *p)
+		p, rows = nil, nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		key, value, isHeader := strings.Cut(line, ": ")
+		switch {
+		case strings.HasPrefix(line, "#") && p == nil, line == "":
+			finish()
+		case isHeader:
+			if p == nil {
+				p = &position{
+					snapshot: Snapshot{Neighborhood: Surrounding, Blocks: make(map[Position]int)},
+					risks:    make(map[Position]float64),
+				}
+			}
+			c.Assert(p.header(key, value), IsNil, Commentf("line %q", line))
+		default:
+			rows = append(rows, line)
+		}
+	}
+	c.Assert(scanner.Err(), IsNil)
+	finish()
+	return corpus
+}
+
+// header sets the value of a header line of the position.
+func (p *position) header(key, value string) error {
+	switch key {
+	case "name":
+		p.name = value
+	case "mines":
+		mines, err := strconv.Atoi(value)
+		p.snapshot.Mines = mines
+		return err
+	case "safe", "mine":
+		for _, field := range strings.Fields(value) {
+			var pos Position
+			if _, err := fmt.Sscanf(field, "%d,%d", &pos.X, &pos.Y); err != nil {
+				return err
+			}
+			if key == "safe" {
+				p.safe = append(p.safe, pos)
+			} else {
+				p.mines = append(p.mines, pos)
+			}
+		}
+	case "risk":
+		for _, field := range strings.Fields(value) {
+			var pos Position
+			var risk float64
+			if _, err := fmt.Sscanf(field, "%d,%d=%g", &pos.X, &pos.Y, &risk); err != nil {
+				return err
+			}
+			p.risks[pos] = risk
+		}
+	default:
+		return ErrUnknownName
+	}
+	return nil
+}
+
+func (s *MSSuite) TestSolver_Corpus(c *C) {
+	corpus := readCorpus(c)
+	c.Assert(len(corpus) > 0, Equals, true)
+	for _, p := range corpus {
+		solver := NewSolver(p.snapshot)
+		c.Check(solver.Safe(), DeepEquals, append([]Position{}, p.safe...), Commentf("position %s", p.name))
+		c.Check(solver.Mines(), DeepEquals, append([]Position{}, p.mines...), Commentf("position %s", p.name))
+
+		estimate := solver.Estimate(corpusSeed, corpusIterations)
+		probabilities := solver.Probabilities()
+		for pos, risk := range p.risks {
+			hint := estimate.Hints[pos]
+			c.Check(math.Abs(hint.Risk-risk) <= corpusTolerance, Equals, true,
+				Commentf("position %s at %v: risk %g, expected %g", p.name, pos, hint.Risk, risk))
+			c.Check(math.Abs(probabilities[pos]-risk) <= corpusTolerance/10, Equals, true,
+				Commentf("position %s at %v: probability %g, expected %g", p.name, pos, probabilities[pos], risk))
+		}
+	}
+}
+
+func (s *MSSuite) BenchmarkSolver_Corpus(c *C) {
+	corpus := readCorpus(c)
+	c.ResetTimer()
+	for i := 0; i < c.N; i++ {
+		for _, p := range corpus {
+			NewSolver(p.snapshot).Estimate(corpusSeed, 1000)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package gominesweeper
+
+// Cue describes a block revealed in blind mode so that it can be played as a
+// sound rather than shown.
+type Cue struct {
+	Position
+
+	// Count is the proximity of the block, or Mine if it was set off.
+	Count int
+
+	// Pan places the block from the left edge (-1) to the right edge (1), and
+	// Pitch from the bottom row (0) to the top row (1).
+	Pan, Pitch float64
+}
+
+// cues returns the cues for the blocks revealed by the changes.
+func (g *Game) cues(changes []Change) []Cue {
+	if !g.config.Blind {
+		return nil
+	}
+
+	var cues []Cue
+	for _, change := range changes {
+		if change.Value != Revealed && change.Value != Exploded {
+			continue
+		}
+		block, _ := g.minefield.peek(change.Position)
+		cues = append(cues, Cue{
+			Position: change.Position,
+			Count:    block.proximity(),
+			Pan:      scale(change.X, g.config.Width)*2 - 1,
+			Pitch:    1 - scale(change.Y, g.config.Height),
+		})
+	}
+	return cues
+}
+
+// scale maps the index into the range 0 to 1.
+func scale(i int, size uint) float64 {
+	if size <= 1 {
+		return 0.5
+	}
+	return float64(i) / float64(size-1)
+}
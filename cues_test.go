@@ -0,0 +1,37 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_Blind(c *C) {
+	game := newTestGame(c, Config{Blind: true, Lives: 2})
+
+	var events []Event
+	game.Subscribe(func(event Event) {
+		events = append(events, event)
+	})
+
+	proximity, err := game.Select(1, 1)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, 3)
+	c.Check(game.Display()[Position{X: 1, Y: 1}], Equals, Revealed)
+	c.Assert(game.ToggleFlag(4, 4), IsNil)
+	_, err = game.Select(4, 0)
+	c.Assert(err, IsNil)
+
+	c.Assert(events, HasLen, 3)
+	c.Check(events[0].Changes, DeepEquals, []Change{{Position{X: 1, Y: 1}, Revealed}})
+	c.Check(events[0].Cues, DeepEquals, []Cue{{Position{X: 1, Y: 1}, 3, -0.5, 0.75}})
+	c.Check(events[1].Cues, IsNil)
+	c.Check(events[2].Cues, DeepEquals, []Cue{{Position{X: 4, Y: 0}, Mine, 1, 1}})
+
+	// the numbers are not hidden otherwise
+	game = newTestGame(c, Config{})
+	game.Subscribe(func(event Event) {
+		c.Check(event.Cues, IsNil)
+	})
+	_, err = game.Select(1, 1)
+	c.Assert(err, IsNil)
+	c.Check(game.Display()[Position{X: 1, Y: 1}], Equals, 3)
+}
@@ -0,0 +1,65 @@
+package gominesweeper
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"time"
+)
+
+// Preset is the name of registered rules, such as a difficulty.
+type Preset string
+
+// The classic difficulties.
+const (
+	Beginner     Preset = "beginner"
+	Intermediate Preset = "intermediate"
+	Expert       Preset = "expert"
+)
+
+// Config returns a config that plays by the preset's rules, or ErrUnknownName
+// if no rules are registered with its name.
+func (p Preset) Config() (Config, error) {
+	rules, err := LookupRules(string(p))
+	if err != nil {
+		return Config{}, err
+	}
+	return rules.Config()
+}
+
+// DailySeed returns the seed of the daily challenge on the date in the
+// namespace.  Days are in UTC, so the challenge changes at the same moment for
+// everyone.
+func DailySeed(namespace string, date time.Time) int64 {
+	sum := sha256.Sum256([]byte(namespace + "\x00" + date.UTC().Format("2006-01-02")))
+	return int64(binary.BigEndian.Uint64(sum[:]))
+}
+
+// DailyBoard returns the config of the daily challenge on the date, which
+// places the mines of every game started with it in the same positions.
+func DailyBoard(date time.Time, difficulty Preset) (Config, error) {
+	return NamespacedDailyBoard("", date, difficulty)
+}
+
+// NamespacedDailyBoard returns the config of the daily challenge on the date
+// in the namespace, so that different communities can have their own
+// challenges.
+func NamespacedDailyBoard(namespace string, date time.Time, difficulty Preset) (Config, error) {
+	cfg, err := difficulty.Config()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Selector = SeededSelector(DailySeed(namespace, date))
+	return cfg, nil
+}
+
+// VerifyDaily plays the replay back and returns the game as it was left, so
+// that its result can go on a shared leaderboard.  It returns
+// ErrReplayMismatch if the replay was not of the daily challenge on the date
+// in the namespace.
+func VerifyDaily(replay Replay, namespace string, date time.Time, difficulty Preset) (*Game, error) {
+	cfg, err := NamespacedDailyBoard(namespace, date, difficulty)
+	if err != nil {
+		return nil, err
+	}
+	return replay.Verify(cfg)
+}
@@ -0,0 +1,61 @@
+package gominesweeper
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestDailyBoard(c *C) {
+	day := time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC)
+	sameDay := time.Date(2024, 3, 2, 8, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	nextDay := day.Add(2 * time.Hour)
+
+	// everyone gets the same board on the same day
+	cfg, err := DailyBoard(day, Expert)
+	c.Assert(err, IsNil)
+	game, err := NewGame(cfg)
+	c.Assert(err, IsNil)
+	c.Check(game.Snapshot().Width, Equals, 30)
+	again, err := DailyBoard(sameDay, Expert)
+	c.Assert(err, IsNil)
+	other, err := NewGame(again)
+	c.Assert(err, IsNil)
+	c.Check(other.Replay().Layout, DeepEquals, game.Replay().Layout)
+
+	// but not on another day or in another namespace
+	c.Check(DailySeed("", nextDay), Not(Equals), DailySeed("", day))
+	c.Check(DailySeed("club", day), Not(Equals), DailySeed("", day))
+
+	_, err = DailyBoard(day, Preset("bogus"))
+	c.Check(err, Equals, ErrUnknownName)
+}
+
+func (s *MSSuite) TestVerifyDaily(c *C) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	cfg, err := DailyBoard(day, Beginner)
+	c.Assert(err, IsNil)
+	game, err := NewGame(cfg)
+	c.Assert(err, IsNil)
+	game.clock = fakeClock()
+	player := NewSolverBot(1)
+	for i := 0; i < 5 && game.State() == Playing; i++ {
+		game.Apply(player.NextMove(game.Snapshot()))
+	}
+
+	played, err := VerifyDaily(game.Replay(), "", day, Beginner)
+	c.Assert(err, IsNil)
+	c.Check(played.Display(), DeepEquals, game.Display())
+	c.Check(played.Elapsed(), Equals, game.moves[len(game.moves)-1].Elapsed)
+
+	_, err = VerifyDaily(game.Replay(), "", day.AddDate(0, 0, 1), Beginner)
+	c.Check(err, Equals, ErrReplayMismatch)
+	_, err = VerifyDaily(game.Replay(), "club", day, Beginner)
+	c.Check(err, Equals, ErrReplayMismatch)
+
+	// the rules have to be the same too
+	replay := game.Replay()
+	replay.Rules.Lives = 3
+	_, err = VerifyDaily(replay, "", day, Beginner)
+	c.Check(err, Equals, ErrReplayMismatch)
+}
@@ -0,0 +1,85 @@
+package gominesweeper
+
+// DefuseAll is won once every mine has been defused.
+var DefuseAll WinCondition = namedWin{func(g *Game) bool {
+	for _, pos := range g.minefield.mines() {
+		if !g.defused[pos] {
+			return false
+		}
+	}
+	return true
+}, "defuse"}
+
+// Defuse ends the defusal of the flag at the position, as decided by the
+// outcome of its minigame.  A flag on a mine is either defused, or the mine
+// goes off and costs a life.  Defusing a flag that is not on a mine does
+// nothing either way.
+func (g *Game) Defuse(x, y int, success bool) error {
+	move := Move{Defuse, Position{X: x, Y: y}}
+	if !success {
+		move.Action = Detonate
+	}
+	_, err := g.Apply(move)
+	return err
+}
+
+// Defusals returns the number of mines that were defused and the number of
+// defusals of mines that failed.
+func (g *Game) Defusals() (defused, failed int) {
+	return len(g.defused), g.failedDefusals
+}
+
+// startDefusal starts or calls off the defusal of the flag at the position.
+func (g *Game) startDefusal(pos Position, flagged bool) {
+	if !flagged {
+		delete(g.defusing, pos)
+		return
+	}
+
+	deadline := g.clock().Add(g.config.DefuseTime)
+	g.defusing[pos] = deadline
+	if g.config.OnDefuse != nil {
+		g.config.OnDefuse(g, pos, deadline)
+	}
+}
+
+// endDefusal settles the defusal of the flag at the position.
+func (g *Game) endDefusal(pos Position, success bool) {
+	block, _ := g.minefield.block(pos)
+	if block.proximity() != Mine {
+		return
+	} else if success {
+		g.defused[pos] = true
+		return
+	}
+
+	g.failedDefusals++
+	block.ToggleFlag()
+	block.Select()
+	g.explode(pos)
+}
+
+// expire ends a time attack whose time is up, and fails every defusal that
+// has run past its deadline, ending the game if the mines go off.
+func (g *Game) expire() {
+	if g.timeUp(); g.state != Playing || len(g.defusing) == 0 {
+		return
+	}
+
+	now := g.clock()
+	var expired []Position
+	for pos, deadline := range g.defusing {
+		if now.After(deadline) {
+			expired = append(expired, pos)
+		}
+	}
+	sortPositions(expired)
+
+	for _, pos := range expired {
+		delete(g.defusing, pos)
+		if g.endDefusal(pos, false); g.state != Playing {
+			g.finish(now)
+			return
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package gominesweeper
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_Defuse(c *C) {
+	now := time.Unix(0, 0)
+	var started []Position
+	game := newTestGame(c, Config{
+		Lives:      2,
+		DefuseTime: 10 * time.Second,
+		OnDefuse: func(g *Game, pos Position, deadline time.Time) {
+			c.Check(deadline, Equals, now.Add(10*time.Second))
+			started = append(started, pos)
+		},
+	})
+	game.clock = func() time.Time { return now }
+
+	c.Check(game.Defuse(0, 0, true), Equals, ErrNotDefusing)
+
+	// defused mines stay flagged
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	c.Assert(game.Defuse(0, 0, true), IsNil)
+	c.Check(game.Defuse(0, 0, true), Equals, ErrNotDefusing)
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	c.Check(game.Display()[Position{X: 0, Y: 0}], Equals, Defused)
+
+	// removing the flag calls the defusal off
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	c.Check(game.Defuse(4, 0, true), Equals, ErrNotDefusing)
+
+	// failing to defuse a mine sets it off
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	c.Assert(game.Defuse(4, 0, false), IsNil)
+	c.Check(game.Display()[Position{X: 4, Y: 0}], Equals, Exploded)
+	c.Check(game.Lives(), Equals, uint(1))
+
+	// flags that are not on a mine are harmless
+	c.Assert(game.ToggleFlag(1, 1), IsNil)
+	c.Assert(game.Defuse(1, 1, false), IsNil)
+	c.Check(game.Display()[Position{X: 1, Y: 1}], Equals, Flagged)
+	c.Check(game.Lives(), Equals, uint(1))
+
+	c.Assert(game.ToggleFlag(2, 1), IsNil)
+	c.Assert(game.ToggleFlag(1, 2), IsNil)
+	c.Assert(game.Defuse(2, 1, true), IsNil)
+	c.Assert(game.Defuse(1, 2, true), IsNil)
+	c.Check(game.State(), Equals, Playing)
+
+	// running out of time sets the mine off
+	c.Assert(game.ToggleFlag(3, 4), IsNil)
+	now = now.Add(11 * time.Second)
+	c.Check(game.Tick(), Equals, Lost)
+	c.Check(game.EndState().Detonated, Equals, Position{X: 3, Y: 4})
+
+	defused, failed := game.Defusals()
+	c.Check(defused, Equals, 3)
+	c.Check(failed, Equals, 2)
+	c.Check(started, DeepEquals, []Position{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 4}})
+}
+
+func (s *MSSuite) TestGame_DefuseAll(c *C) {
+	game := newTestGame(c, Config{DefuseTime: time.Minute})
+
+	for _, pos := range []Position{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 4}} {
+		c.Check(game.State(), Equals, Playing)
+		c.Assert(game.ToggleFlag(pos.X, pos.Y), IsNil)
+		c.Assert(game.Defuse(pos.X, pos.Y, true), IsNil)
+	}
+	c.Check(game.State(), Equals, Won)
+	c.Check(game.Replay().Moves[1].Move, Equals, Move{Defuse, Position{X: 0, Y: 0}})
+}
@@ -0,0 +1,117 @@
+package gominesweeper
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DescribeCell returns a sentence describing the block at the position as the
+// player sees it, for a screen reader, such as "row 3, column 4: revealed, 2
+// adjacent mines; 5 unrevealed neighbors".  Rows and columns are counted from
+// 1.  It returns an OutOfBoundsError if the position is off the board.
+func (g *Game) DescribeCell(x, y int) (string, error) {
+	pos := Position{X: x, Y: y}
+	if !g.minefield.contains(pos) {
+		return "", g.minefield.outOfBounds(pos)
+	}
+
+	value := g.value(pos)
+	if value < 0 {
+		return fmt.Sprintf("row %d, column %d: %s", y+1, x+1, words(value)), nil
+	}
+
+	var unrevealed, flagged int
+	for _, neighbor := range g.config.Neighborhood.Neighbors(pos) {
+		if !g.minefield.contains(neighbor) {
+			continue
+		}
+		switch g.value(neighbor) {
+		case Unknown, Questioned:
+			unrevealed++
+		case Flagged, Defused:
+			flagged++
+		}
+	}
+	description := fmt.Sprintf("row %d, column %d: revealed, %s; %s", y+1, x+1, words(value), count(unrevealed, "unrevealed neighbor", "unrevealed neighbors"))
+	if flagged > 0 {
+		description += ", " + count(flagged, "flagged", "flagged")
+	}
+	return description, nil
+}
+
+// DescribeBoard returns text describing the board as the player sees it, for
+// a screen reader.  The first line sums up the game, and each line after it
+// describes a row, grouping the blocks next to each other that look the same,
+// such as "row 1: columns 1 to 3, unrevealed; column 4, 2 adjacent mines".
+func (g *Game) DescribeBoard() string {
+	display := g.Display()
+	var unrevealed, flagged int
+	for _, value := range display {
+		switch value {
+		case Unknown, Questioned:
+			unrevealed++
+		case Flagged, Defused:
+			flagged++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d by %d board with %s: %s, %s left, %s, %s.\n",
+		g.config.Width, g.config.Height, count(int(g.config.Mines), "mine", "mines"), g.state,
+		count(int(g.lives), "life", "lives"), count(flagged, "flag", "flags")+" placed", count(unrevealed, "block", "blocks")+" unrevealed")
+	for y := 0; y < int(g.config.Height); y++ {
+		fmt.Fprintf(&b, "row %d: ", y+1)
+		for x := 0; x < int(g.config.Width); {
+			value := display[Position{X: x, Y: y}]
+			end := x + 1
+			for end < int(g.config.Width) && display[Position{X: end, Y: y}] == value {
+				end++
+			}
+			if x > 0 {
+				b.WriteString("; ")
+			}
+			if end-x == 1 {
+				fmt.Fprintf(&b, "column %d, %s", x+1, words(value))
+			} else {
+				fmt.Fprintf(&b, "columns %d to %d, %s", x+1, end, words(value))
+			}
+			x = end
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// words returns the words for the value of a block in Display.
+func words(value int) string {
+	switch value {
+	case Unknown:
+		return "unrevealed"
+	case Flagged:
+		return "flagged"
+	case Questioned:
+		return "question mark"
+	case Revealed:
+		return "revealed"
+	case Mine:
+		return "mine"
+	case Exploded:
+		return "exploded mine"
+	case WrongFlag:
+		return "wrong flag"
+	case Defused:
+		return "defused mine"
+	}
+	return count(value, "adjacent mine", "adjacent mines")
+}
+
+// count returns the number followed by the noun, or by its plural unless the
+// number is 1, saying "no" for none.
+func count(n int, noun, plural string) string {
+	if n == 0 {
+		return "no " + plural
+	} else if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -0,0 +1,56 @@
+package gominesweeper
+
+import (
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_DescribeCell(c *C) {
+	game := newTestGame(c, Config{Lives: 2})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	_, err = game.Apply(Move{Question, Position{X: 0, Y: 4}})
+	c.Assert(err, IsNil)
+
+	for _, t := range []struct {
+		x, y     int
+		expected string
+	}{
+		{3, 1, "row 2, column 4: revealed, 2 adjacent mines; 4 unrevealed neighbors, 1 flagged"},
+		{4, 2, "row 3, column 5: revealed, no adjacent mines; no unrevealed neighbors"},
+		{4, 3, "row 4, column 5: revealed, 1 adjacent mine; 2 unrevealed neighbors"},
+		{4, 0, "row 1, column 5: flagged"},
+		{0, 4, "row 5, column 1: question mark"},
+		{0, 0, "row 1, column 1: unrevealed"},
+	} {
+		description, err := game.DescribeCell(t.x, t.y)
+		c.Assert(err, IsNil)
+		c.Check(description, Equals, t.expected)
+	}
+
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+	description, err := game.DescribeCell(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(description, Equals, "row 1, column 1: exploded mine")
+
+	_, err = game.DescribeCell(5, 0)
+	c.Check(errors.Is(err, ErrOutOfBounds), Equals, true)
+}
+
+func (s *MSSuite) TestGame_DescribeBoard(c *C) {
+	game := newTestGame(c, Config{})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+
+	c.Check(game.DescribeBoard(), Equals, ""+
+		"5 by 5 board with 5 mines: playing, 1 life left, 1 flag placed, 18 blocks unrevealed.\n"+
+		"row 1: columns 1 to 4, unrevealed; column 5, flagged\n"+
+		"row 2: columns 1 to 3, unrevealed; column 4, 2 adjacent mines; column 5, 1 adjacent mine\n"+
+		"row 3: columns 1 to 3, unrevealed; column 4, 1 adjacent mine; column 5, no adjacent mines\n"+
+		"row 4: columns 1 to 3, unrevealed; columns 4 to 5, 1 adjacent mine\n"+
+		"row 5: columns 1 to 5, unrevealed\n")
+}
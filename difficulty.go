@@ -0,0 +1,30 @@
+package gominesweeper
+
+import (
+	"github.com/smousa/go-minesweeper/minegen"
+)
+
+// Difficulty rates how hard a minefield is to clear: its 3BV, openings, the
+// guesses the solver had to make and a Score combining them.
+type Difficulty = minegen.Difficulty
+
+// RateBoard rates the difficulty of clearing the minefield from the start,
+// without changing it.  The solver starts in its largest opening, and always
+// guesses right among the blocks that are the least likely to be mines; see
+// minegen.Rate.
+func RateBoard(mf *Minefield) Difficulty {
+	return minegen.Rate(mf.board())
+}
+
+// RateBoardFrom rates the difficulty of clearing the minefield like RateBoard,
+// but with the solver starting at the given block, which must not be a mine.
+// The first selection is not counted as a guess.
+func RateBoardFrom(mf *Minefield, start Position) Difficulty {
+	return minegen.RateFrom(mf.board(), start)
+}
+
+// board returns the mines and neighborhood of the minefield as a
+// minegen.Board.
+func (mf *Minefield) board() minegen.Board {
+	return minegen.Board{Width: mf.width, Height: mf.height, Mines: mf.mines(), Neighborhood: mf.neighborhood}
+}
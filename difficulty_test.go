@@ -0,0 +1,42 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestRateBoard(c *C) {
+	game := newTestGame(c, Config{})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+
+	// starting from the larger opening in the bottom left corner, the solver
+	// cannot clear the minefield without guessing
+	d := RateBoard(game.minefield)
+	c.Check(d, DeepEquals, Difficulty{BBBV: 10, Openings: 2, Guesses: 3, Frontier: 14, Score: 40})
+
+	// the minefield itself is left as it was
+	c.Check(game.minefield.revealed(), Equals, 6)
+
+	// a minefield that is one big opening needs no guesses
+	mf, err := NewMinefieldConfig(Config{Width: 4, Height: 4, Mines: 1, Selector: func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 3, Y: 3}}, nil
+	}})
+	c.Assert(err, IsNil)
+	c.Check(RateBoard(mf), DeepEquals, Difficulty{BBBV: 1, Openings: 1, Score: 1})
+}
+
+func (s *MSSuite) TestRateBoardFrom(c *C) {
+	mf, err := NewMinefieldConfig(Config{Width: 4, Height: 4, Mines: 1, Selector: func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 3, Y: 3}}, nil
+	}})
+	c.Assert(err, IsNil)
+
+	// the opening clears everything, but the number next to the mine
+	// leaves the solver guessing
+	c.Check(RateBoardFrom(mf, Position{X: 0, Y: 0}).Guesses, Equals, 0)
+	c.Check(RateBoardFrom(mf, Position{X: 3, Y: 2}).Guesses > 0, Equals, true)
+
+	// starting on a mine or out of bounds starts with a guess instead
+	c.Check(RateBoardFrom(mf, Position{X: 3, Y: 3}).Guesses, Equals, 1)
+	c.Check(RateBoardFrom(mf, Position{X: 9, Y: 9}).Guesses, Equals, 1)
+}
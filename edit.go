@@ -0,0 +1,82 @@
+package gominesweeper
+
+// PlaceMine puts a mine on the block at the position, counting it in the
+// proximity of each of its neighbors rather than counting the whole minefield
+// again, e.g. for a level editor.  It returns an OutOfBoundsError if the
+// position is off the minefield, a DuplicatePointError if there is already a
+// mine there, and ErrProximityFull if a neighbor cannot count another mine.
+func (mf *Minefield) PlaceMine(x, y int) error {
+	pos := Position{X: x, Y: y}
+	block, ok := mf.block(pos)
+	if !ok {
+		return mf.outOfBounds(pos)
+	} else if block.proximity() == Mine {
+		return &DuplicatePointError{pos}
+	}
+
+	neighbors := mf.neighbors(pos)
+	for _, neighbor := range neighbors {
+		if neighbor.proximity() == MaxProximity {
+			return ErrProximityFull
+		}
+	}
+	for _, neighbor := range neighbors {
+		*neighbor++
+	}
+	*block = packProximity(Mine) | block.status()
+	return nil
+}
+
+// RemoveMine takes the mine off the block at the position, counting the mines
+// around it for its own proximity and taking it out of the proximity of each
+// of its neighbors.  It returns an OutOfBoundsError if the position is off the
+// minefield, ErrNotMine if there is no mine there, and ErrProximityFull if
+// the block cannot count the mines around it.
+func (mf *Minefield) RemoveMine(x, y int) error {
+	pos := Position{X: x, Y: y}
+	block, ok := mf.block(pos)
+	if !ok {
+		return mf.outOfBounds(pos)
+	} else if block.proximity() != Mine {
+		return ErrNotMine
+	}
+
+	proximity := 0
+	for _, neighbor := range mf.neighborhood.Neighbors(pos) {
+		if block, ok := mf.peek(neighbor); ok && block.proximity() == Mine {
+			proximity++
+		}
+	}
+	if proximity > MaxProximity {
+		return ErrProximityFull
+	}
+	for _, neighbor := range mf.neighbors(pos) {
+		*neighbor--
+	}
+	*block = packProximity(proximity) | block.status()
+	return nil
+}
+
+// Proximity returns the number of mines in the proximity of the block at the
+// position, or Mine, whether or not it has been revealed.  It returns an
+// OutOfBoundsError if the position is off the minefield.
+func (mf *Minefield) Proximity(x, y int) (int, error) {
+	pos := Position{X: x, Y: y}
+	block, ok := mf.peek(pos)
+	if !ok {
+		return 0, mf.outOfBounds(pos)
+	}
+	return block.proximity(), nil
+}
+
+// neighbors returns the blocks in the neighborhood of the position that are
+// on the minefield and are not mines.
+func (mf *Minefield) neighbors(pos Position) []*Block {
+	var blocks []*Block
+	for _, neighbor := range mf.neighborhood.Neighbors(pos) {
+		if block, ok := mf.block(neighbor); ok && block.proximity() != Mine {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
@@ -0,0 +1,69 @@
+package gominesweeper
+
+import (
+	"errors"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestMinefield_PlaceMine(c *C) {
+	for _, sparse := range []bool{false, true} {
+		minefield, err := NewMinefieldConfig(Config{Width: 5, Height: 5, Selector: func(width, height, max uint) ([]Position, error) {
+			return nil, nil
+		}, Sparse: sparse})
+		c.Assert(err, IsNil)
+
+		// placing the mines one at a time gives the same blocks as placing
+		// them all at once
+		for _, mine := range []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}} {
+			c.Assert(minefield.PlaceMine(mine.X, mine.Y), IsNil)
+		}
+		expected, err := ParseBoard(strings.NewReader(testBoard))
+		c.Assert(err, IsNil)
+		c.Check(allBlocks(minefield), DeepEquals, allBlocks(expected), Commentf("sparse %v", sparse))
+
+		var dup *DuplicatePointError
+		c.Check(errors.As(minefield.PlaceMine(0, 0), &dup), Equals, true)
+		c.Check(errors.Is(minefield.PlaceMine(5, 0), ErrOutOfBounds), Equals, true)
+
+		// removing a mine counts its own proximity again
+		c.Assert(minefield.RemoveMine(2, 1), IsNil)
+		proximity, err := minefield.Proximity(2, 1)
+		c.Assert(err, IsNil)
+		c.Check(proximity, Equals, 1)
+		proximity, err = minefield.Proximity(3, 1)
+		c.Assert(err, IsNil)
+		c.Check(proximity, Equals, 1)
+		proximity, err = minefield.Proximity(1, 2)
+		c.Assert(err, IsNil)
+		c.Check(proximity, Equals, Mine)
+
+		c.Check(minefield.RemoveMine(2, 1), Equals, ErrNotMine)
+		_, err = minefield.Proximity(0, 5)
+		c.Check(errors.Is(err, ErrOutOfBounds), Equals, true)
+	}
+}
+
+func (s *MSSuite) TestMinefield_RemoveMineFull(c *C) {
+	minefield, err := NewMinefieldConfig(Config{Width: 7, Height: 7, Neighborhood: Radius(3), Selector: func(width, height, max uint) ([]Position, error) {
+		return nil, nil
+	}})
+	c.Assert(err, IsNil)
+
+	// the center has every other block as a neighbor, and cannot count more
+	// than MaxProximity of them once it is not a mine
+	c.Assert(minefield.PlaceMine(3, 3), IsNil)
+	placed := 0
+	for y := 0; y < 7 && placed <= MaxProximity; y++ {
+		for x := 0; x < 7 && placed <= MaxProximity; x++ {
+			if x != 3 || y != 3 {
+				c.Assert(minefield.PlaceMine(x, y), IsNil)
+				placed++
+			}
+		}
+	}
+	before := allBlocks(minefield)
+	c.Check(minefield.RemoveMine(3, 3), Equals, ErrProximityFull)
+	c.Check(allBlocks(minefield), DeepEquals, before)
+}
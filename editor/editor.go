@@ -0,0 +1,191 @@
+// Package editor builds boards by hand for level editors, placing and
+// removing one mine at a time while the proximities around it are kept up to
+// date:
+//
+//	ed, err := editor.New(9, 9)
+//	...
+//	err = ed.Toggle(4, 4)
+//	...
+//	if err := ed.Validate(editor.Requirements{MaxDensity: 0.2, NoGuess: true}); err != nil {
+//		...
+//	}
+//	err = ed.Write(w)
+//
+// Boards are read and written in the text format of gominesweeper.ParseBoard,
+// and Rows gives the Board of a levels.Level.
+package editor
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+var (
+	ErrTooSparse     = errors.New("board has too few mines")
+	ErrTooDense      = errors.New("board has too many mines")
+	ErrNeedsGuessing = errors.New("board cannot be cleared without guessing")
+)
+
+// Editor is a board being built.  It is not safe for concurrent use.
+type Editor struct {
+	minefield *gominesweeper.Minefield
+	mines     int
+}
+
+// New returns an editor for an empty board of the size.  It returns
+// gominesweeper.ErrExceedDimensions if the board has no blocks.
+func New(width, height uint) (*Editor, error) {
+	mf, err := gominesweeper.FromLayout(width, height, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Editor{minefield: mf}, nil
+}
+
+// Load returns an editor for the board read as by gominesweeper.ParseBoard.
+func Load(r io.Reader) (*Editor, error) {
+	mf, err := gominesweeper.ParseBoard(r)
+	if err != nil {
+		return nil, err
+	}
+	e := &Editor{minefield: mf}
+	e.each(func(x, y, proximity int) {
+		if proximity == gominesweeper.Mine {
+			e.mines++
+		}
+	})
+	return e, nil
+}
+
+// Width returns the number of blocks in each row of the board.
+func (e *Editor) Width() int {
+	return e.minefield.Width()
+}
+
+// Height returns the number of rows of the board.
+func (e *Editor) Height() int {
+	return e.minefield.Height()
+}
+
+// Mines returns the number of mines placed.
+func (e *Editor) Mines() int {
+	return e.mines
+}
+
+// Density returns the fraction of the blocks that are mines.
+func (e *Editor) Density() float64 {
+	return float64(e.mines) / float64(e.Width()*e.Height())
+}
+
+// Place puts a mine at the position.  It returns an error if the position is
+// off the board or already a mine, or if a neighbor cannot count another
+// mine.
+func (e *Editor) Place(x, y int) error {
+	if err := e.minefield.PlaceMine(x, y); err != nil {
+		return err
+	}
+	e.mines++
+	return nil
+}
+
+// Remove takes the mine off the position.  It returns an error if the
+// position is off the board or not a mine.
+func (e *Editor) Remove(x, y int) error {
+	if err := e.minefield.RemoveMine(x, y); err != nil {
+		return err
+	}
+	e.mines--
+	return nil
+}
+
+// Toggle places a mine at the position, or removes the one that is there.
+func (e *Editor) Toggle(x, y int) error {
+	if proximity, err := e.Proximity(x, y); err != nil {
+		return err
+	} else if proximity == gominesweeper.Mine {
+		return e.Remove(x, y)
+	}
+	return e.Place(x, y)
+}
+
+// Proximity returns the number of mines around the position, or
+// gominesweeper.Mine if it is a mine.
+func (e *Editor) Proximity(x, y int) (int, error) {
+	return e.minefield.Proximity(x, y)
+}
+
+// Minefield returns a copy of the board to play.
+func (e *Editor) Minefield() *gominesweeper.Minefield {
+	return e.minefield.Clone()
+}
+
+// Difficulty rates how hard the board is to clear.
+func (e *Editor) Difficulty() gominesweeper.Difficulty {
+	return gominesweeper.RateBoard(e.minefield)
+}
+
+// Requirements are what a board must meet to be used as a level.
+type Requirements struct {
+	// MinDensity and MaxDensity bound the fraction of the blocks that are
+	// mines; a MaxDensity of 0 leaves it unbounded.
+	MinDensity, MaxDensity float64
+
+	// NoGuess requires the board to be cleared without guessing, starting
+	// from Start, or from its largest opening if Start is nil.
+	NoGuess bool
+	Start   *gominesweeper.Position
+}
+
+// Validate returns ErrTooSparse or ErrTooDense if the board has too few or
+// too many mines, and ErrNeedsGuessing if it has to be guessed at.
+func (e *Editor) Validate(req Requirements) error {
+	density := e.Density()
+	switch {
+	case density < req.MinDensity:
+		return ErrTooSparse
+	case req.MaxDensity > 0 && density > req.MaxDensity:
+		return ErrTooDense
+	case !req.NoGuess:
+		return nil
+	}
+
+	var difficulty gominesweeper.Difficulty
+	if req.Start != nil {
+		difficulty = gominesweeper.RateBoardFrom(e.minefield, *req.Start)
+	} else {
+		difficulty = e.Difficulty()
+	}
+	if difficulty.Guesses > 0 {
+		return ErrNeedsGuessing
+	}
+	return nil
+}
+
+// Write writes the board in the text format of gominesweeper.ParseBoard.
+func (e *Editor) Write(w io.Writer) error {
+	return gominesweeper.WriteBoard(w, e.minefield)
+}
+
+// Rows returns the rows of the board in the text format, such as for the
+// Board of a levels.Level.
+func (e *Editor) Rows() ([]string, error) {
+	var buf bytes.Buffer
+	if err := e.Write(&buf); err != nil {
+		return nil, err
+	}
+	return strings.Fields(buf.String()), nil
+}
+
+// each calls the function with the proximity of every block, by row.
+func (e *Editor) each(fn func(x, y, proximity int)) {
+	for y := 0; y < e.Height(); y++ {
+		for x := 0; x < e.Width(); x++ {
+			proximity, _ := e.Proximity(x, y)
+			fn(x, y, proximity)
+		}
+	}
+}
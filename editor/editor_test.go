@@ -0,0 +1,81 @@
+package editor
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+type EditorSuite struct{}
+
+var _ = Suite(&EditorSuite{})
+
+func (s *EditorSuite) TestEditor(c *C) {
+	ed, err := New(5, 3)
+	c.Assert(err, IsNil)
+	c.Check(ed.Mines(), Equals, 0)
+
+	c.Assert(ed.Place(0, 0), IsNil)
+	c.Assert(ed.Toggle(4, 2), IsNil)
+	c.Assert(ed.Toggle(2, 1), IsNil)
+	c.Assert(ed.Toggle(2, 1), IsNil)
+	c.Check(ed.Mines(), Equals, 2)
+	c.Check(ed.Density(), Equals, 2.0/15)
+
+	proximity, err := ed.Proximity(1, 1)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, 1)
+
+	rows, err := ed.Rows()
+	c.Assert(err, IsNil)
+	c.Check(rows, DeepEquals, []string{"*1...", "11.11", "...1*"})
+	var buf bytes.Buffer
+	c.Assert(ed.Write(&buf), IsNil)
+	c.Check(buf.String(), Equals, "*1...\n11.11\n...1*\n")
+
+	c.Check(ed.Remove(2, 1), Equals, gominesweeper.ErrNotMine)
+	c.Check(errors.Is(ed.Toggle(5, 0), gominesweeper.ErrOutOfBounds), Equals, true)
+	c.Check(ed.Mines(), Equals, 2)
+
+	_, err = New(0, 3)
+	c.Check(err, Equals, gominesweeper.ErrExceedDimensions)
+}
+
+func (s *EditorSuite) TestLoad(c *C) {
+	ed, err := Load(strings.NewReader("*1...\n11.11\n...1*\n"))
+	c.Assert(err, IsNil)
+	c.Check(ed.Width(), Equals, 5)
+	c.Check(ed.Height(), Equals, 3)
+	c.Check(ed.Mines(), Equals, 2)
+
+	// the copy to play is not changed by editing
+	mf := ed.Minefield()
+	c.Assert(ed.Remove(0, 0), IsNil)
+	result, err := mf.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(result.Value, Equals, gominesweeper.Mine)
+}
+
+func (s *EditorSuite) TestValidate(c *C) {
+	ed, err := Load(strings.NewReader("*1...\n11.11\n...1*\n"))
+	c.Assert(err, IsNil)
+	c.Check(ed.Validate(Requirements{NoGuess: true}), IsNil)
+	c.Check(ed.Validate(Requirements{MinDensity: 0.2}), Equals, ErrTooSparse)
+	c.Check(ed.Validate(Requirements{MaxDensity: 0.1}), Equals, ErrTooDense)
+
+	ed, err = Load(strings.NewReader("*2*\n121\n...\n"))
+	c.Assert(err, IsNil)
+	c.Check(ed.Validate(Requirements{NoGuess: true, Start: &gominesweeper.Position{X: 1, Y: 2}}), IsNil)
+
+	// the mine may be any of the hidden blocks around the first 1
+	ed, err = Load(strings.NewReader(".*.\n...\n"))
+	c.Assert(err, IsNil)
+	c.Check(ed.Validate(Requirements{NoGuess: true, Start: &gominesweeper.Position{X: 0, Y: 1}}), Equals, ErrNeedsGuessing)
+}
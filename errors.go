@@ -0,0 +1,43 @@
+package gominesweeper
+
+import (
+	"fmt"
+)
+
+// OutOfBoundsError is returned for a position that is off a minefield of the
+// given size.  It matches ErrOutOfBounds with errors.Is.
+type OutOfBoundsError struct {
+	Pos           Position
+	Width, Height int
+}
+
+// Error describes the position and the size of the minefield.
+func (e *OutOfBoundsError) Error() string {
+	return fmt.Sprintf("point (%d,%d) is out of bounds of %dx%d", e.Pos.X, e.Pos.Y, e.Width, e.Height)
+}
+
+// Unwrap returns ErrOutOfBounds.
+func (e *OutOfBoundsError) Unwrap() error {
+	return ErrOutOfBounds
+}
+
+// DuplicatePointError is returned for a position that a Selector placed more
+// than one mine on.  It matches ErrDupPoint with errors.Is.
+type DuplicatePointError struct {
+	Pos Position
+}
+
+// Error describes the position.
+func (e *DuplicatePointError) Error() string {
+	return fmt.Sprintf("duplicate point found at (%d,%d)", e.Pos.X, e.Pos.Y)
+}
+
+// Unwrap returns ErrDupPoint.
+func (e *DuplicatePointError) Unwrap() error {
+	return ErrDupPoint
+}
+
+// outOfBounds returns the error for the position being off the minefield.
+func (mf *Minefield) outOfBounds(pos Position) error {
+	return &OutOfBoundsError{pos, mf.width, mf.height}
+}
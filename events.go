@@ -0,0 +1,149 @@
+package gominesweeper
+
+import (
+	"sort"
+	"time"
+)
+
+// Change is a block whose displayed value has changed.
+type Change struct {
+	Position
+	Value int
+}
+
+// Event is published to the subscribers of a game whenever its display or
+// state changes.
+type Event struct {
+	// Move is the move that caused the event, or nil if it was caused by
+	// time passing.
+	Move *Move `json:"move,omitempty"`
+
+	// Changes are the blocks whose value in Display changed, ordered by row.
+	Changes []Change `json:"changes"`
+
+	State   State         `json:"state"`
+	Lives   uint          `json:"lives"`
+	Elapsed time.Duration `json:"elapsed"`
+	Paused  bool          `json:"paused,omitempty"`
+
+	// Cues describe the blocks revealed in blind mode, ordered by row.
+	Cues []Cue `json:"cues,omitempty"`
+
+	// Score and Combo are only kept in score attack.
+	Score int `json:"score,omitempty"`
+	Combo int `json:"combo,omitempty"`
+}
+
+// Subscribe calls fn with an Event after every move, whenever Tick changes
+// the game and when it is paused or resumed, until the returned function is
+// called.  fn is called while the move is being made, so it must not make
+// moves of its own.
+func (g *Game) Subscribe(fn func(Event)) (unsubscribe func()) {
+	if g.subscribers == nil {
+		g.subscribers = make(map[int]func(Event))
+	}
+	if len(g.subscribers) == 0 {
+		g.shown, g.shownState = g.Display(), g.state
+		g.minefield.touches()
+	}
+
+	id := g.nextID
+	g.nextID++
+	g.subscribers[id] = fn
+	return func() {
+		delete(g.subscribers, id)
+	}
+}
+
+// publish sends the changes since the last event to every subscriber.  Events
+// that were not caused by a move are only sent if something changed.
+func (g *Game) publish(move *Move, elapsed time.Duration) {
+	if len(g.subscribers) == 0 {
+		g.minefield.untrack()
+		return
+	}
+
+	// only the blocks the minefield handed out can have changed, unless the
+	// state changed, which can change how any block is shown
+	var changes []Change
+	if touched, ok := g.minefield.touches(); ok && g.state == g.shownState {
+		changes = g.changes(touched)
+	} else {
+		display := g.Display()
+		changes = diff(g.shown, display)
+		g.shown = display
+	}
+	g.shownState = g.state
+	if move == nil && len(changes) == 0 && g.Paused() == g.shownPaused {
+		return
+	}
+	g.shownPaused = g.Paused()
+
+	event := Event{
+		Move:    move,
+		Changes: changes,
+		Cues:    g.cues(changes),
+		State:   g.state,
+		Lives:   g.lives,
+		Elapsed: elapsed,
+		Paused:  g.shownPaused,
+		Score:   g.score,
+		Combo:   g.combo,
+	}
+	for _, fn := range g.subscribers {
+		fn(event)
+	}
+}
+
+// changes returns the blocks at the positions whose value in Display is not
+// the one last shown, ordered by row, and shows them.
+func (g *Game) changes(positions map[Position]bool) []Change {
+	var changes []Change
+	for pos := range positions {
+		value := g.value(pos)
+		if old, ok := g.shown[pos]; !ok || old != value {
+			g.shown[pos] = value
+			changes = append(changes, Change{pos, value})
+		}
+	}
+	sortChanges(changes)
+	return changes
+}
+
+// Diff returns the blocks of the after snapshot whose value is not the same as
+// in the before snapshot, ordered by row, like the changes of an Event.  When
+// the snapshots are of different parts of the minefield, the blocks are
+// matched by their place on the minefield and the changes are relative to
+// the after snapshot's Origin; blocks only in the before snapshot are left
+// out.
+func Diff(before, after Snapshot) []Change {
+	shift := Position{X: after.Origin.X - before.Origin.X, Y: after.Origin.Y - before.Origin.Y}
+	shifted := before.Blocks
+	if shift != (Position{}) {
+		shifted = make(map[Position]int, len(before.Blocks))
+		for pos, value := range before.Blocks {
+			shifted[Position{X: pos.X - shift.X, Y: pos.Y - shift.Y}] = value
+		}
+	}
+	return diff(shifted, after.Blocks)
+}
+
+// diff returns the values of the after display that are not the same as in
+// the before display, ordered by row.
+func diff(before, after map[Position]int) []Change {
+	var changes []Change
+	for pos, value := range after {
+		if old, ok := before[pos]; !ok || old != value {
+			changes = append(changes, Change{pos, value})
+		}
+	}
+	sortChanges(changes)
+	return changes
+}
+
+// sortChanges orders the changes by row.
+func sortChanges(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		return less(changes[i].Position, changes[j].Position)
+	})
+}
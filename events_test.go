@@ -0,0 +1,118 @@
+package gominesweeper
+
+import (
+	"encoding/json"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_Subscribe(c *C) {
+	now := time.Unix(0, 0)
+	game := newTestGame(c, Config{Lives: 2, DefuseTime: time.Second})
+	game.clock = func() time.Time { return now }
+
+	var events []Event
+	unsubscribe := game.Subscribe(func(event Event) {
+		events = append(events, event)
+	})
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	c.Check(game.Tick(), Equals, Playing)
+	now = now.Add(2 * time.Second)
+	c.Check(game.Tick(), Equals, Playing)
+
+	c.Check(events, DeepEquals, []Event{{
+		Move: &Move{Reveal, Position{X: 4, Y: 2}},
+		Changes: []Change{
+			{Position{X: 3, Y: 1}, 2}, {Position{X: 4, Y: 1}, 1},
+			{Position{X: 3, Y: 2}, 1}, {Position{X: 4, Y: 2}, 0},
+			{Position{X: 3, Y: 3}, 1}, {Position{X: 4, Y: 3}, 1},
+		},
+		State: Playing,
+		Lives: 2,
+	}, {
+		Move:    &Move{Flag, Position{X: 0, Y: 0}},
+		Changes: []Change{{Position{X: 0, Y: 0}, Flagged}},
+		State:   Playing,
+		Lives:   2,
+	}, {
+		Changes: []Change{{Position{X: 0, Y: 0}, Exploded}},
+		State:   Playing,
+		Lives:   1,
+		Elapsed: 2 * time.Second,
+	}})
+
+	unsubscribe()
+	_, err = game.Select(1, 2)
+	c.Assert(err, IsNil)
+	c.Check(events, HasLen, 3)
+}
+
+func (s *MSSuite) TestEvent_JSON(c *C) {
+	event := Event{
+		Move:    &Move{Flag, Position{X: 1, Y: 2}},
+		Changes: []Change{{Position{X: 1, Y: 2}, Flagged}},
+		State:   Lost,
+		Lives:   0,
+		Elapsed: time.Second,
+	}
+	data, err := json.Marshal(event)
+	c.Assert(err, IsNil)
+	c.Check(string(data), Equals, `{"move":{"Action":"flag","X":1,"Y":2},"changes":[{"X":1,"Y":2,"Value":-2}],"state":"lost","lives":0,"elapsed":1000000000}`)
+
+	var decoded Event
+	c.Assert(json.Unmarshal(data, &decoded), IsNil)
+	c.Check(decoded, DeepEquals, event)
+
+	c.Check(json.Unmarshal([]byte(`{"state":"paused"}`), &decoded), NotNil)
+}
+
+func (s *MSSuite) TestDiff(c *C) {
+	game := newTestGame(c, Config{})
+	game.clock = fakeClock()
+	var events []Event
+	game.Subscribe(func(event Event) {
+		events = append(events, event)
+	})
+
+	before := game.Snapshot()
+	c.Check(Diff(before, before), HasLen, 0)
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	after := game.Snapshot()
+
+	// the same changes as the event
+	changes := Diff(before, after)
+	c.Check(changes, DeepEquals, events[0].Changes)
+	c.Check(changes, HasLen, 6)
+
+	// snapshots of different parts are matched by their place
+	view := Snapshot{Origin: Position{X: 3, Y: 1}, Blocks: map[Position]int{{X: 0, Y: 0}: 2, {X: 1, Y: 0}: 1, {X: 0, Y: 1}: 1}}
+	moved := Snapshot{Origin: Position{X: 4, Y: 1}, Blocks: map[Position]int{{X: 0, Y: 0}: 1, {X: 0, Y: 1}: Flagged, {X: 0, Y: 2}: 1}}
+	c.Check(Diff(view, moved), DeepEquals, []Change{{Position{X: 0, Y: 1}, Flagged}, {Position{X: 0, Y: 2}, 1}})
+}
+
+func (s *MSSuite) TestGame_SubscribeChanges(c *C) {
+	game, err := NewGame(Config{Width: 30, Height: 30, Mines: 90, Lives: 5, Selector: SeededSelector(3)})
+	c.Assert(err, IsNil)
+
+	shown := game.Display()
+	game.Subscribe(func(event Event) {
+		for _, change := range event.Changes {
+			shown[change.Position] = change.Value
+		}
+	})
+
+	// the changes built from the blocks each move touched keep up with the
+	// whole display, through to the end of the game
+	bot := NewSolverBot(3)
+	for game.State() == Playing {
+		_, err := game.Apply(bot.NextMove(game.Snapshot()))
+		c.Assert(err, IsNil)
+		c.Assert(shown, DeepEquals, game.Display())
+		c.Check(game.minefield.touched, HasLen, 0)
+	}
+}
@@ -0,0 +1,48 @@
+// Command basic shows the library at its simplest: it starts a beginner game,
+// makes a few moves and prints the board after each.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+func main() {
+	if err := run(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "basic:", err)
+		os.Exit(1)
+	}
+}
+
+// run plays the moves on a beginner board with the same mines every time.
+func run(w io.Writer) error {
+	cfg, err := gominesweeper.Beginner.Config()
+	if err != nil {
+		return err
+	}
+	cfg.Selector = gominesweeper.SeededSelector(3)
+	game, err := gominesweeper.NewGame(cfg)
+	if err != nil {
+		return err
+	}
+
+	moves := []gominesweeper.Move{
+		{Action: gominesweeper.Reveal, Position: gominesweeper.Position{X: 4, Y: 4}},
+		{Action: gominesweeper.Flag, Position: gominesweeper.Position{X: 1, Y: 2}},
+		{Action: gominesweeper.Reveal, Position: gominesweeper.Position{X: 0, Y: 2}},
+	}
+	for _, move := range moves {
+		result, err := game.Apply(move)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s %d,%d: %d revealed, %s\n", move.Action, move.X, move.Y, result.Revealed, result.State)
+		if err := gominesweeper.RenderText(w, game.Snapshot()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type BasicSuite struct{}
+
+var _ = Suite(&BasicSuite{})
+
+func (s *BasicSuite) TestRun(c *C) {
+	var buf bytes.Buffer
+	c.Assert(run(&buf), IsNil)
+	lines := strings.Split(buf.String(), "\n")
+	c.Assert(lines, HasLen, 31)
+	c.Check(lines[0], Equals, "reveal 4,4: 44 revealed, playing")
+	c.Check(lines[10], Equals, "flag 1,2: 0 revealed, playing")
+	c.Check(lines[20], Equals, "reveal 0,2: 1 revealed, playing")
+	c.Check(lines[23], Equals, "1F1..123#")
+}
@@ -0,0 +1,51 @@
+// Command bot has the solver bot play games by itself, showing the first game
+// as it was left and how many of the rest it won.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	"github.com/smousa/go-minesweeper/sim"
+)
+
+func main() {
+	preset := flag.String("rules", "intermediate", "rules to play by")
+	games := flag.Int("games", 100, "number of games to play")
+	flag.Parse()
+
+	if err := run(os.Stdout, gominesweeper.Preset(*preset), *games); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run has the bot play the games by the preset's rules.
+func run(w io.Writer, preset gominesweeper.Preset, games int) error {
+	cfg, err := preset.Config()
+	if err != nil {
+		return err
+	}
+
+	// watch the first game closely
+	first := cfg
+	first.Selector = gominesweeper.SeededSelector(0)
+	game, err := gominesweeper.NewGame(first)
+	if err != nil {
+		return err
+	}
+	state := gominesweeper.Play(gominesweeper.NewSolverBot(0), game)
+	gominesweeper.RenderText(w, game.Snapshot())
+	fmt.Fprintf(w, "first game: %s in %d moves\n", state, len(game.Replay().Moves))
+
+	result, err := sim.Simulate(context.Background(), sim.SolverBot, sim.Options{Config: cfg, Games: games})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "won %d of %d games (%.0f%%)\n", result.Wins, result.Games, 100*result.WinRate())
+	return nil
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type BotSuite struct{}
+
+var _ = Suite(&BotSuite{})
+
+func (s *BotSuite) TestRun(c *C) {
+	var buf bytes.Buffer
+	c.Assert(run(&buf, gominesweeper.Beginner, 10), IsNil)
+	c.Check(buf.String(), Matches, `(?s)([.F1-8]{9}\n){9}first game: won in 18 moves\nwon \d+ of 10 games \(\d+%\)\n`)
+
+	c.Check(run(&buf, "missing", 1), Equals, gominesweeper.ErrUnknownName)
+}
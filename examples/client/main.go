@@ -0,0 +1,98 @@
+// Command client has the solver bot play a game through the HTTP API of the
+// server, showing the board as it was left.  Without -server it plays against
+// a server of its own.
+//
+//	client -server http://localhost:8080
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	"github.com/smousa/go-minesweeper/server"
+)
+
+func main() {
+	addr := flag.String("server", "", "URL of the server to play on")
+	flag.Parse()
+
+	if *addr == "" {
+		srv := httptest.NewServer(server.New())
+		defer srv.Close()
+		*addr = srv.URL
+	}
+	req := server.GameRequest{Width: 9, Height: 9, Mines: 10}
+	if err := run(os.Stdout, *addr, req); err != nil {
+		fmt.Fprintln(os.Stderr, "client:", err)
+		os.Exit(1)
+	}
+}
+
+// run starts a game of the request on the server and plays it until it is
+// over.
+func run(w io.Writer, addr string, req server.GameRequest) error {
+	var snapshot server.Snapshot
+	if err := post(addr+"/games", req, &snapshot); err != nil {
+		return err
+	}
+
+	bot := gominesweeper.NewSolverBot(0)
+	moves := 0
+	for ; snapshot.State == gominesweeper.Playing.String(); moves++ {
+		move := bot.NextMove(view(snapshot, req.Mines))
+		body := server.MoveRequest{Action: move.Action, X: move.X, Y: move.Y}
+		if err := post(addr+"/games/"+snapshot.ID+"/moves", body, &snapshot); err != nil {
+			return err
+		}
+	}
+
+	if err := gominesweeper.RenderText(w, view(snapshot, req.Mines)); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s in %d moves\n", snapshot.State, moves)
+	return err
+}
+
+// view returns the server's snapshot as the library's, for the bot.
+func view(s server.Snapshot, mines uint) gominesweeper.Snapshot {
+	view := gominesweeper.Snapshot{
+		Mines:        int(mines),
+		Neighborhood: gominesweeper.Surrounding,
+		Blocks:       make(map[gominesweeper.Position]int),
+	}
+	view.Height = len(s.Blocks)
+	for y, row := range s.Blocks {
+		view.Width = len(row)
+		for x, value := range row {
+			view.Blocks[gominesweeper.Position{X: x, Y: y}] = value
+		}
+	}
+	return view
+}
+
+// post posts the body as JSON and decodes the response into v, returning the
+// error of any response that is not OK.
+func post(url string, body, v any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		var e server.ErrorResponse
+		json.NewDecoder(resp.Body).Decode(&e)
+		return fmt.Errorf("%s: %s", resp.Status, e.Error)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
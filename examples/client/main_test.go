@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	"github.com/smousa/go-minesweeper/server"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type ClientSuite struct{}
+
+var _ = Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestRun(c *C) {
+	gominesweeper.RegisterSelector("client-test", gominesweeper.SeededSelector(0))
+	srv := httptest.NewServer(server.New())
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	req := server.GameRequest{Width: 9, Height: 9, Mines: 10, Selector: "client-test"}
+	c.Assert(run(&buf, srv.URL, req), IsNil)
+	c.Check(buf.String(), Matches, `(?s)([.F1-8]{9}\n){9}won in \d+ moves\n`)
+
+	err := run(&buf, srv.URL, server.GameRequest{Width: 9, Height: 9, Mines: 100})
+	c.Check(err, ErrorMatches, "400 Bad Request: .*")
+}
@@ -0,0 +1,90 @@
+// Command daily generates the daily puzzle, printing the day's notation for
+// sharing along with how hard the board is, and writing a picture of the
+// solved board.
+//
+//	daily -rules expert -date 2024-01-31 -png daily.png
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	"github.com/smousa/go-minesweeper/notation"
+)
+
+func main() {
+	preset := flag.String("rules", "expert", "rules of the puzzle")
+	date := flag.String("date", time.Now().UTC().Format(time.DateOnly), "day of the puzzle")
+	namespace := flag.String("namespace", "", "community the puzzle is for")
+	out := flag.String("png", "", "file to write a picture of the solved board to")
+	flag.Parse()
+
+	if err := daily(*preset, *date, *namespace, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "daily:", err)
+		os.Exit(1)
+	}
+}
+
+// daily prints the puzzle of the date and writes its picture to the file, if
+// there is one.
+func daily(preset, date, namespace, out string) error {
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return err
+	}
+	cfg, err := gominesweeper.NamespacedDailyBoard(namespace, day, gominesweeper.Preset(preset))
+	if err != nil {
+		return err
+	}
+	mf, err := gominesweeper.NewMinefieldConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	seed := gominesweeper.DailySeed(namespace, day)
+	puzzle := notation.Game{Width: cfg.Width, Height: cfg.Height, Mines: cfg.Mines, Seed: seed}
+	rating := gominesweeper.RateBoard(mf)
+	fmt.Printf("%s %s: %s\n", date, preset, notation.Format(puzzle))
+	fmt.Printf("3BV %d, %d openings, %d guesses, difficulty %d\n", rating.BBBV, rating.Openings, rating.Guesses, rating.Score)
+	if out == "" {
+		return nil
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := gominesweeper.ClassicTheme.PNG()(f, solved(cfg, mf)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// solved returns a snapshot of the minefield with every block shown.
+func solved(cfg gominesweeper.Config, mf *gominesweeper.Minefield) gominesweeper.Snapshot {
+	mines, _ := cfg.Selector(cfg.Width, cfg.Height, cfg.Mines)
+	mine := make(map[gominesweeper.Position]bool, len(mines))
+	for _, pos := range mines {
+		mine[pos] = true
+	}
+	for pos := range mf.Display() {
+		if !mine[pos] {
+			mf.Select(pos.X, pos.Y)
+		}
+	}
+
+	snapshot := gominesweeper.Snapshot{
+		Width:  int(cfg.Width),
+		Height: int(cfg.Height),
+		Mines:  int(cfg.Mines),
+		Blocks: mf.Display(),
+	}
+	for pos := range mine {
+		snapshot.Blocks[pos] = gominesweeper.Mine
+	}
+	return snapshot
+}
@@ -0,0 +1,67 @@
+// Command selector places the mines of a game with a custom Selector, here
+// along both diagonals of the board, and registers it by name so that it can
+// also be chosen by rules and the server.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+func main() {
+	if err := run(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "selector:", err)
+		os.Exit(1)
+	}
+}
+
+// diagonals places up to max mines along the diagonals of the board, from the
+// top down.
+func diagonals(width, height, max uint) ([]gominesweeper.Position, error) {
+	var mines []gominesweeper.Position
+	seen := make(map[gominesweeper.Position]bool)
+	for y := 0; y < int(height) && y < int(width); y++ {
+		for _, x := range []int{y, int(width) - 1 - y} {
+			pos := gominesweeper.Position{X: x, Y: y}
+			if uint(len(mines)) < max && !seen[pos] {
+				mines = append(mines, pos)
+				seen[pos] = true
+			}
+		}
+	}
+	if uint(len(mines)) < max {
+		return nil, gominesweeper.ErrExceedDimensions
+	}
+	return mines, nil
+}
+
+// run wins a game on a board of diagonals chosen by name.
+func run(w io.Writer) error {
+	gominesweeper.RegisterSelector("diagonals", diagonals)
+	selector, err := gominesweeper.LookupSelector("diagonals")
+	if err != nil {
+		return err
+	}
+
+	game, err := gominesweeper.NewGame(gominesweeper.Config{Width: 7, Height: 7, Mines: 13, Selector: selector})
+	if err != nil {
+		return err
+	}
+
+	// knowing where the mines are, reveal everything else
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 7; x++ {
+			if x == y || x == 6-y {
+				continue
+			}
+			if _, err := game.Select(x, y); err != nil {
+				return err
+			}
+		}
+	}
+	fmt.Fprintln(w, game.State())
+	return gominesweeper.RenderText(w, game.Snapshot())
+}
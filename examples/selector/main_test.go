@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type SelectorSuite struct{}
+
+var _ = Suite(&SelectorSuite{})
+
+func (s *SelectorSuite) TestDiagonals(c *C) {
+	mines, err := diagonals(3, 2, 3)
+	c.Assert(err, IsNil)
+	c.Check(mines, DeepEquals, []gominesweeper.Position{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 1, Y: 1}})
+
+	// the diagonals cross in the middle
+	_, err = diagonals(3, 2, 4)
+	c.Check(err, Equals, gominesweeper.ErrExceedDimensions)
+}
+
+func (s *SelectorSuite) TestRun(c *C) {
+	var buf bytes.Buffer
+	c.Assert(run(&buf), IsNil)
+	c.Check(buf.String(), Equals, "won\n"+
+		"F21.12F\n"+
+		"2F222F2\n"+
+		"12F3F21\n"+
+		".23F32.\n"+
+		"12F3F21\n"+
+		"2F222F2\n"+
+		"F21.12F\n")
+}
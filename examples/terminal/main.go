@@ -0,0 +1,37 @@
+// Command terminal plays a beginner game in the terminal.  Enter "r X Y" to
+// reveal a block or "f X Y" to flag it.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+func main() {
+	cfg, _ := gominesweeper.Beginner.Config()
+	game, err := gominesweeper.NewGame(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for game.State() == gominesweeper.Playing {
+		gominesweeper.RenderText(os.Stdout, game.Snapshot())
+		var action string
+		var x, y int
+		if _, err := fmt.Scan(&action, &x, &y); err != nil {
+			return
+		}
+		if action == "f" {
+			err = game.ToggleFlag(x, y)
+		} else {
+			_, err = game.Select(x, y)
+		}
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+	gominesweeper.RenderText(os.Stdout, game.Snapshot())
+	fmt.Println("you", game.State())
+}
@@ -0,0 +1,15 @@
+//go:build js && wasm
+
+// Command wasm plays minesweeper in the browser.  Build it and copy the
+// JavaScript support file next to index.html, then serve the directory:
+//
+//	GOOS=js GOARCH=wasm go build -o main.wasm
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" .
+package main
+
+import "github.com/smousa/go-minesweeper/wasm"
+
+func main() {
+	wasm.Register()
+	select {}
+}
@@ -0,0 +1,66 @@
+// Command webhook serves games over HTTP and announces every game that is won
+// or lost by posting its snapshot as JSON to a webhook.
+//
+//	webhook -hook https://example.com/minesweeper
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/smousa/go-minesweeper/server"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to serve on")
+	hook := flag.String("hook", "", "URL to announce finished games to")
+	flag.Parse()
+	if *hook == "" {
+		log.Fatal("webhook: -hook is required")
+	}
+
+	games := server.New()
+	mux := http.NewServeMux()
+	mux.Handle("/games", games)
+	mux.Handle("/games/", announce(games, *hook))
+	mux.Handle("/", server.Demo())
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+// announce wraps the handler so that every move that ends a game is posted to
+// the hook.
+func announce(next http.Handler, hook string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+		for key, values := range rec.Header() {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(rec.Code)
+		w.Write(rec.Body.Bytes())
+
+		var snapshot server.Snapshot
+		if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &snapshot) != nil {
+			return
+		} else if snapshot.State != "won" && snapshot.State != "lost" {
+			return
+		}
+		go func(body []byte) {
+			resp, err := http.Post(hook, "application/json", bytes.NewReader(body))
+			if err != nil {
+				log.Printf("webhook: announcing game %s: %v", snapshot.ID, err)
+				return
+			}
+			resp.Body.Close()
+		}(rec.Body.Bytes())
+	})
+}
@@ -0,0 +1,334 @@
+package gominesweeper
+
+import (
+	"time"
+)
+
+// State describes whether a game is still being played.
+type State int
+
+const (
+	Playing State = iota
+	Won
+	Lost
+)
+
+// MarshalText encodes the state as its name.
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes the state from its name.
+func (s *State) UnmarshalText(text []byte) error {
+	for _, state := range []State{Playing, Won, Lost} {
+		if state.String() == string(text) {
+			*s = state
+			return nil
+		}
+	}
+	return ErrUnknownName
+}
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case Playing:
+		return "playing"
+	case Won:
+		return "won"
+	case Lost:
+		return "lost"
+	}
+	return "unknown"
+}
+
+// Game tracks a player's progress on a minefield.
+type Game struct {
+	minefield *Minefield
+	config    Config
+	lives     uint
+	state     State
+	detonated Position
+
+	// clock keeps time from the first move until the game is over
+	clock      func() time.Time
+	start, end time.Time
+
+	// paused is when the game was paused, which is zero while it is not
+	paused time.Time
+
+	// recent are the times of the moves within the MoveLimit
+	recent []time.Time
+
+	moves  []Record
+	splits Splits
+	ghost  *Ghost
+	race   func(GhostEvent)
+
+	// defusing holds the deadline of each flag waiting to be defused
+	defusing       map[Position]time.Time
+	defused        map[Position]bool
+	failedDefusals int
+
+	// score and combo are kept in score attack, where lastReveal is when the
+	// combo was last added to
+	score, combo int
+	lastReveal   time.Time
+
+	// actions are the reveals left when they are limited, and clicks the
+	// moves left in a click limited game
+	actions, clicks uint
+
+	// reason is why the game ended, and endFlags are the mines flagged by
+	// winning it rather than by the player
+	reason   EndReason
+	endFlags map[Position]bool
+
+	// subscribers are sent the changes since the shown display
+	subscribers map[int]func(Event)
+	nextID      int
+	shown       map[Position]int
+	shownState  State
+	shownPaused bool
+
+	// layout is the position of every mine before the opening was cleared,
+	// which is nil until then
+	layout []Position
+
+	// rateElapsed and rateMoves are the time and moves of the last report of
+	// the rate of play, and rateDone is set once the end has been reported
+	rateElapsed time.Duration
+	rateMoves   int
+	rateDone    bool
+}
+
+// NewGame generates a new minefield as described by the config and starts a
+// game on it.
+func NewGame(cfg Config) (*Game, error) {
+	if (cfg.Mode == TimeAttack && cfg.TimeLimit <= 0) || (cfg.Mode == ClickLimited && cfg.Clicks == 0) {
+		return nil, ErrNoLimit
+	}
+	minefield, err := NewMinefieldConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return newGame(minefield, cfg), nil
+}
+
+// newGame starts a game on an existing minefield.
+func newGame(minefield *Minefield, cfg Config) *Game {
+	if cfg.Lives == 0 {
+		cfg.Lives = 1
+	}
+	if cfg.Win == nil && cfg.DefuseTime > 0 {
+		cfg.Win = DefuseAll
+	} else if cfg.Win == nil {
+		cfg.Win = ClearAll
+	}
+	cfg.Selector = nil
+	cfg.Neighborhood = minefield.neighborhood
+
+	splits := make(Splits, len(cfg.Splits))
+	for i, fraction := range cfg.Splits {
+		splits[i].Fraction = fraction
+	}
+	if cfg.Metrics != nil {
+		cfg.Metrics.GameCreated(cfg)
+	}
+	return &Game{
+		minefield: minefield,
+		config:    cfg,
+		lives:     cfg.Lives,
+		state:     Playing,
+		clock:     time.Now,
+		splits:    splits,
+		actions:   cfg.Actions,
+		clicks:    cfg.Clicks,
+		defusing:  make(map[Position]time.Time),
+		defused:   make(map[Position]bool),
+	}
+}
+
+// State returns the current state of the game.
+func (g *Game) State() State {
+	return g.state
+}
+
+// Lives returns the number of mines that can still be set off before the game
+// is lost.
+func (g *Game) Lives() uint {
+	return g.lives
+}
+
+// Elapsed returns the time passed since the first move, stopping once the
+// game is over.
+func (g *Game) Elapsed() time.Duration {
+	if g.start.IsZero() {
+		return 0
+	} else if !g.end.IsZero() {
+		return g.end.Sub(g.start)
+	} else if g.Paused() {
+		return g.paused.Sub(g.start)
+	}
+	return g.clock().Sub(g.start)
+}
+
+// Select reveals the block at the given position.  Setting off a mine costs a
+// life and marks the mine as Exploded; once all lives are lost every mine is
+// revealed.  The game is won once its win condition is met, at which point any
+// remaining mines are flagged.
+func (g *Game) Select(x, y int) (int, error) {
+	result, err := g.Apply(Move{Reveal, Position{X: x, Y: y}})
+	return result.Value, err
+}
+
+// ToggleFlag toggles the flag on a particular block while the game is being
+// played.  When defusing, placing a flag starts its defusal and removing it
+// calls the defusal off; defused flags cannot be removed.  With a FlagLimit,
+// placing more flags than there are mines returns ErrFlagLimit.
+func (g *Game) ToggleFlag(x, y int) error {
+	_, err := g.Apply(Move{Flag, Position{X: x, Y: y}})
+	return err
+}
+
+// Tick checks the win condition in between moves, for conditions that depend
+// on time, and returns the resulting state.
+func (g *Game) Tick() State {
+	if g.Paused() {
+		return g.state
+	} else if g.expire(); g.state == Playing && !g.start.IsZero() {
+		if g.checkWin(); g.state != Playing {
+			g.finish(g.clock())
+		}
+	}
+	elapsed := g.Elapsed()
+	g.publish(nil, elapsed)
+	g.reportRate(elapsed)
+	return g.state
+}
+
+// strict returns the error of a move that would do nothing in strict mode.
+func (g *Game) strict(pos Position, action Action) error {
+	if !g.config.Strict || action != Reveal && action != Flag && action != Question {
+		return nil
+	}
+	block, ok := g.minefield.peek(pos)
+	switch {
+	case !ok:
+		return nil
+	case block.checked():
+		return ErrAlreadyRevealed
+	case block.flagged() && action != Flag:
+		return ErrFlagged
+	}
+	return nil
+}
+
+// explode sets off the selected mine at the position, costing a life.
+func (g *Game) explode(pos Position) {
+	block, _ := g.minefield.block(pos)
+	block.Explode()
+	if g.lives--; g.lives == 0 {
+		g.minefield.revealMines()
+		g.state, g.reason = Lost, Detonated
+		g.detonated = pos
+	}
+}
+
+// checkWin ends the game if it is still being played and its win condition
+// has been met.
+func (g *Game) checkWin() {
+	if g.state == Playing && g.config.Win.Won(g) {
+		g.endFlags = make(map[Position]bool)
+		g.minefield.stored(func(pos Position, block *Block) {
+			if block.proximity() == Mine && !block.flagged() && !block.checked() {
+				g.endFlags[pos] = true
+			}
+		})
+		g.minefield.flagMines()
+		g.state, g.reason = Won, WinMet
+	}
+}
+
+// Display returns the current state of all the blocks.  Defused mines are shown
+// as Defused and, once the game is lost, flags that were not placed on a mine
+// are shown as WrongFlag.  In blind mode, revealed proximities are shown as
+// Revealed.
+func (g *Game) Display() map[Position]int {
+	display := make(map[Position]int)
+	g.minefield.each(func(pos Position, block *Block) {
+		display[pos] = g.value(pos)
+	})
+	return display
+}
+
+// EndState reports how a game ended.
+type EndState struct {
+	State State
+
+	// Mode is the mode the game was played in, and Reason is why it ended.
+	Mode   Mode
+	Reason EndReason
+
+	// Solved is the 3BV solved, which is the score of a time attack, and
+	// Moves is the number of moves made.
+	Solved, Moves int
+
+	// Detonated is the mine that lost the game.
+	Detonated Position
+
+	// WrongFlags are the flags that were not placed on a mine, and
+	// UnflaggedMines are the mines that were not flagged, both ordered by row.
+	WrongFlags, UnflaggedMines []Position
+}
+
+// EndState reports how the game ended, or only its State and Mode if it is
+// still being played.
+func (g *Game) EndState() EndState {
+	end := EndState{State: g.state, Mode: g.config.Mode}
+	if g.state == Playing {
+		return end
+	}
+
+	end.Reason = g.reason
+	_, end.Solved = g.minefield.bbbv()
+	end.Moves = len(g.moves)
+	if g.state != Lost {
+		return end
+	}
+
+	end.Detonated = g.detonated
+	g.minefield.stored(func(pos Position, block *Block) {
+		if block.flagged() && block.proximity() != Mine {
+			end.WrongFlags = append(end.WrongFlags, pos)
+		} else if !block.flagged() && block.proximity() == Mine {
+			end.UnflaggedMines = append(end.UnflaggedMines, pos)
+		}
+	})
+	sortPositions(end.WrongFlags)
+	sortPositions(end.UnflaggedMines)
+	return end
+}
+
+// record adds the move to the game's history, starting the clock on the first
+// move and stopping it when the game is over.
+func (g *Game) record(move Move) {
+	now := g.clock()
+	if g.start.IsZero() {
+		g.start = now
+	}
+	if g.state != Playing {
+		g.finish(now)
+	}
+	elapsed := now.Sub(g.start)
+	g.moves = append(g.moves, Record{move, elapsed})
+	if move.Action == Reveal {
+		g.split(elapsed)
+	}
+
+	if g.ghost != nil {
+		g.race(g.ghost.compare(g, elapsed))
+	}
+	g.publish(&move, elapsed)
+	g.reportRate(elapsed)
+}
@@ -0,0 +1,247 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+// newTestGame starts a game on the 5x5 example minefield.
+func newTestGame(c *C, cfg Config) *Game {
+	cfg.Width, cfg.Height, cfg.Mines = 5, 5, 5
+	cfg.Selector = func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+	}
+	game, err := NewGame(cfg)
+	c.Assert(err, IsNil)
+	return game
+}
+
+func (s *MSSuite) TestGame_Win(c *C) {
+	game := newTestGame(c, Config{})
+	c.Check(game.State(), Equals, Playing)
+	c.Check(game.Lives(), Equals, uint(1))
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+
+	for _, move := range []struct {
+		pos       Position
+		proximity int
+	}{
+		{Position{X: 4, Y: 2}, 0}, {Position{X: 0, Y: 4}, 0}, {Position{X: 0, Y: 1}, 2}, {Position{X: 0, Y: 2}, 1},
+		{Position{X: 1, Y: 0}, 2}, {Position{X: 1, Y: 1}, 3}, {Position{X: 2, Y: 0}, 1}, {Position{X: 2, Y: 2}, 2},
+		{Position{X: 3, Y: 0}, 2}, {Position{X: 4, Y: 4}, 1},
+	} {
+		c.Check(game.State(), Equals, Playing)
+		proximity, err := game.Select(move.pos.X, move.pos.Y)
+		c.Assert(err, IsNil)
+		c.Check(proximity, Equals, move.proximity)
+	}
+	c.Check(game.State(), Equals, Won)
+	c.Check(game.EndState(), DeepEquals, EndState{State: Won, Reason: WinMet, Solved: 10, Moves: 11})
+
+	// the remaining mines are flagged
+	display := game.Display()
+	for _, pos := range []Position{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 4}} {
+		c.Check(display[pos], Equals, Flagged)
+	}
+
+	_, err := game.Select(0, 0)
+	c.Check(err, Equals, ErrGameOver)
+	c.Check(game.ToggleFlag(0, 0), Equals, ErrGameOver)
+}
+
+func (s *MSSuite) TestGame_Lose(c *C) {
+	game := newTestGame(c, Config{})
+	c.Check(game.EndState(), DeepEquals, EndState{State: Playing})
+
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	c.Assert(game.ToggleFlag(0, 1), IsNil)
+	c.Assert(game.ToggleFlag(4, 4), IsNil)
+	proximity, err := game.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, Mine)
+	c.Check(game.State(), Equals, Lost)
+	c.Check(game.Lives(), Equals, uint(0))
+
+	display := game.Display()
+	c.Check(display[Position{X: 0, Y: 0}], Equals, Exploded)
+	c.Check(display[Position{X: 4, Y: 0}], Equals, Flagged)
+	c.Check(display[Position{X: 1, Y: 2}], Equals, Mine)
+	c.Check(display[Position{X: 2, Y: 1}], Equals, Mine)
+	c.Check(display[Position{X: 3, Y: 4}], Equals, Mine)
+	c.Check(display[Position{X: 0, Y: 1}], Equals, WrongFlag)
+	c.Check(display[Position{X: 4, Y: 4}], Equals, WrongFlag)
+	c.Check(display[Position{X: 1, Y: 1}], Equals, Unknown)
+
+	c.Check(game.EndState(), DeepEquals, EndState{
+		State:          Lost,
+		Reason:         Detonated,
+		Moves:          4,
+		Detonated:      Position{X: 0, Y: 0},
+		WrongFlags:     []Position{{X: 0, Y: 1}, {X: 4, Y: 4}},
+		UnflaggedMines: []Position{{X: 0, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 4}},
+	})
+}
+
+func (s *MSSuite) TestGame_Lives(c *C) {
+	game := newTestGame(c, Config{Lives: 2})
+	c.Check(game.Lives(), Equals, uint(2))
+
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	proximity, err := game.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, Mine)
+	c.Check(game.State(), Equals, Playing)
+	c.Check(game.Lives(), Equals, uint(1))
+
+	// only the exploded mine is revealed
+	display := game.Display()
+	c.Check(display[Position{X: 0, Y: 0}], Equals, Exploded)
+	c.Check(display[Position{X: 4, Y: 0}], Equals, Flagged)
+	c.Check(display[Position{X: 1, Y: 2}], Equals, Unknown)
+
+	proximity, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, Checked)
+	c.Check(game.Lives(), Equals, uint(1))
+
+	proximity, err = game.Select(1, 2)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, Mine)
+	c.Check(game.State(), Equals, Lost)
+
+	display = game.Display()
+	c.Check(display[Position{X: 0, Y: 0}], Equals, Exploded)
+	c.Check(display[Position{X: 1, Y: 2}], Equals, Exploded)
+	c.Check(display[Position{X: 4, Y: 0}], Equals, Flagged)
+	c.Check(display[Position{X: 2, Y: 1}], Equals, Mine)
+}
+
+func (s *MSSuite) TestGame_AutoFlag(c *C) {
+	game := newTestGame(c, Config{AutoFlag: true})
+	var events []Event
+	game.Subscribe(func(event Event) {
+		events = append(events, event)
+	})
+
+	_, err := game.Select(0, 4)
+	c.Assert(err, IsNil)
+	c.Check(game.Display()[Position{X: 3, Y: 4}], Equals, Unknown)
+
+	// the 1 at (2,4) is left with a single hidden neighbor
+	_, err = game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(game.Display()[Position{X: 3, Y: 4}], Equals, Flagged)
+	c.Assert(events, HasLen, 2)
+	c.Check(events[1].Changes, DeepEquals, []Change{
+		{Position{X: 3, Y: 1}, 2}, {Position{X: 4, Y: 1}, 1},
+		{Position{X: 3, Y: 2}, 1}, {Position{X: 4, Y: 2}, 0},
+		{Position{X: 3, Y: 3}, 1}, {Position{X: 4, Y: 3}, 1},
+		{Position{X: 3, Y: 4}, Flagged},
+	})
+	c.Check(game.Replay().Rules.AutoFlag, Equals, true)
+
+	// and only with the rule
+	game = newTestGame(c, Config{})
+	_, err = game.Select(0, 4)
+	c.Assert(err, IsNil)
+	_, err = game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(game.Display()[Position{X: 3, Y: 4}], Equals, Unknown)
+}
+
+func (s *MSSuite) TestGame_OpeningSize(c *C) {
+	game := newTestGame(c, Config{OpeningSize: 1})
+	c.Assert(game.ToggleFlag(4, 4), IsNil)
+	_, err := game.Apply(Move{Question, Position{X: 2, Y: 4}})
+	c.Assert(err, IsNil)
+	proximity, err := game.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, 0)
+	c.Check(game.State(), Equals, Playing)
+	c.Check(game.minefield.mines(), DeepEquals, []Position{{X: 4, Y: 0}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 4}})
+	c.Check(game.Display()[Position{X: 1, Y: 1}], Equals, 2)
+	c.Check(game.Display()[Position{X: 4, Y: 4}], Equals, Flagged)
+	c.Check(game.Display()[Position{X: 2, Y: 4}], Equals, Questioned)
+
+	// only the first selection is cleared
+	proximity, err = game.Select(4, 0)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, Mine)
+
+	// the replay starts from the original layout and clears it the same way
+	replay := game.Replay()
+	c.Check(replay.Layout, DeepEquals, []Position{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 4}})
+	c.Check(replay.Rules.OpeningSize, Equals, uint(1))
+	again, err := replay.Play()
+	c.Assert(err, IsNil)
+	c.Check(again.Display(), DeepEquals, game.Display())
+}
+
+func (s *MSSuite) TestGame_OpeningSizeFull(c *C) {
+	// each block counts the whole row below it, so the block below the first
+	// selection would count 31 mines once the mine there is moved
+	deltas := Deltas{{X: 0, Y: 1}}
+	for x := 1; x < 32; x++ {
+		deltas = append(deltas, Position{X: x, Y: 1})
+	}
+	var mines []Position
+	for x := 0; x < 32; x++ {
+		mines = append(mines, Position{X: x, Y: 0})
+		if x > 0 && x < 31 {
+			mines = append(mines, Position{X: x, Y: 2})
+		}
+	}
+	game, err := NewGame(Config{Width: 32, Height: 3, Mines: uint(len(mines)), Neighborhood: deltas, OpeningSize: 1, Selector: func(width, height, max uint) ([]Position, error) {
+		return mines, nil
+	}})
+	c.Assert(err, IsNil)
+
+	_, err = game.Select(0, 0)
+	c.Check(err, Equals, ErrProximityFull)
+	c.Check(game.minefield.mines(), HasLen, len(mines))
+	c.Check(game.minefield.mines()[0], Equals, Position{X: 0, Y: 0})
+	c.Check(game.Replay().Moves, HasLen, 0)
+	c.Check(game.layout, IsNil)
+}
+
+func (s *MSSuite) TestGame_FlagLimit(c *C) {
+	game := newTestGame(c, Config{FlagLimit: true})
+	for x := 0; x < 5; x++ {
+		c.Assert(game.ToggleFlag(x, 3), IsNil)
+	}
+	c.Check(game.ToggleFlag(0, 4), Equals, ErrFlagLimit)
+	c.Check(game.Display()[Position{X: 0, Y: 4}], Equals, Unknown)
+	c.Check(game.Replay().Moves, HasLen, 5)
+	c.Check(game.Replay().Rules.FlagLimit, Equals, true)
+
+	// flags can still be removed, making room for another
+	c.Assert(game.ToggleFlag(0, 3), IsNil)
+	c.Assert(game.ToggleFlag(0, 4), IsNil)
+	c.Check(game.minefield.Flags(), Equals, 5)
+}
+
+func (s *MSSuite) TestGame_Strict(c *C) {
+	game := newTestGame(c, Config{Strict: true})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(0, 4), IsNil)
+
+	_, err = game.Select(4, 1)
+	c.Check(err, Equals, ErrAlreadyRevealed)
+	_, err = game.Select(0, 4)
+	c.Check(err, Equals, ErrFlagged)
+	c.Check(game.ToggleFlag(4, 1), Equals, ErrAlreadyRevealed)
+	_, err = game.Select(9, 9)
+	c.Check(err, DeepEquals, &OutOfBoundsError{Position{X: 9, Y: 9}, 5, 5})
+
+	// refused moves are not recorded
+	c.Check(game.Replay().Moves, HasLen, 2)
+	c.Check(game.Replay().Rules.Strict, Equals, true)
+
+	// and without strict mode they do nothing
+	game = newTestGame(c, Config{})
+	_, err = game.Select(4, 2)
+	c.Assert(err, IsNil)
+	proximity, err := game.Select(4, 1)
+	c.Check(err, IsNil)
+	c.Check(proximity, Equals, Checked)
+}
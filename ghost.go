@@ -0,0 +1,77 @@
+package gominesweeper
+
+import (
+	"time"
+)
+
+// GhostEvent compares the progress of a game against a ghost at a point in
+// time.
+type GhostEvent struct {
+	Elapsed time.Duration
+
+	// Revealed and GhostRevealed are the number of blocks without mines
+	// revealed by the player and the ghost respectively.
+	Revealed, GhostRevealed int
+}
+
+// Ahead returns the number of blocks the player is ahead of the ghost, or a
+// negative number if the player is behind.
+func (e GhostEvent) Ahead() int {
+	return e.Revealed - e.GhostRevealed
+}
+
+// Ghost plays a replay back in step with a live game on the same minefield.
+type Ghost struct {
+	game  *Game
+	moves []Record
+}
+
+// NewGhost prepares the replay to be raced against.
+func NewGhost(replay Replay) (*Ghost, error) {
+	game, err := replay.NewGame()
+	if err != nil {
+		return nil, err
+	}
+	return &Ghost{game: game, moves: replay.Moves}, nil
+}
+
+// Revealed plays the ghost forward to the elapsed time and returns the number
+// of blocks it has revealed by then.  A ghost cannot be played backwards.
+func (gh *Ghost) Revealed(elapsed time.Duration) int {
+	for len(gh.moves) > 0 && gh.moves[0].Elapsed <= elapsed {
+		gh.game.Apply(gh.moves[0].Move)
+		gh.moves = gh.moves[1:]
+	}
+	return gh.game.minefield.revealed()
+}
+
+// Compare returns how the game is faring against the ghost at the game's
+// elapsed time.
+func (gh *Ghost) Compare(g *Game) GhostEvent {
+	return gh.compare(g, g.Elapsed())
+}
+
+// compare returns how the game is faring against the ghost at the elapsed
+// time.
+func (gh *Ghost) compare(g *Game, elapsed time.Duration) GhostEvent {
+	return GhostEvent{
+		Elapsed:       elapsed,
+		Revealed:      g.minefield.revealed(),
+		GhostRevealed: gh.Revealed(elapsed),
+	}
+}
+
+// Race races the game against a replay of the same minefield, calling fn with
+// the comparison after every move.  The returned Ghost may also be compared
+// against in between moves, e.g. on a timer.
+func (g *Game) Race(replay Replay, fn func(GhostEvent)) (*Ghost, error) {
+	if !replay.Matches(g) {
+		return nil, ErrReplayMismatch
+	}
+	ghost, err := NewGhost(replay)
+	if err != nil {
+		return nil, err
+	}
+	g.ghost, g.race = ghost, fn
+	return ghost, nil
+}
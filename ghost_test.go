@@ -0,0 +1,51 @@
+package gominesweeper
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGhost(c *C) {
+	// the ghost opens both zeros in its first two moves
+	ghost := newTestGame(c, Config{})
+	ghost.clock = fakeClock()
+	_, err := ghost.Select(4, 2)
+	c.Assert(err, IsNil)
+	_, err = ghost.Select(0, 4)
+	c.Assert(err, IsNil)
+	_, err = ghost.Select(0, 1)
+	c.Assert(err, IsNil)
+
+	game := newTestGame(c, Config{})
+	game.clock = fakeClock()
+
+	var events []GhostEvent
+	_, err = game.Race(ghost.Replay(), func(event GhostEvent) {
+		events = append(events, event)
+	})
+	c.Assert(err, IsNil)
+
+	_, err = game.Select(0, 4)
+	c.Assert(err, IsNil)
+	_, err = game.Select(1, 1)
+	c.Assert(err, IsNil)
+	_, err = game.Select(4, 4)
+	c.Assert(err, IsNil)
+
+	c.Check(events, DeepEquals, []GhostEvent{
+		{Elapsed: 0, Revealed: 6, GhostRevealed: 6},
+		{Elapsed: time.Second, Revealed: 7, GhostRevealed: 12},
+		{Elapsed: 2 * time.Second, Revealed: 8, GhostRevealed: 13},
+	})
+	c.Check(events[0].Ahead(), Equals, 0)
+	c.Check(events[2].Ahead(), Equals, -5)
+
+	// racing requires the same minefield
+	other, err := NewGame(Config{Width: 6, Height: 5, Mines: 5, Selector: func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+	}})
+	c.Assert(err, IsNil)
+	_, err = other.Race(ghost.Replay(), func(GhostEvent) {})
+	c.Check(err, Equals, ErrReplayMismatch)
+}
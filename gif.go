@@ -0,0 +1,83 @@
+package gominesweeper
+
+import (
+	"image"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// GIFOptions are how a replay is exported as an animation.
+type GIFOptions struct {
+	// Theme draws each frame, or ClassicTheme if it has no CellSize.
+	Theme Theme
+
+	// Delay is how long each move is shown for.  When it is zero, each move
+	// is shown until the next one was made, but for no longer than MaxDelay
+	// if it is set.
+	Delay    time.Duration
+	MaxDelay time.Duration
+
+	// Hold is how long the end of the game is shown for before the animation
+	// starts over, or 2 seconds if it is zero.
+	Hold time.Duration
+}
+
+// minDelay is the shortest delay between frames that viewers honor.
+const minDelay = 20 * time.Millisecond
+
+// ExportGIF writes an animation of the replay being played, with a frame for
+// the empty minefield and one after every move.
+func ExportGIF(w io.Writer, replay Replay, opts GIFOptions) error {
+	theme := opts.Theme
+	if theme.CellSize == 0 {
+		theme = ClassicTheme
+	}
+	hold := opts.Hold
+	if hold == 0 {
+		hold = 2 * time.Second
+	}
+
+	game, err := replay.NewGame()
+	if err != nil {
+		return err
+	}
+	palette := theme.palette()
+	anim := &gif.GIF{}
+	frame := func() {
+		img := theme.draw(game.Snapshot())
+		paletted := image.NewPaletted(img.Bounds(), palette)
+		draw.Draw(paletted, img.Bounds(), img, image.Point{}, draw.Src)
+		anim.Image = append(anim.Image, paletted)
+	}
+
+	frame()
+	var shown time.Duration
+	for _, record := range replay.Moves {
+		anim.Delay = append(anim.Delay, opts.delay(record.Elapsed-shown))
+		shown = record.Elapsed
+		if err := game.playback([]Record{record}); err != nil {
+			return err
+		}
+		frame()
+	}
+	anim.Delay = append(anim.Delay, centiseconds(hold))
+	return gif.EncodeAll(w, anim)
+}
+
+// delay returns the delay of a frame that was shown for the duration while
+// the game was played, in 100ths of a second.
+func (opts GIFOptions) delay(d time.Duration) int {
+	if opts.Delay > 0 {
+		d = opts.Delay
+	} else if opts.MaxDelay > 0 && d > opts.MaxDelay {
+		d = opts.MaxDelay
+	}
+	return centiseconds(max(d, minDelay))
+}
+
+// centiseconds returns the duration in 100ths of a second.
+func centiseconds(d time.Duration) int {
+	return int(d / (10 * time.Millisecond))
+}
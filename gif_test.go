@@ -0,0 +1,43 @@
+package gominesweeper
+
+import (
+	"bytes"
+	"image/gif"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestExportGIF(c *C) {
+	game := newTestGame(c, Config{})
+	game.clock = fakeClock()
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(1, 1), IsNil)
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+
+	var buf bytes.Buffer
+	c.Assert(ExportGIF(&buf, game.Replay(), GIFOptions{MaxDelay: 500 * time.Millisecond}), IsNil)
+	anim, err := gif.DecodeAll(&buf)
+	c.Assert(err, IsNil)
+	c.Assert(anim.Image, HasLen, 4)
+	c.Check(anim.Delay, DeepEquals, []int{2, 50, 50, 200})
+
+	// the flood fill from 4,2 shows in the second frame, and the mine that
+	// went off in the last
+	size := ClassicTheme.CellSize
+	c.Check(anim.Image[0].At(size*4+2, size*2+2), Equals, ClassicTheme.Hidden)
+	c.Check(anim.Image[1].At(size*4+2, size*2+2), Equals, ClassicTheme.Revealed)
+	c.Check(anim.Image[1].At(size*4+2, size*4+2), Equals, ClassicTheme.Hidden)
+	c.Check(anim.Image[2].At(2, 2), Equals, ClassicTheme.Hidden)
+	c.Check(anim.Image[3].At(2, 2), Equals, ClassicTheme.Exploded)
+
+	buf.Reset()
+	c.Assert(ExportGIF(&buf, game.Replay(), GIFOptions{Delay: time.Second, Hold: time.Second}), IsNil)
+	anim, err = gif.DecodeAll(&buf)
+	c.Assert(err, IsNil)
+	c.Check(anim.Delay, DeepEquals, []int{100, 100, 100, 100})
+
+	c.Check(ExportGIF(&buf, Replay{}, GIFOptions{}), Equals, ErrReplayVersion)
+}
@@ -0,0 +1,315 @@
+package gominesweeper
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+// Theme is how a board is drawn as an image.
+type Theme struct {
+	// CellSize is the width and height of a block in pixels.
+	CellSize int
+
+	Hidden, Light, Shadow color.RGBA // hidden blocks and their bevel
+	Revealed, Grid        color.RGBA // revealed blocks and the lines between them
+	Mine, Exploded        color.RGBA
+	Flag, Defused         color.RGBA
+
+	// Numbers are the colors of the proximities 1 to 8; larger proximities
+	// use the last color.
+	Numbers [8]color.RGBA
+
+	// HeatMap overlays every hidden block with Heat, made as opaque as the
+	// block is likely to be a mine according to Solver.Probabilities.
+	HeatMap bool
+	Heat    color.RGBA
+}
+
+// ClassicTheme draws the board with the grey raised tiles and colored numbers
+// of the original game.
+var ClassicTheme = Theme{
+	CellSize: 16,
+	Hidden:   color.RGBA{0xc0, 0xc0, 0xc0, 0xff},
+	Light:    color.RGBA{0xff, 0xff, 0xff, 0xff},
+	Shadow:   color.RGBA{0x80, 0x80, 0x80, 0xff},
+	Revealed: color.RGBA{0xc0, 0xc0, 0xc0, 0xff},
+	Grid:     color.RGBA{0x80, 0x80, 0x80, 0xff},
+	Mine:     color.RGBA{0x00, 0x00, 0x00, 0xff},
+	Exploded: color.RGBA{0xff, 0x00, 0x00, 0xff},
+	Flag:     color.RGBA{0xff, 0x00, 0x00, 0xff},
+	Defused:  color.RGBA{0x00, 0x80, 0x00, 0xff},
+	Heat:     color.RGBA{0xff, 0x00, 0x00, 0xc0},
+	Numbers: [8]color.RGBA{
+		{0x00, 0x00, 0xff, 0xff},
+		{0x00, 0x80, 0x00, 0xff},
+		{0xff, 0x00, 0x00, 0xff},
+		{0x00, 0x00, 0x80, 0xff},
+		{0x80, 0x00, 0x00, 0xff},
+		{0x00, 0x80, 0x80, 0xff},
+		{0x00, 0x00, 0x00, 0xff},
+		{0x80, 0x80, 0x80, 0xff},
+	},
+}
+
+func init() {
+	RegisterRenderer("png", ClassicTheme.PNG())
+	RegisterRenderer("svg", ClassicTheme.SVG())
+}
+
+// glyphs are the characters drawn on blocks in a PNG, as rows of 3 pixels.
+var glyphs = map[byte][5]string{
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"##.", "..#", ".#.", "#..", "###"},
+	'3': {"##.", "..#", ".#.", "..#", "##."},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "##.", "..#", "##."},
+	'6': {".##", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", ".#.", ".#.", ".#."},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "##."},
+	'+': {"...", ".#.", "###", ".#.", "..."},
+	'?': {"##.", "..#", ".#.", "...", ".#."},
+}
+
+// label returns the character drawn on a revealed block.
+func label(value int) byte {
+	switch {
+	case value == Revealed:
+		return '?'
+	case value > 9:
+		return '+'
+	}
+	return '0' + byte(value)
+}
+
+// number returns the color of a proximity.
+func (t Theme) number(value int) color.RGBA {
+	if value > len(t.Numbers) {
+		value = len(t.Numbers)
+	}
+	return t.Numbers[value-1]
+}
+
+// PNG returns a renderer that draws the snapshot as a PNG image.
+func (t Theme) PNG() Renderer {
+	return func(w io.Writer, s Snapshot) error {
+		return png.Encode(w, t.draw(s))
+	}
+}
+
+// draw draws the snapshot as an image.
+func (t Theme) draw(s Snapshot) *image.RGBA {
+	size := t.CellSize
+	img := image.NewRGBA(image.Rect(0, 0, s.Width*size, s.Height*size))
+	heat := t.heat(s)
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			cell := image.Rect(x*size, y*size, (x+1)*size, (y+1)*size)
+			t.drawBlock(img, cell, s.Blocks[Position{X: x, Y: y}])
+			if odds, ok := heat[Position{X: x, Y: y}]; ok {
+				c := color.NRGBA{t.Heat.R, t.Heat.G, t.Heat.B, uint8(odds * float64(t.Heat.A))}
+				draw.Draw(img, cell, image.NewUniform(c), image.Point{}, draw.Over)
+			}
+		}
+	}
+	return img
+}
+
+// heat returns the odds of each hidden block being a mine when the theme has
+// a heat map.
+func (t Theme) heat(s Snapshot) map[Position]float64 {
+	if !t.HeatMap {
+		return nil
+	}
+	return NewSolver(s).Probabilities()
+}
+
+// palette returns every color of the theme.
+func (t Theme) palette() color.Palette {
+	var palette color.Palette
+	seen := make(map[color.RGBA]bool)
+	for _, c := range append([]color.RGBA{t.Hidden, t.Light, t.Shadow, t.Revealed, t.Grid, t.Mine, t.Exploded, t.Flag, t.Defused}, t.Numbers[:]...) {
+		if !seen[c] {
+			seen[c] = true
+			palette = append(palette, c)
+		}
+	}
+	return palette
+}
+
+// drawBlock draws the value of a block in the cell.
+func (t Theme) drawBlock(img *image.RGBA, cell image.Rectangle, value int) {
+	switch value {
+	case Unknown, Flagged, Questioned:
+		t.drawTile(img, cell)
+		if value == Flagged {
+			t.drawFlag(img, cell, t.Flag)
+		} else if value == Questioned {
+			drawGlyph(img, cell, glyphs['?'], t.Mine)
+		}
+		return
+	case Exploded:
+		fill(img, cell, t.Exploded)
+	default:
+		fill(img, cell, t.Revealed)
+	}
+	fill(img, image.Rect(cell.Min.X, cell.Min.Y, cell.Max.X, cell.Min.Y+1), t.Grid)
+	fill(img, image.Rect(cell.Min.X, cell.Min.Y, cell.Min.X+1, cell.Max.Y), t.Grid)
+
+	switch value {
+	case Mine, Exploded:
+		drawMine(img, cell, t.Mine)
+	case Defused:
+		drawMine(img, cell, t.Defused)
+	case WrongFlag:
+		drawMine(img, cell, t.Mine)
+		for i := cell.Dx() / 4; i < cell.Dx()*3/4; i++ {
+			img.SetRGBA(cell.Min.X+i, cell.Min.Y+i, t.Exploded)
+			img.SetRGBA(cell.Max.X-1-i, cell.Min.Y+i, t.Exploded)
+		}
+	case 0:
+	case Revealed:
+		drawGlyph(img, cell, glyphs[label(value)], t.Shadow)
+	default:
+		drawGlyph(img, cell, glyphs[label(value)], t.number(value))
+	}
+}
+
+// drawTile draws a raised hidden block.
+func (t Theme) drawTile(img *image.RGBA, cell image.Rectangle) {
+	bevel := max(cell.Dx()/8, 1)
+	fill(img, cell, t.Shadow)
+	fill(img, image.Rect(cell.Min.X, cell.Min.Y, cell.Max.X-bevel, cell.Max.Y-bevel), t.Light)
+	fill(img, cell.Inset(bevel), t.Hidden)
+}
+
+// drawFlag draws a flag on its pole.
+func (t Theme) drawFlag(img *image.RGBA, cell image.Rectangle, c color.RGBA) {
+	size := cell.Dx()
+	pole := cell.Min.X + size/2
+	fill(img, image.Rect(pole, cell.Min.Y+size/4, pole+max(size/16, 1), cell.Max.Y-size/4), t.Mine)
+	fill(img, image.Rect(cell.Min.X+size/4, cell.Max.Y-size/4, cell.Max.X-size/4, cell.Max.Y-size/4+max(size/16, 1)), t.Mine)
+	height := size / 4
+	for i := 0; i < height; i++ {
+		fill(img, image.Rect(pole-height+i, cell.Min.Y+size/4+i/2, pole, cell.Min.Y+size/4+height-i/2), c)
+	}
+}
+
+// drawMine draws a mine as a disc in the middle of the cell.
+func drawMine(img *image.RGBA, cell image.Rectangle, c color.RGBA) {
+	center := cell.Min.Add(cell.Max).Div(2)
+	radius := cell.Dx() / 4
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				img.SetRGBA(center.X+x, center.Y+y, c)
+			}
+		}
+	}
+}
+
+// drawGlyph draws the glyph in the middle of the cell, scaled to fit.
+func drawGlyph(img *image.RGBA, cell image.Rectangle, glyph [5]string, c color.RGBA) {
+	scale := max(cell.Dx()/8, 1)
+	origin := cell.Min.Add(image.Pt(cell.Dx()-3*scale, cell.Dy()-5*scale).Div(2))
+	for y, row := range glyph {
+		for x := range row {
+			if row[x] == '#' {
+				corner := origin.Add(image.Pt(x*scale, y*scale))
+				fill(img, image.Rectangle{corner, corner.Add(image.Pt(scale, scale))}, c)
+			}
+		}
+	}
+}
+
+// fill paints the rectangle in a solid color.
+func fill(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// SVG returns a renderer that draws the snapshot as an SVG image.
+func (t Theme) SVG() Renderer {
+	return func(w io.Writer, s Snapshot) error {
+		size := t.CellSize
+		buf := bufio.NewWriter(w)
+		fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+			s.Width*size, s.Height*size, s.Width*size, s.Height*size)
+		heat := t.heat(s)
+		for y := 0; y < s.Height; y++ {
+			for x := 0; x < s.Width; x++ {
+				t.svgBlock(buf, x*size, y*size, s.Blocks[Position{X: x, Y: y}])
+				if odds, ok := heat[Position{X: x, Y: y}]; ok {
+					fmt.Fprintf(buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" fill-opacity="%.3f"/>`+"\n",
+						x*size, y*size, size, size, hex(t.Heat), odds*float64(t.Heat.A)/0xff)
+				}
+			}
+		}
+		buf.WriteString("</svg>\n")
+		return buf.Flush()
+	}
+}
+
+// svgBlock writes the elements that draw the value of a block at x, y.
+func (t Theme) svgBlock(w io.Writer, x, y, value int) {
+	size := t.CellSize
+	half := float64(size) / 2
+	rect := func(c color.RGBA) {
+		fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="%s" stroke-width="1"/>`+"\n",
+			x, y, size, size, hex(c), hex(t.Grid))
+	}
+	mine := func(c color.RGBA) {
+		fmt.Fprintf(w, `<circle cx="%g" cy="%g" r="%g" fill="%s"/>`+"\n",
+			float64(x)+half, float64(y)+half, float64(size)/4, hex(c))
+	}
+	text := func(s string, c color.RGBA) {
+		fmt.Fprintf(w, `<text x="%g" y="%g" font-family="monospace" font-weight="bold" font-size="%d" text-anchor="middle" dominant-baseline="central" fill="%s">%s</text>`+"\n",
+			float64(x)+half, float64(y)+half, size*3/4, hex(c), s)
+	}
+
+	switch value {
+	case Unknown, Flagged, Questioned:
+		bevel := max(size/8, 1)
+		fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x, y, size, size, hex(t.Shadow))
+		fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x, y, size-bevel, size-bevel, hex(t.Light))
+		fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x+bevel, y+bevel, size-2*bevel, size-2*bevel, hex(t.Hidden))
+		if value == Flagged {
+			fmt.Fprintf(w, `<polygon points="%g,%g %g,%g %g,%g" fill="%s"/>`+"\n",
+				float64(x)+half, float64(y+size/4), float64(x)+half, float64(y)+half, float64(x+size/4), float64(y)+3*float64(size)/8, hex(t.Flag))
+			fmt.Fprintf(w, `<line x1="%g" y1="%d" x2="%g" y2="%d" stroke="%s"/>`+"\n",
+				float64(x)+half, y+size/4, float64(x)+half, y+size*3/4, hex(t.Mine))
+		} else if value == Questioned {
+			text("?", t.Mine)
+		}
+	case Exploded:
+		rect(t.Exploded)
+		mine(t.Mine)
+	case Mine:
+		rect(t.Revealed)
+		mine(t.Mine)
+	case Defused:
+		rect(t.Revealed)
+		mine(t.Defused)
+	case WrongFlag:
+		rect(t.Revealed)
+		mine(t.Mine)
+		text("X", t.Exploded)
+	case 0:
+		rect(t.Revealed)
+	case Revealed:
+		rect(t.Revealed)
+		text("?", t.Shadow)
+	default:
+		rect(t.Revealed)
+		text(string(label(value)), t.number(value))
+	}
+}
+
+// hex returns the color in the #rrggbb notation.
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
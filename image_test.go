@@ -0,0 +1,73 @@
+package gominesweeper
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestRenderImage(c *C) {
+	game := newTestGame(c, Config{})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(1, 1), IsNil)
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+
+	renderer, err := LookupRenderer("png")
+	c.Assert(err, IsNil)
+	var buf bytes.Buffer
+	c.Assert(renderer(&buf, game.Snapshot()), IsNil)
+	img, err := png.Decode(&buf)
+	c.Assert(err, IsNil)
+	c.Check(img.Bounds().Dx(), Equals, 5*ClassicTheme.CellSize)
+	c.Check(img.Bounds().Dy(), Equals, 5*ClassicTheme.CellSize)
+
+	// the mine that went off is on red, and the middle of a hidden block is grey
+	size := ClassicTheme.CellSize
+	c.Check(img.At(2, 2), Equals, ClassicTheme.Exploded)
+	c.Check(img.At(size, 0), Equals, ClassicTheme.Light)
+	c.Check(img.At(size*5/2, size*9/2), Equals, ClassicTheme.Hidden)
+
+	themed := ClassicTheme
+	themed.CellSize = 10
+	buf.Reset()
+	c.Assert(themed.SVG()(&buf, game.Snapshot()), IsNil)
+	svg := buf.String()
+	c.Check(strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="50" height="50"`), Equals, true)
+	c.Check(strings.Count(svg, "<circle"), Equals, 6)
+	c.Check(strings.Count(svg, ">1</text>"), Equals, 4)
+	c.Check(strings.Count(svg, ">2</text>"), Equals, 1)
+	c.Check(strings.Count(svg, "<polygon"), Equals, 0)
+}
+
+func (s *MSSuite) TestRenderHeatMap(c *C) {
+	game := newTestGame(c, Config{})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+
+	theme := ClassicTheme
+	theme.HeatMap = true
+	var buf bytes.Buffer
+	c.Assert(theme.PNG()(&buf, game.Snapshot()), IsNil)
+	img, err := png.Decode(&buf)
+	c.Assert(err, IsNil)
+
+	// safe blocks are left as they are, and the redder the riskier
+	size := ClassicTheme.CellSize
+	middle := func(x, y int) (r, g, b uint32) {
+		r, g, b, _ = img.At(x*size+size/2, y*size+size/2).RGBA()
+		return r, g, b
+	}
+	c.Check(img.At(2*size+size/2, size/2), Equals, ClassicTheme.Hidden)
+	mineR, mineG, _ := middle(2, 1)
+	evenR, evenG, _ := middle(3, 0)
+	c.Check(mineR > evenR && mineG < evenG, Equals, true)
+
+	buf.Reset()
+	c.Assert(theme.SVG()(&buf, game.Snapshot()), IsNil)
+	c.Check(strings.Count(buf.String(), `fill-opacity="0.753"`), Equals, 1)
+	c.Check(strings.Count(buf.String(), `fill-opacity="0.376"`), Equals, 4)
+}
@@ -0,0 +1,168 @@
+package gominesweeper
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// ChunkSize is the width and height of the chunks that an InfiniteField places
+// its mines in.
+const ChunkSize = 16
+
+// maxReveal is the most blocks an InfiniteField reveals in a single Select, as
+// an opening may go on forever when there are few mines.
+const maxReveal = 64 * ChunkSize * ChunkSize
+
+// InfiniteField is a minefield without bounds for endless play.  Its mines are
+// placed a chunk at a time, when a block in or next to the chunk is first
+// needed, and the same seed always places them in the same positions.
+type InfiniteField struct {
+	seed  int64
+	mines uint
+
+	// chunks holds the mines of each chunk placed so far, keyed by the
+	// position of the chunk, and blocks holds the blocks played on
+	chunks map[Position]map[Position]bool
+	blocks map[Position]*Block
+}
+
+// NewInfiniteField returns an infinite minefield with the number of mines in
+// every chunk.
+func NewInfiniteField(seed int64, mines uint) (*InfiniteField, error) {
+	if mines >= ChunkSize*ChunkSize {
+		return nil, ErrExceedDimensions
+	}
+	return &InfiniteField{
+		seed:   seed,
+		mines:  mines,
+		chunks: make(map[Position]map[Position]bool),
+		blocks: make(map[Position]*Block),
+	}, nil
+}
+
+// Select reveals the block at the position, recursively revealing the
+// neighbors of any 0 up to a limit, and returns its proximity as Block.Select
+// does.  A selected mine is marked as Exploded.
+func (f *InfiniteField) Select(x, y int) int {
+	pos := Position{X: x, Y: y}
+	block := f.block(pos)
+	proximity := block.Select()
+	if proximity == Mine {
+		block.Explode()
+	} else if proximity == 0 {
+		f.open(pos)
+	}
+	return proximity
+}
+
+// open reveals the opening around the 0 at the position, breadth first so
+// that the blocks nearest the position are revealed before the limit is hit.
+func (f *InfiniteField) open(pos Position) {
+	queue := []Position{pos}
+	for revealed := 1; len(queue) > 0; queue = queue[1:] {
+		for _, neighbor := range Surrounding.Neighbors(queue[0]) {
+			if revealed >= maxReveal {
+				return
+			}
+			switch f.block(neighbor).Select() {
+			case 0:
+				queue = append(queue, neighbor)
+				revealed++
+			case Checked, Flagged:
+			default:
+				revealed++
+			}
+		}
+	}
+}
+
+// ToggleFlag toggles the flag on the block at the position.
+func (f *InfiniteField) ToggleFlag(x, y int) {
+	f.block(Position{X: x, Y: y}).ToggleFlag()
+}
+
+// Check returns the state of the block at the position as Block.Check does,
+// without placing any mines.
+func (f *InfiniteField) Check(x, y int) int {
+	if block, ok := f.blocks[Position{X: x, Y: y}]; ok {
+		return block.Check()
+	}
+	return Unknown
+}
+
+// Viewport returns a snapshot of the blocks in the rectangle at the origin,
+// with their positions relative to the origin, so that a client can show the
+// part of the field that is in view.  Mines is the number of mines expected in
+// a rectangle of that size.
+func (f *InfiniteField) Viewport(origin Position, width, height int) Snapshot {
+	blocks := make(map[Position]int, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			blocks[Position{X: x, Y: y}] = f.Check(origin.X+x, origin.Y+y)
+		}
+	}
+	return Snapshot{
+		Origin:       origin,
+		Width:        width,
+		Height:       height,
+		Mines:        int(math.Round(float64(f.mines) * float64(width*height) / (ChunkSize * ChunkSize))),
+		Neighborhood: Surrounding,
+		State:        Playing,
+		Blocks:       blocks,
+	}
+}
+
+// block returns the block at the position, counting its proximity when it is
+// first needed.
+func (f *InfiniteField) block(pos Position) *Block {
+	if block, ok := f.blocks[pos]; ok {
+		return block
+	}
+
+	proximity := 0
+	if f.mine(pos) {
+		proximity = Mine
+	} else {
+		for _, neighbor := range Surrounding.Neighbors(pos) {
+			if f.mine(neighbor) {
+				proximity++
+			}
+		}
+	}
+	block := NewBlock(proximity)
+	f.blocks[pos] = block
+	return block
+}
+
+// mine returns true if there is a mine at the position, placing the mines of
+// its chunk if they have not been placed yet.
+func (f *InfiniteField) mine(pos Position) bool {
+	chunk := Position{X: floorDiv(pos.X, ChunkSize), Y: floorDiv(pos.Y, ChunkSize)}
+	mines, ok := f.chunks[chunk]
+	if !ok {
+		mines = make(map[Position]bool, f.mines)
+		positions, _ := SeededSelector(chunkSeed(f.seed, chunk))(ChunkSize, ChunkSize, f.mines)
+		for _, mine := range positions {
+			mines[Position{X: chunk.X*ChunkSize + mine.X, Y: chunk.Y*ChunkSize + mine.Y}] = true
+		}
+		f.chunks[chunk] = mines
+	}
+	return mines[pos]
+}
+
+// chunkSeed returns the seed of the chunk at the position by hashing it with
+// the field's seed.
+func chunkSeed(seed int64, chunk Position) int64 {
+	h := fnv.New64a()
+	binary.Write(h, binary.LittleEndian, [3]int64{seed, int64(chunk.X), int64(chunk.Y)})
+	return int64(h.Sum64())
+}
+
+// floorDiv divides a by b, rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	if a < 0 {
+		return (a - b + 1) / b
+	}
+	return a / b
+}
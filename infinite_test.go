@@ -0,0 +1,79 @@
+package gominesweeper
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestInfiniteField(c *C) {
+	_, err := NewInfiniteField(42, ChunkSize*ChunkSize)
+	c.Check(err, Equals, ErrExceedDimensions)
+
+	field, err := NewInfiniteField(42, 40)
+	c.Assert(err, IsNil)
+	again, err := NewInfiniteField(42, 40)
+	c.Assert(err, IsNil)
+
+	// every chunk has its own mines, placed the same way for the same seed
+	for _, chunk := range []Position{{X: 0, Y: 0}, {X: -1, Y: 0}, {X: 3, Y: -7}, {X: -1000000, Y: 1000000}} {
+		count := 0
+		for y := 0; y < ChunkSize; y++ {
+			for x := 0; x < ChunkSize; x++ {
+				pos := Position{X: chunk.X*ChunkSize + x, Y: chunk.Y*ChunkSize + y}
+				if field.mine(pos) {
+					count++
+				}
+				c.Assert(again.mine(pos), Equals, field.mine(pos))
+			}
+		}
+		c.Check(count, Equals, 40, Commentf("chunk %v", chunk))
+	}
+	c.Check(field.chunks[Position{X: 0, Y: 0}], Not(DeepEquals), field.chunks[Position{X: -1, Y: 0}])
+
+	// proximities count mines across chunk borders
+	for x := -2; x <= 2; x++ {
+		pos := Position{X: x, Y: 0}
+		if field.mine(pos) {
+			c.Check(field.Select(x, 0), Equals, Mine)
+			c.Check(field.Check(x, 0), Equals, Exploded)
+			continue
+		}
+		proximity := 0
+		for _, neighbor := range Surrounding.Neighbors(pos) {
+			if field.mine(neighbor) {
+				proximity++
+			}
+		}
+		field.Select(x, 0)
+		c.Check(field.Check(x, 0), Equals, proximity)
+	}
+
+	field.ToggleFlag(-5, -5)
+	c.Check(field.Check(-5, -5), Equals, Flagged)
+	c.Check(field.Select(-5, -5), Equals, Flagged)
+}
+
+func (s *MSSuite) TestInfiniteField_Open(c *C) {
+	// without mines an opening goes on until the limit
+	field, err := NewInfiniteField(1, 0)
+	c.Assert(err, IsNil)
+	c.Check(field.Select(0, 0), Equals, 0)
+	revealed := 0
+	for _, block := range field.blocks {
+		if block.checked() {
+			revealed++
+		}
+	}
+	c.Check(revealed, Equals, maxReveal)
+
+	view := field.Viewport(Position{X: -2, Y: -2}, 4, 4)
+	c.Check(view.Origin, Equals, Position{X: -2, Y: -2})
+	c.Check(view.Mines, Equals, 0)
+	var buf strings.Builder
+	c.Assert(RenderText(&buf, view), IsNil)
+	c.Check(buf.String(), Equals, "....\n....\n....\n....\n")
+
+	view = field.Viewport(Position{X: 1000, Y: 1000}, 2, 2)
+	c.Check(view.Blocks, DeepEquals, map[Position]int{{X: 0, Y: 0}: Unknown, {X: 1, Y: 0}: Unknown, {X: 0, Y: 1}: Unknown, {X: 1, Y: 1}: Unknown})
+}
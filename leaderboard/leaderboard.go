@@ -0,0 +1,259 @@
+// Package leaderboard ranks the fastest wins on the same board.
+//
+// Scores are submitted as archives, which are played back to check that they
+// won the board they claim to before they are ranked.  The times of the moves
+// can only be vouched for by the server that timed them, so an archive must be
+// signed by a key the leaderboard trusts.
+//
+// A Seasonal leaderboard also ranks each season of a Schedule afresh, keeping
+// the boards of the seasons that are over alongside the board of all time.
+package leaderboard
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+var (
+	ErrNotWon    = errors.New("replay did not win the game")
+	ErrNotRanked = errors.New("player is not ranked")
+	ErrUntrusted = errors.New("archive is not signed by a trusted key")
+	ErrNoSeasons = errors.New("seasons must have a positive length")
+)
+
+// Key picks out a board: the preset it is played by and the seed of the
+// SeededSelector that places its mines, such as a DailySeed.
+type Key struct {
+	Preset gominesweeper.Preset
+	Seed   int64
+}
+
+// String returns the key as "preset/seed".
+func (k Key) String() string {
+	return fmt.Sprintf("%s/%d", k.Preset, k.Seed)
+}
+
+// Config returns the config of the key's board.
+func (k Key) Config() (gominesweeper.Config, error) {
+	cfg, err := k.Preset.Config()
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Selector = gominesweeper.SeededSelector(k.Seed)
+	return cfg, nil
+}
+
+// Entry is a player's best win on a board.
+type Entry struct {
+	Player    string        `json:"player"`
+	Elapsed   time.Duration `json:"elapsed"`
+	Submitted time.Time     `json:"submitted"`
+}
+
+// Leaderboard ranks the players of each board by their fastest win.
+type Leaderboard interface {
+	// SubmitScore verifies the archive of the player's game on the board
+	// and ranks it, returning the player's best entry.
+	SubmitScore(key Key, player string, archive gominesweeper.Archive) (Entry, error)
+
+	// TopN returns the best n entries on the board, fastest first.
+	TopN(key Key, n int) ([]Entry, error)
+
+	// Rank returns the player's place on the board, starting at 1, or
+	// ErrNotRanked.
+	Rank(key Key, player string) (int, error)
+}
+
+// Verify checks the archive and plays it back on the key's board, returning
+// the entry it earns.  It returns ErrUntrusted unless the archive is signed
+// by one of the trusted keys, ErrReplayMismatch if it was of another board and
+// ErrNotWon if it did not win, along with any error of Archive.Verify.
+func Verify(key Key, player string, archive gominesweeper.Archive, trusted []ed25519.PublicKey) (Entry, error) {
+	if err := archive.Verify(); err != nil {
+		return Entry{}, err
+	} else if !signedBy(archive, trusted) {
+		return Entry{}, ErrUntrusted
+	}
+
+	cfg, err := key.Config()
+	if err != nil {
+		return Entry{}, err
+	}
+	replay, err := archive.Replay()
+	if err != nil {
+		return Entry{}, err
+	}
+	game, err := replay.Verify(cfg)
+	if err != nil {
+		return Entry{}, err
+	} else if game.State() != gominesweeper.Won {
+		return Entry{}, ErrNotWon
+	}
+	return Entry{Player: player, Elapsed: game.Elapsed()}, nil
+}
+
+// signedBy returns true if the archive has a signature by one of the keys.
+// The signatures must already have been verified.
+func signedBy(archive gominesweeper.Archive, keys []ed25519.PublicKey) bool {
+	for _, signature := range archive.Signatures {
+		for _, key := range keys {
+			if key.Equal(signature.Key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Local is a Leaderboard kept in a JSON file.  It is safe for concurrent use.
+type Local struct {
+	mu      sync.Mutex
+	path    string
+	trusted []ed25519.PublicKey
+	boards  map[string][]Entry
+
+	// clock gives the time of submissions
+	clock func() time.Time
+}
+
+// Open returns the leaderboard kept in the file at the path, which is created
+// on the first submission if it does not exist.  It ranks the archives signed
+// by any of the trusted keys.
+func Open(path string, trusted ...ed25519.PublicKey) (*Local, error) {
+	l := &Local{path: path, trusted: trusted, boards: make(map[string][]Entry), clock: time.Now}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &l.boards); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// SubmitScore verifies the archive and ranks it if it beats the player's
+// best.  The leaderboard is left as it was if it cannot be saved.
+func (l *Local) SubmitScore(key Key, player string, archive gominesweeper.Archive) (Entry, error) {
+	entry, err := Verify(key, player, archive, l.trusted)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry.Submitted = l.clock()
+	return l.submit(key, entry)
+}
+
+// submit ranks a verified entry if it beats the player's best, returning the
+// player's best entry.
+func (l *Local) submit(key Key, entry Entry) (Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	previous, ok := l.boards[key.String()]
+	entries := append([]Entry(nil), previous...)
+	for i, best := range entries {
+		if best.Player != entry.Player {
+			continue
+		} else if best.Elapsed <= entry.Elapsed {
+			return best, nil
+		}
+		entries = append(entries[:i], entries[i+1:]...)
+		break
+	}
+
+	entries = append(entries, entry)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Elapsed != entries[j].Elapsed {
+			return entries[i].Elapsed < entries[j].Elapsed
+		}
+		return entries[i].Submitted.Before(entries[j].Submitted)
+	})
+	l.boards[key.String()] = entries
+	if err := l.save(); err != nil {
+		if ok {
+			l.boards[key.String()] = previous
+		} else {
+			delete(l.boards, key.String())
+		}
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// entries returns the entries of a board, and whether it has been ranked.
+func (l *Local) entries(key Key) ([]Entry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries, ok := l.boards[key.String()]
+	return entries, ok
+}
+
+// restore puts back the entries of a board returned by entries, undoing the
+// submissions since.
+func (l *Local) restore(key Key, entries []Entry, ok bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if ok {
+		l.boards[key.String()] = entries
+	} else {
+		delete(l.boards, key.String())
+	}
+	return l.save()
+}
+
+// TopN returns the best n entries on the board, fastest first.
+func (l *Local) TopN(key Key, n int) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := l.boards[key.String()]
+	if n > len(entries) {
+		n = len(entries)
+	} else if n < 0 {
+		n = 0
+	}
+	top := make([]Entry, n)
+	copy(top, entries)
+	return top, nil
+}
+
+// Rank returns the player's place on the board.
+func (l *Local) Rank(key Key, player string) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, entry := range l.boards[key.String()] {
+		if entry.Player == player {
+			return i + 1, nil
+		}
+	}
+	return 0, ErrNotRanked
+}
+
+// save writes the leaderboard to its file, replacing it only once it has all
+// been written.
+func (l *Local) save() error {
+	data, err := json.MarshalIndent(l.boards, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	} else if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), l.path)
+}
@@ -0,0 +1,157 @@
+package leaderboard
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type LeaderboardSuite struct{}
+
+var _ = Suite(&LeaderboardSuite{})
+
+// server is the key of the server the games are played on.
+var server = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+// won returns a board that the solver bot wins and the archive of the win.
+func won(c *C) (Key, gominesweeper.Archive) {
+	for seed := int64(1); seed < 100; seed++ {
+		key := Key{gominesweeper.Beginner, seed}
+		cfg, err := key.Config()
+		c.Assert(err, IsNil)
+		game, err := gominesweeper.NewGame(cfg)
+		c.Assert(err, IsNil)
+		if gominesweeper.Play(gominesweeper.NewSolverBot(1), game) == gominesweeper.Won {
+			archive, err := game.Archive()
+			c.Assert(err, IsNil)
+			return key, archive
+		}
+	}
+	c.Fatal("no board was won")
+	return Key{}, gominesweeper.Archive{}
+}
+
+// taking returns the archive with its moves spread evenly over the duration,
+// signed by the server.
+func taking(c *C, archive gominesweeper.Archive, d time.Duration) gominesweeper.Archive {
+	moves := make([]gominesweeper.Record, len(archive.Moves))
+	for i, record := range archive.Moves {
+		record.Elapsed = d * time.Duration(i) / time.Duration(len(moves)-1)
+		moves[i] = record
+	}
+	archive.Moves = moves
+	archive.Result.Elapsed = d
+	archive.Signatures = nil
+	c.Assert(archive.Sign("server", server), IsNil)
+	return archive
+}
+
+func (s *LeaderboardSuite) TestLocal(c *C) {
+	key, archive := won(c)
+	path := filepath.Join(c.MkDir(), "leaderboard.json")
+	board, err := Open(path, server.Public().(ed25519.PublicKey))
+	c.Assert(err, IsNil)
+	now := time.Unix(0, 0)
+	board.clock = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	entry, err := board.SubmitScore(key, "alice", taking(c, archive, 30*time.Second))
+	c.Assert(err, IsNil)
+	c.Check(entry.Elapsed, Equals, 30*time.Second)
+	_, err = board.SubmitScore(key, "bob", taking(c, archive, 20*time.Second))
+	c.Assert(err, IsNil)
+	_, err = board.SubmitScore(key, "carol", taking(c, archive, 30*time.Second))
+	c.Assert(err, IsNil)
+
+	// a slower win does not replace a player's best, but a faster one does
+	entry, err = board.SubmitScore(key, "bob", taking(c, archive, 40*time.Second))
+	c.Assert(err, IsNil)
+	c.Check(entry.Elapsed, Equals, 20*time.Second)
+	_, err = board.SubmitScore(key, "carol", taking(c, archive, 10*time.Second))
+	c.Assert(err, IsNil)
+
+	top, err := board.TopN(key, 2)
+	c.Assert(err, IsNil)
+	c.Assert(top, HasLen, 2)
+	c.Check(top[0].Player, Equals, "carol")
+	c.Check(top[1].Player, Equals, "bob")
+	none, err := board.TopN(key, -1)
+	c.Assert(err, IsNil)
+	c.Check(none, HasLen, 0)
+
+	rank, err := board.Rank(key, "alice")
+	c.Assert(err, IsNil)
+	c.Check(rank, Equals, 3)
+	_, err = board.Rank(key, "dave")
+	c.Check(err, Equals, ErrNotRanked)
+	_, err = board.Rank(Key{gominesweeper.Beginner, key.Seed + 1}, "alice")
+	c.Check(err, Equals, ErrNotRanked)
+
+	// the leaderboard carries on from the file
+	again, err := Open(path)
+	c.Assert(err, IsNil)
+	all, err := again.TopN(key, 10)
+	c.Assert(err, IsNil)
+	c.Check(all, HasLen, 3)
+	c.Check(all[0].Player, Equals, top[0].Player)
+	c.Check(all[0].Submitted.Equal(top[0].Submitted), Equals, true)
+}
+
+func (s *LeaderboardSuite) TestLocalSaveFails(c *C) {
+	key, archive := won(c)
+	path := filepath.Join(c.MkDir(), "missing", "leaderboard.json")
+	board, err := Open(path, server.Public().(ed25519.PublicKey))
+	c.Assert(err, IsNil)
+
+	// the submission is not ranked when it cannot be saved
+	_, err = board.SubmitScore(key, "alice", taking(c, archive, 30*time.Second))
+	c.Check(err, NotNil)
+	top, err := board.TopN(key, 10)
+	c.Assert(err, IsNil)
+	c.Check(top, HasLen, 0)
+	_, err = board.Rank(key, "alice")
+	c.Check(err, Equals, ErrNotRanked)
+}
+
+func (s *LeaderboardSuite) TestVerify(c *C) {
+	key, archive := won(c)
+	trusted := []ed25519.PublicKey{server.Public().(ed25519.PublicKey)}
+	signed := taking(c, archive, 30*time.Second)
+
+	// archives of another board or of a game that was not won are refused
+	_, err := Verify(Key{gominesweeper.Beginner, key.Seed + 1}, "mallory", signed, trusted)
+	c.Check(err, Equals, gominesweeper.ErrReplayMismatch)
+	unfinished := archive
+	unfinished.Moves = archive.Moves[:len(archive.Moves)-1]
+	unfinished.Result = gominesweeper.ArchiveResult{State: gominesweeper.Playing, Lives: 1}
+	_, err = Verify(key, "mallory", taking(c, unfinished, 30*time.Second), trusted)
+	c.Check(err, Equals, ErrNotWon)
+
+	// the times cannot be changed without the server's key
+	_, err = Verify(key, "mallory", archive, trusted)
+	c.Check(err, Equals, ErrUntrusted)
+	forged := taking(c, archive, time.Second)
+	forged.Signatures = nil
+	c.Assert(forged.Sign("mallory", ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize))), IsNil)
+	_, err = Verify(key, "mallory", forged, trusted)
+	c.Check(err, Equals, ErrUntrusted)
+	tampered := signed
+	tampered.Result.Elapsed = time.Second
+	tampered.Moves = taking(c, archive, time.Second).Moves
+	_, err = Verify(key, "mallory", tampered, trusted)
+	c.Check(err, Equals, gominesweeper.ErrBadSignature)
+
+	entry, err := Verify(key, "alice", signed, trusted)
+	c.Assert(err, IsNil)
+	c.Check(entry.Player, Equals, "alice")
+	c.Check(entry.Elapsed, Equals, 30*time.Second)
+}
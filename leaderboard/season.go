@@ -0,0 +1,166 @@
+package leaderboard
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+// Schedule divides time into seasons of the same length, numbered from 0 at
+// the start.
+type Schedule struct {
+	Start  time.Time
+	Length time.Duration
+}
+
+// Season returns the number of the season at the time.  Times before the
+// start fall in the first season.
+func (s Schedule) Season(t time.Time) int {
+	if t.Before(s.Start) {
+		return 0
+	}
+	return int(t.Sub(s.Start) / s.Length)
+}
+
+// Bounds returns the start of the season and the start of the next one.
+func (s Schedule) Bounds(season int) (start, end time.Time) {
+	start = s.Start.Add(time.Duration(season) * s.Length)
+	return start, start.Add(s.Length)
+}
+
+// Seasonal is a Leaderboard that ranks the players of the current season,
+// starting afresh when a season is over.  The boards of all time and of past
+// seasons are kept in their own files in a directory.  It is safe for
+// concurrent use.
+type Seasonal struct {
+	mu       sync.Mutex
+	dir      string
+	schedule Schedule
+	trusted  []ed25519.PublicKey
+	allTime  *Local
+
+	// season is the number of the current season and board is its board
+	season int
+	board  *Local
+
+	// clock gives the time of submissions and of the season
+	clock func() time.Time
+}
+
+// OpenSeasonal returns the seasonal leaderboard kept in the directory, which
+// is created if it does not exist.  It ranks the archives signed by any of the
+// trusted keys, and returns ErrNoSeasons unless the seasons have a length.
+func OpenSeasonal(dir string, schedule Schedule, trusted ...ed25519.PublicKey) (*Seasonal, error) {
+	if schedule.Length <= 0 {
+		return nil, ErrNoSeasons
+	} else if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	allTime, err := Open(filepath.Join(dir, "all-time.json"), trusted...)
+	if err != nil {
+		return nil, err
+	}
+	return &Seasonal{dir: dir, schedule: schedule, trusted: trusted, allTime: allTime, season: -1, clock: time.Now}, nil
+}
+
+// SubmitScore verifies the archive and ranks it in the current season and of
+// all time, returning the player's best entry of the season.  Neither board is
+// changed if either cannot be saved.
+func (s *Seasonal) SubmitScore(key Key, player string, archive gominesweeper.Archive) (Entry, error) {
+	entry, err := Verify(key, player, archive, s.trusted)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry.Submitted = s.clock()
+	board, err := s.current(entry.Submitted)
+	if err != nil {
+		return Entry{}, err
+	}
+	previous, ok := board.entries(key)
+	best, err := board.submit(key, entry)
+	if err != nil {
+		return Entry{}, err
+	}
+	if _, err := s.allTime.submit(key, entry); err != nil {
+		// the season is put back so that it does not rank what the board of
+		// all time could not
+		return Entry{}, errors.Join(err, board.restore(key, previous, ok))
+	}
+	return best, nil
+}
+
+// TopN returns the best n entries of the current season on the board.
+func (s *Seasonal) TopN(key Key, n int) ([]Entry, error) {
+	board, err := s.Current()
+	if err != nil {
+		return nil, err
+	}
+	return board.TopN(key, n)
+}
+
+// Rank returns the player's place on the board in the current season.
+func (s *Seasonal) Rank(key Key, player string) (int, error) {
+	board, err := s.Current()
+	if err != nil {
+		return 0, err
+	}
+	return board.Rank(key, player)
+}
+
+// AllTime returns the leaderboard of every season together.  Scores should
+// be submitted to the Seasonal leaderboard rather than to it.
+func (s *Seasonal) AllTime() *Local {
+	return s.allTime
+}
+
+// Current returns the leaderboard of the current season.
+func (s *Seasonal) Current() (*Local, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current(s.clock())
+}
+
+// Season returns the leaderboard of a season, such as one that is over, which
+// is empty if no scores were submitted in it.
+func (s *Seasonal) Season(season int) (*Local, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if season == s.season {
+		return s.board, nil
+	}
+	return s.open(season)
+}
+
+// current returns the board of the season at the time, rolling over to it if
+// the last season is over.  The board of the last season stays in its file.
+func (s *Seasonal) current(now time.Time) (*Local, error) {
+	season := s.schedule.Season(now)
+	if season == s.season {
+		return s.board, nil
+	}
+	board, err := s.open(season)
+	if err != nil {
+		return nil, err
+	}
+	s.season, s.board = season, board
+	return board, nil
+}
+
+// open opens the board of a season.
+func (s *Seasonal) open(season int) (*Local, error) {
+	board, err := Open(filepath.Join(s.dir, fmt.Sprintf("season-%d.json", season)), s.trusted...)
+	if err != nil {
+		return nil, err
+	}
+	board.clock = s.clock
+	return board, nil
+}
@@ -0,0 +1,114 @@
+package leaderboard
+
+import (
+	"crypto/ed25519"
+	"path/filepath"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *LeaderboardSuite) TestSchedule(c *C) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule := Schedule{Start: start, Length: 7 * 24 * time.Hour}
+	c.Check(schedule.Season(start.Add(-time.Hour)), Equals, 0)
+	c.Check(schedule.Season(start), Equals, 0)
+	c.Check(schedule.Season(start.Add(7*24*time.Hour-time.Nanosecond)), Equals, 0)
+	c.Check(schedule.Season(start.Add(15*24*time.Hour)), Equals, 2)
+
+	from, to := schedule.Bounds(2)
+	c.Check(from, Equals, start.Add(14*24*time.Hour))
+	c.Check(to, Equals, start.Add(21*24*time.Hour))
+}
+
+func (s *LeaderboardSuite) TestSeasonal(c *C) {
+	key, archive := won(c)
+	dir := filepath.Join(c.MkDir(), "seasons")
+	_, err := OpenSeasonal(dir, Schedule{}, server.Public().(ed25519.PublicKey))
+	c.Check(err, Equals, ErrNoSeasons)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule := Schedule{Start: start, Length: 7 * 24 * time.Hour}
+	board, err := OpenSeasonal(dir, schedule, server.Public().(ed25519.PublicKey))
+	c.Assert(err, IsNil)
+	now := start
+	board.clock = func() time.Time { return now }
+
+	_, err = board.SubmitScore(key, "alice", taking(c, archive, 20*time.Second))
+	c.Assert(err, IsNil)
+	_, err = board.SubmitScore(key, "bob", taking(c, archive, 30*time.Second))
+	c.Assert(err, IsNil)
+
+	// the next season starts afresh, with the last one kept
+	now = start.Add(8 * 24 * time.Hour)
+	top, err := board.TopN(key, 10)
+	c.Assert(err, IsNil)
+	c.Check(top, HasLen, 0)
+	entry, err := board.SubmitScore(key, "bob", taking(c, archive, 40*time.Second))
+	c.Assert(err, IsNil)
+	c.Check(entry.Elapsed, Equals, 40*time.Second)
+	rank, err := board.Rank(key, "bob")
+	c.Assert(err, IsNil)
+	c.Check(rank, Equals, 1)
+	_, err = board.Rank(key, "alice")
+	c.Check(err, Equals, ErrNotRanked)
+
+	last, err := board.Season(0)
+	c.Assert(err, IsNil)
+	top, err = last.TopN(key, 10)
+	c.Assert(err, IsNil)
+	c.Assert(top, HasLen, 2)
+	c.Check(top[0].Player, Equals, "alice")
+	c.Check(top[1].Elapsed, Equals, 30*time.Second)
+
+	// of all time, players keep their best from any season
+	top, err = board.AllTime().TopN(key, 10)
+	c.Assert(err, IsNil)
+	c.Assert(top, HasLen, 2)
+	c.Check(top[0].Player, Equals, "alice")
+	c.Check(top[1].Elapsed, Equals, 30*time.Second)
+
+	// the seasons carry on from the directory
+	again, err := OpenSeasonal(dir, schedule)
+	c.Assert(err, IsNil)
+	again.clock = board.clock
+	top, err = again.TopN(key, 10)
+	c.Assert(err, IsNil)
+	c.Assert(top, HasLen, 1)
+	c.Check(top[0].Player, Equals, "bob")
+	top, err = again.AllTime().TopN(key, 10)
+	c.Assert(err, IsNil)
+	c.Check(top, HasLen, 2)
+}
+
+func (s *LeaderboardSuite) TestSeasonalSaveFails(c *C) {
+	key, archive := won(c)
+	dir := c.MkDir()
+	board, err := OpenSeasonal(dir, Schedule{Start: time.Unix(0, 0), Length: time.Hour}, server.Public().(ed25519.PublicKey))
+	c.Assert(err, IsNil)
+	_, err = board.SubmitScore(key, "alice", taking(c, archive, 30*time.Second))
+	c.Assert(err, IsNil)
+
+	// the season is not ranked when the board of all time cannot be saved
+	board.allTime.path = filepath.Join(dir, "missing", "all-time.json")
+	_, err = board.SubmitScore(key, "alice", taking(c, archive, 20*time.Second))
+	c.Check(err, NotNil)
+	_, err = board.SubmitScore(key, "bob", taking(c, archive, 40*time.Second))
+	c.Check(err, NotNil)
+	for _, l := range []Leaderboard{board, board.AllTime()} {
+		top, err := l.TopN(key, 10)
+		c.Assert(err, IsNil)
+		c.Assert(top, HasLen, 1)
+		c.Check(top[0].Elapsed, Equals, 30*time.Second)
+	}
+
+	// nor is the saved season
+	current, err := board.Current()
+	c.Assert(err, IsNil)
+	again, err := Open(current.path)
+	c.Assert(err, IsNil)
+	top, err := again.TopN(key, 10)
+	c.Assert(err, IsNil)
+	c.Assert(top, HasLen, 1)
+	c.Check(top[0].Elapsed, Equals, 30*time.Second)
+}
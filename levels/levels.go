@@ -0,0 +1,422 @@
+// Package levels runs single-player campaigns: a sequence of boards that get
+// harder, each unlocked by playing the ones before it, with the player's
+// progress kept between runs.
+//
+// A campaign is either written by hand, as JSON read by Load, or ramped from
+// one size of board to another with Ramp:
+//
+//	campaign := levels.Campaign{Name: "tour", Levels: levels.Ramp(1, 10, beginner, expert)}
+//	tracker, err := levels.NewTracker(campaign, levels.FileStore("progress.json"))
+//	...
+//	game, err := tracker.Start(tracker.Next().Name, gominesweeper.Config{})
+package levels
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+var (
+	ErrBadCampaign  = errors.New("malformed campaign")
+	ErrUnknownLevel = errors.New("unknown level")
+	ErrLocked       = errors.New("level is locked")
+)
+
+// Level is a board of a campaign, either seeded or written by hand.
+type Level struct {
+	Name string `json:"name"`
+
+	// Width, Height and Mines are the size of a seeded board, whose mines
+	// are placed by a SeededSelector with the Seed.
+	Width  uint  `json:"width,omitempty"`
+	Height uint  `json:"height,omitempty"`
+	Mines  uint  `json:"mines,omitempty"`
+	Seed   int64 `json:"seed,omitempty"`
+
+	// Board is a board written by hand, one row per line as read by
+	// gominesweeper.ParseBoard, which is played instead of a seeded one.
+	Board []string `json:"board,omitempty"`
+
+	// Lives is the number of mines that may be set off; defaults to 1.
+	Lives uint `json:"lives,omitempty"`
+
+	// Par is the time to beat, for unlock rules such as UnderPar, or 0 if
+	// there is none.
+	Par time.Duration `json:"par,omitempty"`
+}
+
+// Config returns the config of the level's board, with the rest of the
+// config left as it is.
+func (l Level) Config(cfg gominesweeper.Config) (gominesweeper.Config, error) {
+	cfg.Lives = l.Lives
+	if l.Board == nil {
+		cfg.Width, cfg.Height, cfg.Mines = l.Width, l.Height, l.Mines
+		cfg.Selector = gominesweeper.SeededSelector(l.Seed)
+		return cfg, nil
+	}
+
+	// parse the board to check it
+	board := strings.Join(l.Board, "\n")
+	if _, err := gominesweeper.ParseBoard(strings.NewReader(board)); err != nil {
+		return cfg, err
+	}
+	var mines []gominesweeper.Position
+	for y, row := range l.Board {
+		for x, char := range row {
+			if char == '*' {
+				mines = append(mines, gominesweeper.Position{X: x, Y: y})
+			}
+		}
+	}
+	cfg.Width, cfg.Height, cfg.Mines = uint(len(l.Board[0])), uint(len(l.Board)), uint(len(mines))
+	cfg.Selector = func(width, height, max uint) ([]gominesweeper.Position, error) {
+		return append([]gominesweeper.Position(nil), mines...), nil
+	}
+	return cfg, nil
+}
+
+// Ramp returns n seeded levels, named "1" to n, that grow evenly from the
+// size of the first rules to the size of the last, so that each is at least
+// as hard as the one before.  The seeds of the boards are drawn from the
+// seed.
+func Ramp(seed int64, n int, first, last gominesweeper.Rules) []Level {
+	src := gominesweeper.SeededSource(seed)
+	levels := make([]Level, n)
+	for i := range levels {
+		step := func(from, to uint) uint {
+			if n == 1 {
+				return from
+			}
+			return uint(int(from) + (int(to)-int(from))*i/(n-1))
+		}
+		width, height := step(first.Width, last.Width), step(first.Height, last.Height)
+		levels[i] = Level{
+			Name:   strconv.Itoa(i + 1),
+			Width:  width,
+			Height: height,
+			Mines:  min(step(first.Mines, last.Mines), width*height-1),
+			Seed:   src.Int63(),
+			Lives:  first.Lives,
+		}
+	}
+	return levels
+}
+
+// UnlockRule decides whether the level at the index of the campaign is
+// unlocked, given the progress so far.
+type UnlockRule func(levels []Level, progress Progress, index int) bool
+
+var (
+	// Sequential unlocks each level once the one before it is completed.
+	Sequential UnlockRule = Skip(0)
+
+	// Open unlocks every level from the start.
+	Open UnlockRule = func(levels []Level, progress Progress, index int) bool {
+		return true
+	}
+
+	// UnderPar unlocks each level once the one before it is completed
+	// within its Par.
+	UnderPar UnlockRule = func(levels []Level, progress Progress, index int) bool {
+		if index == 0 {
+			return true
+		}
+		before := levels[index-1]
+		record := progress.Levels[before.Name]
+		return record.Completed && (before.Par == 0 || record.Best <= before.Par)
+	}
+)
+
+// Skip unlocks a level once at most n of the levels before it are left
+// uncompleted, so that a player who is stuck can skip ahead.
+func Skip(n int) UnlockRule {
+	return func(levels []Level, progress Progress, index int) bool {
+		skipped := 0
+		for _, level := range levels[:index] {
+			if !progress.Completed(level.Name) {
+				skipped++
+			}
+		}
+		return skipped <= n
+	}
+}
+
+// Campaign is a sequence of levels, played in order.
+type Campaign struct {
+	Name   string  `json:"name"`
+	Levels []Level `json:"levels"`
+
+	// Unlock decides which levels can be played; defaults to Sequential.
+	Unlock UnlockRule `json:"-"`
+}
+
+// Load reads a campaign written as JSON.  It returns ErrBadCampaign if the
+// campaign is not valid.
+func Load(r io.Reader) (Campaign, error) {
+	var campaign Campaign
+	if err := json.NewDecoder(r).Decode(&campaign); err != nil {
+		return campaign, err
+	}
+	return campaign, campaign.Validate()
+}
+
+// Validate returns ErrBadCampaign unless the campaign has levels, their names
+// are unique and every board can be played.
+func (c Campaign) Validate() error {
+	if len(c.Levels) == 0 {
+		return ErrBadCampaign
+	}
+	names := make(map[string]bool, len(c.Levels))
+	for _, level := range c.Levels {
+		if level.Name == "" || names[level.Name] {
+			return ErrBadCampaign
+		}
+		names[level.Name] = true
+		if level.Board != nil {
+			if _, err := level.Config(gominesweeper.Config{}); err != nil {
+				return ErrBadCampaign
+			}
+		} else if level.Width*level.Height <= level.Mines {
+			return ErrBadCampaign
+		}
+	}
+	return nil
+}
+
+// index returns the index of the level with the name, or -1 if there is
+// none.
+func (c Campaign) index(name string) int {
+	for i, level := range c.Levels {
+		if level.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// unlocked returns true if the level at the index is unlocked.
+func (c Campaign) unlocked(progress Progress, index int) bool {
+	unlock := c.Unlock
+	if unlock == nil {
+		unlock = Sequential
+	}
+	return unlock(c.Levels, progress, index)
+}
+
+// Record is how a player has done on a level.
+type Record struct {
+	Attempts  int  `json:"attempts"`
+	Completed bool `json:"completed"`
+
+	// Best is the fastest win of the level.
+	Best time.Duration `json:"best,omitempty"`
+}
+
+// Progress is how a player has done on every level of a campaign they have
+// played, by the level's name.
+type Progress struct {
+	Levels map[string]Record `json:"levels"`
+}
+
+// Completed returns true if the level with the name has been won.
+func (p Progress) Completed(name string) bool {
+	return p.Levels[name].Completed
+}
+
+// add records the result of a game of the level.
+func (p *Progress) add(name string, result gominesweeper.GameResult) {
+	if p.Levels == nil {
+		p.Levels = make(map[string]Record)
+	}
+	record := p.Levels[name]
+	record.Attempts++
+	if result.State == gominesweeper.Won {
+		if !record.Completed || result.Elapsed < record.Best {
+			record.Best = result.Elapsed
+		}
+		record.Completed = true
+	}
+	p.Levels[name] = record
+}
+
+// Store keeps the progress between runs.
+type Store interface {
+	// Load returns the progress that was saved, or empty progress if there
+	// is none.
+	Load() (Progress, error)
+	Save(progress Progress) error
+}
+
+// MemoryStore keeps the progress in memory only.
+type MemoryStore struct {
+	progress Progress
+}
+
+// Load returns the progress that was saved.
+func (m *MemoryStore) Load() (Progress, error) {
+	return m.progress, nil
+}
+
+// Save keeps the progress.
+func (m *MemoryStore) Save(progress Progress) error {
+	m.progress = progress
+	return nil
+}
+
+// FileStore keeps the progress as JSON in the file at the path.
+type FileStore string
+
+// Load reads the progress from the file.
+func (f FileStore) Load() (Progress, error) {
+	var progress Progress
+	data, err := os.ReadFile(string(f))
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	} else if err != nil {
+		return progress, err
+	}
+	err = json.Unmarshal(data, &progress)
+	return progress, err
+}
+
+// Save writes the progress to the file, replacing it only once it has all
+// been written.
+func (f FileStore) Save(progress Progress) error {
+	data, err := json.MarshalIndent(progress, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(string(f)), filepath.Base(string(f))+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	} else if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), string(f))
+}
+
+// Tracker keeps a player's progress through a campaign, saving it to its
+// store after every game.  It is safe for concurrent use.
+type Tracker struct {
+	mu       sync.Mutex
+	campaign Campaign
+	store    Store
+	progress Progress
+	err      error
+}
+
+// NewTracker returns a tracker of the campaign that carries on from the
+// progress in the store.  It returns ErrBadCampaign if the campaign is not
+// valid.
+func NewTracker(campaign Campaign, store Store) (*Tracker, error) {
+	if err := campaign.Validate(); err != nil {
+		return nil, err
+	}
+	progress, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Tracker{campaign: campaign, store: store, progress: progress}, nil
+}
+
+// Unlocked returns true if the level with the name can be played.
+func (t *Tracker) Unlocked(name string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	i := t.campaign.index(name)
+	return i >= 0 && t.campaign.unlocked(t.progress, i)
+}
+
+// Next returns the first unlocked level that has not been completed, or the
+// last level once they all have.
+func (t *Tracker) Next() Level {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, level := range t.campaign.Levels {
+		if !t.progress.Completed(level.Name) && t.campaign.unlocked(t.progress, i) {
+			return level
+		}
+	}
+	return t.campaign.Levels[len(t.campaign.Levels)-1]
+}
+
+// Start starts a game of the level with the name, configured by the rest of
+// the config, that is recorded once it is over.  It returns ErrUnknownLevel
+// if there is no such level and ErrLocked if it has not been unlocked.
+// Errors saving the progress are kept by Err.
+func (t *Tracker) Start(name string, cfg gominesweeper.Config) (*gominesweeper.Game, error) {
+	t.mu.Lock()
+	i := t.campaign.index(name)
+	if i < 0 {
+		t.mu.Unlock()
+		return nil, ErrUnknownLevel
+	} else if !t.campaign.unlocked(t.progress, i) {
+		t.mu.Unlock()
+		return nil, ErrLocked
+	}
+	level := t.campaign.Levels[i]
+	t.mu.Unlock()
+
+	cfg, err := level.Config(cfg)
+	if err != nil {
+		return nil, err
+	}
+	onEnd := cfg.OnEnd
+	cfg.OnEnd = func(g *gominesweeper.Game, result gominesweeper.GameResult) {
+		if err := t.Record(name, result); err != nil {
+			t.mu.Lock()
+			t.err = err
+			t.mu.Unlock()
+		}
+		if onEnd != nil {
+			onEnd(g, result)
+		}
+	}
+	return gominesweeper.NewGame(cfg)
+}
+
+// Record adds the result of a game of the level with the name to the
+// progress and saves it.  It returns ErrUnknownLevel if there is no such
+// level.
+func (t *Tracker) Record(name string, result gominesweeper.GameResult) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.campaign.index(name) < 0 {
+		return ErrUnknownLevel
+	}
+	t.progress.add(name, result)
+	return t.store.Save(t.progress)
+}
+
+// Err returns the last error from saving the progress of a game begun with
+// Start.
+func (t *Tracker) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+// Progress returns the progress so far.
+func (t *Tracker) Progress() Progress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	progress := Progress{Levels: make(map[string]Record, len(t.progress.Levels))}
+	for name, record := range t.progress.Levels {
+		progress.Levels[name] = record
+	}
+	return progress
+}
@@ -0,0 +1,131 @@
+package levels
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type LevelsSuite struct{}
+
+var _ = Suite(&LevelsSuite{})
+
+const campaignJSON = `{
+	"name": "tutorial",
+	"levels": [
+		{"name": "corner", "board": ["*1.", "11.", "..."]},
+		{"name": "small", "width": 5, "height": 5, "mines": 3, "seed": 7, "par": 60000000000},
+		{"name": "big", "width": 9, "height": 9, "mines": 10, "seed": 8}
+	]
+}`
+
+func (s *LevelsSuite) TestLoad(c *C) {
+	campaign, err := Load(strings.NewReader(campaignJSON))
+	c.Assert(err, IsNil)
+	c.Check(campaign.Name, Equals, "tutorial")
+	c.Check(campaign.Levels, HasLen, 3)
+	c.Check(campaign.Levels[1].Par, Equals, time.Minute)
+
+	cfg, err := campaign.Levels[0].Config(gominesweeper.Config{})
+	c.Assert(err, IsNil)
+	c.Check([]uint{cfg.Width, cfg.Height, cfg.Mines}, DeepEquals, []uint{3, 3, 1})
+	mines, err := cfg.Selector(3, 3, 1)
+	c.Assert(err, IsNil)
+	c.Check(mines, DeepEquals, []gominesweeper.Position{{X: 0, Y: 0}})
+
+	for _, bad := range []string{
+		`{"levels": []}`,
+		`{"levels": [{"name": "a", "width": 2, "height": 2, "mines": 4}]}`,
+		`{"levels": [{"name": "a", "board": ["*2", "1"]}]}`,
+		`{"levels": [{"name": "a", "board": ["*1"]}, {"name": "a", "board": ["*1"]}]}`,
+	} {
+		_, err := Load(strings.NewReader(bad))
+		c.Check(err, Equals, ErrBadCampaign, Commentf(bad))
+	}
+}
+
+func (s *LevelsSuite) TestRamp(c *C) {
+	beginner, err := gominesweeper.LookupRules("beginner")
+	c.Assert(err, IsNil)
+	expert, err := gominesweeper.LookupRules("expert")
+	c.Assert(err, IsNil)
+
+	levels := Ramp(1, 5, beginner, expert)
+	c.Assert(levels, HasLen, 5)
+	c.Check(levels[0].Name, Equals, "1")
+	c.Check([]uint{levels[0].Width, levels[0].Height, levels[0].Mines}, DeepEquals, []uint{9, 9, 10})
+	c.Check([]uint{levels[4].Width, levels[4].Height, levels[4].Mines}, DeepEquals, []uint{30, 16, 99})
+	for i := 1; i < len(levels); i++ {
+		c.Check(levels[i].Mines >= levels[i-1].Mines, Equals, true)
+		c.Check(levels[i].Width*levels[i].Height >= levels[i-1].Width*levels[i-1].Height, Equals, true)
+	}
+	c.Check(Ramp(1, 5, beginner, expert), DeepEquals, levels)
+	c.Check(Campaign{Levels: levels}.Validate(), IsNil)
+}
+
+func (s *LevelsSuite) TestTracker(c *C) {
+	campaign, err := Load(strings.NewReader(campaignJSON))
+	c.Assert(err, IsNil)
+	path := filepath.Join(c.MkDir(), "progress.json")
+	tracker, err := NewTracker(campaign, FileStore(path))
+	c.Assert(err, IsNil)
+
+	c.Check(tracker.Next().Name, Equals, "corner")
+	c.Check(tracker.Unlocked("corner"), Equals, true)
+	c.Check(tracker.Unlocked("small"), Equals, false)
+	_, err = tracker.Start("small", gominesweeper.Config{})
+	c.Check(err, Equals, ErrLocked)
+	_, err = tracker.Start("bogus", gominesweeper.Config{})
+	c.Check(err, Equals, ErrUnknownLevel)
+
+	// losing a level leaves the next one locked
+	ended := 0
+	game, err := tracker.Start("corner", gominesweeper.Config{OnEnd: func(*gominesweeper.Game, gominesweeper.GameResult) { ended++ }})
+	c.Assert(err, IsNil)
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(tracker.Unlocked("small"), Equals, false)
+
+	// winning it unlocks the next one
+	game, err = tracker.Start("corner", gominesweeper.Config{OnEnd: func(*gominesweeper.Game, gominesweeper.GameResult) { ended++ }})
+	c.Assert(err, IsNil)
+	_, err = game.Select(2, 2)
+	c.Assert(err, IsNil)
+	c.Check(game.State(), Equals, gominesweeper.Won)
+	c.Check(ended, Equals, 2)
+	c.Check(tracker.Err(), IsNil)
+	c.Check(tracker.Unlocked("small"), Equals, true)
+	c.Check(tracker.Next().Name, Equals, "small")
+	c.Check(tracker.Progress().Levels["corner"].Attempts, Equals, 2)
+	c.Check(tracker.Progress().Completed("corner"), Equals, true)
+
+	// the progress carries on from the file
+	again, err := NewTracker(campaign, FileStore(path))
+	c.Assert(err, IsNil)
+	c.Check(again.Progress(), DeepEquals, tracker.Progress())
+	c.Check(again.Next().Name, Equals, "small")
+}
+
+func (s *LevelsSuite) TestUnlockRules(c *C) {
+	levels := []Level{{Name: "a", Par: time.Minute}, {Name: "b"}, {Name: "c"}}
+	progress := Progress{Levels: map[string]Record{"a": {Attempts: 1, Completed: true, Best: 2 * time.Minute}}}
+
+	c.Check(Sequential(levels, progress, 1), Equals, true)
+	c.Check(Sequential(levels, progress, 2), Equals, false)
+	c.Check(Skip(1)(levels, progress, 2), Equals, true)
+	c.Check(Open(levels, Progress{}, 2), Equals, true)
+	c.Check(UnderPar(levels, progress, 0), Equals, true)
+	c.Check(UnderPar(levels, progress, 1), Equals, false)
+
+	tracker, err := NewTracker(Campaign{Levels: []Level{{Name: "a", Width: 3, Height: 3, Mines: 1}, {Name: "b", Width: 3, Height: 3, Mines: 1}}, Unlock: Open}, &MemoryStore{})
+	c.Assert(err, IsNil)
+	c.Check(tracker.Unlocked("b"), Equals, true)
+	c.Check(tracker.Record("b", gominesweeper.GameResult{State: gominesweeper.Won, Elapsed: time.Second}), IsNil)
+	c.Check(tracker.Next().Name, Equals, "a")
+}
@@ -0,0 +1,49 @@
+package gominesweeper
+
+import (
+	"time"
+)
+
+// Metrics is told how games are being played, so that a hosted deployment
+// can monitor them, e.g. with the OpenTelemetry adapter of the telemetry
+// package.  It is called while the game is being played, and must be safe for
+// concurrent use when shared between games.
+type Metrics interface {
+	// GameCreated is called when a game is started with the config.
+	GameCreated(cfg Config)
+
+	// MoveApplied is called after every move made with Apply, with the time
+	// it took and its error, if any.
+	MoveApplied(action Action, latency time.Duration, err error)
+
+	// FloodFilled is called with the number of blocks revealed by a move
+	// that revealed any.
+	FloodFilled(size int)
+
+	// Solved is called with the time the game's Solver took to deduce
+	// what it could.
+	Solved(latency time.Duration)
+}
+
+// Solver returns a solver of what the player can currently see of the game,
+// telling the Metrics how long it took.
+func (g *Game) Solver() *Solver {
+	if g.config.Metrics == nil {
+		return NewSolver(g.Snapshot())
+	}
+	start := time.Now()
+	solver := NewSolver(g.Snapshot())
+	g.config.Metrics.Solved(time.Since(start))
+	return solver
+}
+
+// measure makes the move with Apply, telling the Metrics how it went.
+func (g *Game) measure(move Move) (MoveResult, error) {
+	start := time.Now()
+	result, err := g.apply(move)
+	g.config.Metrics.MoveApplied(move.Action, time.Since(start), err)
+	if result.Revealed > 0 {
+		g.config.Metrics.FloodFilled(result.Revealed)
+	}
+	return result, err
+}
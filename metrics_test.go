@@ -0,0 +1,49 @@
+package gominesweeper
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+// recordedMetrics keeps everything it is told.
+type recordedMetrics struct {
+	created int
+	moves   []Action
+	errors  int
+	fills   []int
+	solved  int
+}
+
+func (m *recordedMetrics) GameCreated(cfg Config) { m.created++ }
+
+func (m *recordedMetrics) MoveApplied(action Action, latency time.Duration, err error) {
+	m.moves = append(m.moves, action)
+	if err != nil {
+		m.errors++
+	}
+}
+
+func (m *recordedMetrics) FloodFilled(size int) { m.fills = append(m.fills, size) }
+
+func (m *recordedMetrics) Solved(latency time.Duration) { m.solved++ }
+
+func (s *MSSuite) TestGame_Metrics(c *C) {
+	metrics := &recordedMetrics{}
+	game := newTestGame(c, Config{Metrics: metrics})
+	c.Check(metrics.created, Equals, 1)
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	_, err = game.Select(9, 9)
+	c.Check(err, NotNil)
+	_, err = game.Select(0, 1)
+	c.Assert(err, IsNil)
+	c.Check(metrics.moves, DeepEquals, []Action{Reveal, Flag, Reveal, Reveal})
+	c.Check(metrics.errors, Equals, 1)
+	c.Check(metrics.fills, DeepEquals, []int{6, 1})
+
+	c.Check(game.Solver().Safe(), DeepEquals, NewSolver(game.Snapshot()).Safe())
+	c.Check(metrics.solved, Equals, 1)
+}
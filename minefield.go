@@ -1,74 +1,184 @@
 package gominesweeper
 
 import (
+	"context"
 	"errors"
-	"math/rand"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
 	"time"
+
+	"github.com/smousa/go-minesweeper/minegen"
 )
 
 const (
-	Mine    = -1
-	Flagged = -2
-	Checked = -3
-	Unknown = -4
+	Mine       = -1
+	Flagged    = -2
+	Checked    = -3
+	Unknown    = -4
+	Exploded   = -5
+	WrongFlag  = -6
+	Defused    = -7
+	Revealed   = -8
+	Questioned = -9
 )
 
 var (
-	ErrExceedDimensions = errors.New("size exceeds max dimensions")
-	ErrOutOfBounds      = errors.New("point is out of bounds")
-	ErrBadCount         = errors.New("points not equal to specification")
-	ErrDupPoint         = errors.New("duplicate point found")
+	ErrExceedDimensions = minegen.ErrExceedDimensions
+	ErrOutOfBounds      = minegen.ErrOutOfBounds
+	ErrBadCount         = minegen.ErrBadCount
+	ErrDupPoint         = minegen.ErrDupPoint
+	ErrGameOver         = errors.New("game is over")
+	ErrReplayMismatch   = errors.New("replay is of a different minefield")
+	ErrNotDefusing      = errors.New("block is not being defused")
+	ErrUnknownName      = errors.New("unknown name")
+	ErrBadBoard         = errors.New("malformed board")
+	ErrBadProximity     = errors.New("proximity does not match the mines")
+	ErrUnplaceable      = minegen.ErrUnplaceable
+	ErrReplayVersion    = errors.New("replay is of an unsupported version")
+	ErrBadArchive       = errors.New("malformed archive")
+	ErrArchiveVersion   = errors.New("archive is of an unsupported version")
+	ErrResultMismatch   = errors.New("moves do not give the archived result")
+	ErrBadSignature     = errors.New("invalid signature")
+	ErrAlreadyRevealed  = errors.New("block is already revealed")
+	ErrFlagged          = errors.New("block is flagged")
+	ErrPaused           = errors.New("game is paused")
+	ErrRateLimited      = errors.New("moves are being made too fast")
+	ErrMoveRejected     = errors.New("move was rejected")
+	ErrFlagLimit        = errors.New("there are as many flags as mines")
+	ErrUnknownAction    = errors.New("unknown action")
+	ErrProximityFull    = errors.New("too many mines in a proximity")
+	ErrRevealedMine     = errors.New("mine is revealed")
+	ErrNotUnique        = errors.New("puzzle has more than one solution")
+	ErrNotDebug         = errors.New("game is not in debug mode")
+	ErrNotMine          = errors.New("block is not a mine")
+	ErrNoLimit          = errors.New("mode has no limit")
 )
 
 // Position represents an point on the X,Y axis
-type Position struct {
-	X, Y int
+type Position = minegen.Position
+
+// less returns true if the position comes before the other position when
+// ordered by row.
+func less(p, other Position) bool {
+	if p.Y != other.Y {
+		return p.Y < other.Y
+	}
+	return p.X < other.X
 }
 
 // Selector is a custom mine selector that given a width, height, and max
 // will return a set of positions for placing mines.
-type Selector func(width, height, max uint) ([]Position, error)
+type Selector = minegen.Selector
 
 // RandomSelector is a random mine selector.
 func RandomSelector(width, height, max uint) ([]Position, error) {
-	size := width * height
-	if size <= max {
-		return nil, ErrExceedDimensions
-	}
-	rand.Seed(time.Now().UnixNano())
-	scope := make([]uint, size)
-	for i := range scope {
-		scope[i] = uint(i)
-		j := rand.Intn(i + 1)
-		scope[i], scope[j] = scope[j], scope[i]
-	}
-	points := make([]Position, max)
-	for i := range points {
-		points[i] = Position{int(scope[i] % width), int(scope[i] / width)}
-	}
-	return points, nil
+	return minegen.Random(width, height, max)
 }
 
-// Block represents a single unit of space that will provide information of the
-// number of mines within its proximity.
-type Block struct {
-	proximity int
-	flagged   bool
-	checked   bool
+// SeededSelector returns a random mine selector that always places the mines
+// in the same positions for the same seed and dimensions.
+func SeededSelector(seed int64) Selector {
+	return minegen.Seeded(seed)
 }
 
+// SelectorFrom returns a random mine selector that draws from the source, so
+// every minefield it places takes new numbers from it.
+func SelectorFrom(src RandSource) Selector {
+	return minegen.From(src)
+}
+
+// Block represents a single unit of space that will provide information of the
+// number of mines within its proximity.  It is packed into a single byte: the
+// low 5 bits hold the proximity, or Mine, and the high 3 bits whether the block
+// is hidden, flagged, questioned, revealed or exploded.  The proximity of a
+// block is at most MaxProximity.
+type Block uint8
+
+// MaxProximity is the most mines a Block can count in its proximity.
+const MaxProximity = 30
+
+const (
+	// proximityMask holds the proximity of a block, which is mineProximity
+	// for a mine
+	proximityMask Block = 0x1f
+	mineProximity Block = 0x1f
+
+	// the status of a block, in the bits above its proximity
+	statusHidden     Block = 0 << 5
+	statusFlagged    Block = 1 << 5
+	statusQuestioned Block = 2 << 5
+	statusChecked    Block = 3 << 5
+	statusExploded   Block = 4 << 5
+)
+
 // NewBlock instantiates a new Block.
 func NewBlock(proximity int) *Block {
-	return &Block{proximity, false, false}
+	b := packProximity(proximity)
+	return &b
+}
+
+// packProximity returns a hidden block with the proximity, which must be Mine
+// or from 0 to MaxProximity.
+func packProximity(proximity int) Block {
+	if proximity == Mine {
+		return mineProximity
+	}
+	return Block(proximity) & proximityMask
+}
+
+// proximity returns the number of mines within the proximity of the block, or
+// Mine.
+func (b Block) proximity() int {
+	if b&proximityMask == mineProximity {
+		return Mine
+	}
+	return int(b & proximityMask)
+}
+
+// status returns the bits of the block's status.
+func (b Block) status() Block {
+	return b &^ proximityMask
+}
+
+// setStatus replaces the status of the block, keeping its proximity.
+func (b *Block) setStatus(status Block) {
+	*b = *b&proximityMask | status
+}
+
+// flagged returns true if the block has a flag on it.
+func (b Block) flagged() bool {
+	return b.status() == statusFlagged
+}
+
+// questioned returns true if the block has a question mark on it.
+func (b Block) questioned() bool {
+	return b.status() == statusQuestioned
+}
+
+// checked returns true if the block has been revealed.
+func (b Block) checked() bool {
+	return b.status() >= statusChecked
+}
+
+// exploded returns true if the block is the mine that went off.
+func (b Block) exploded() bool {
+	return b.status() == statusExploded
 }
 
 // Check will verify the status of a block while only revealing its proximity
 // if the block is selected.
 func (b *Block) Check() int {
-	if b.flagged {
+	switch b.status() {
+	case statusFlagged:
 		return Flagged
-	} else if b.checked {
-		return b.proximity
+	case statusExploded:
+		return Exploded
+	case statusChecked:
+		return b.proximity()
+	case statusQuestioned:
+		return Questioned
 	}
 	return Unknown
 }
@@ -76,121 +186,722 @@ func (b *Block) Check() int {
 // Select reveals the proximity of the block if not already revealed and not
 // previously flagged.
 func (b *Block) Select() int {
-	if b.flagged {
+	if b.flagged() {
 		return Flagged
-	} else if b.checked {
+	} else if b.checked() {
 		return Checked
 	}
-	b.checked = true
-	return b.proximity
+	b.setStatus(statusChecked)
+	return b.proximity()
+}
+
+// Explode marks a selected mine as the one that was set off.
+func (b *Block) Explode() {
+	if b.checked() && b.proximity() == Mine {
+		b.setStatus(statusExploded)
+	}
 }
 
-// ToggleFlag toggles the flag indicator on the block.
+// ToggleFlag toggles the flag indicator on the block, replacing any question
+// mark.
 func (b *Block) ToggleFlag() {
-	if !b.checked {
-		b.flagged = !b.flagged
+	if b.flagged() {
+		b.setStatus(statusHidden)
+	} else if !b.checked() {
+		b.setStatus(statusFlagged)
+	}
+}
+
+// ToggleQuestion toggles the question mark on a hidden, unflagged block.
+func (b *Block) ToggleQuestion() {
+	if b.questioned() {
+		b.setStatus(statusHidden)
+	} else if b.status() == statusHidden {
+		b.setStatus(statusQuestioned)
 	}
 }
 
-// Minefield describes the layout of all the blocks.
-type Minefield map[Position]*Block
+// Config describes how a minefield is generated.
+type Config struct {
+	Width, Height, Mines uint
+
+	// Selector places the mines; defaults to RandomSelector.
+	Selector Selector
+
+	// Neighborhood decides which blocks count towards a block's proximity and
+	// which blocks are revealed alongside a 0; defaults to Surrounding.
+	Neighborhood Neighborhood
+
+	// Lives is the number of mines a Game may set off before it is lost;
+	// defaults to 1.
+	Lives uint
+
+	// Win decides when a Game has been won; defaults to ClearAll.
+	Win WinCondition
+
+	// DefuseTime turns on defusing: every flag placed by a Game has to be
+	// defused within this time or, if it is on a mine, the mine goes off.
+	// The Win condition defaults to DefuseAll.
+	DefuseTime time.Duration
+
+	// OnDefuse is called when a flag starts to be defused, so that a
+	// minigame can be played before calling Game.Defuse.
+	OnDefuse func(g *Game, pos Position, deadline time.Time)
+
+	// ScoreAttack turns on scoring: every block revealed by a Game scores
+	// points multiplied by the combo of consecutive reveals, which is reset
+	// by setting off a mine, flagging a block without a mine or, when
+	// ComboTimeout is set, waiting longer than ComboTimeout between reveals.
+	ScoreAttack  bool
+	ComboTimeout time.Duration
+
+	// Actions limits the number of selections a Game may make that reveal
+	// a block, however many blocks they reveal.  The game is lost once they
+	// run out, unless its Win condition has been met, e.g. RevealFraction.
+	Actions uint
+
+	// Mode is how a Game is played to its end; defaults to Classic.  A
+	// TimeAttack ends after TimeLimit, counted from the first move, and a
+	// ClickLimited game is lost once it has made Clicks moves without
+	// being won.  NewGame returns ErrNoLimit if the mode's limit is 0.
+	Mode      Mode
+	TimeLimit time.Duration
+	Clicks    uint
+
+	// Blind hides the proximity of revealed blocks from a Game's Display,
+	// sending them to its subscribers as Cues instead.
+	Blind bool
+
+	// Splits are the fractions of the 3BV at which a Game takes split times,
+	// e.g. 0.25, 0.5 and 0.75.
+	Splits []float64
+
+	// OnEnd is called with the GameResult of a Game once it is over, e.g. to
+	// keep statistics.
+	OnEnd func(g *Game, result GameResult)
 
-// NewMinefield generates a new minefield using the random mine selector
-func NewMinefield(width, height, mines uint) (Minefield, error) {
-	return Minefield(make(map[Position]*Block)).init(width, height, mines, RandomSelector)
+	// OnRateOfPlay turns on reporting how fast a Game is being played, e.g.
+	// for dashboards or spotting cheats.  It is called after the move or Tick
+	// that is at least RateOfPlayInterval after the last report, and once
+	// more when the game is over.
+	OnRateOfPlay       func(g *Game, rate RateOfPlay)
+	RateOfPlayInterval time.Duration
+
+	// Strict turns the moves of a Game that would do nothing into errors:
+	// selecting a revealed block returns ErrAlreadyRevealed and a flagged
+	// one ErrFlagged, and flagging a revealed block returns
+	// ErrAlreadyRevealed.  Moves after the game is over always return
+	// ErrGameOver.
+	Strict bool
+
+	// AutoFlag has a Game flag the hidden neighbors of a revealed proximity
+	// once there are as many of them as mines around it.  The flags show up
+	// in the changes of the move's Event.
+	AutoFlag bool
+
+	// OpeningSize makes the first selection of a Game an opening: the mines
+	// within OpeningSize steps of it through the Neighborhood are moved to
+	// random blocks without a mine outside of them, where there is room.  The
+	// blocks are drawn from a source seeded by the board and the first
+	// selection, so that they always give the same minefield.  The selection
+	// returns ErrProximityFull if the moved mines cannot be counted.
+	OpeningSize uint
+
+	// BlockPausedMoves makes the moves of a paused Game return ErrPaused,
+	// instead of resuming it.
+	BlockPausedMoves bool
+
+	// MoveLimit and ValidateMove guard a Game against cheating, e.g. when it
+	// is played over a server.  Moves over the limit or that fail the
+	// validator are refused with a RejectionError before the move itself is
+	// checked, and do not count towards the limit.
+	MoveLimit    MoveLimit
+	ValidateMove MoveValidator
+
+	// FlagLimit keeps the number of flags from exceeding the number of
+	// mines: placing another flag returns ErrFlagLimit.
+	FlagLimit bool
+
+	// Metrics is told how a Game is played, e.g. for monitoring a server.
+	Metrics Metrics
+
+	// Sparse stores only the mines, the blocks with mines in their proximity
+	// and the blocks that have been played on, which saves memory on huge
+	// boards with very few mines, as each block stored takes tens of bytes
+	// rather than one.  The other blocks are created when first needed.
+	Sparse bool
+
+	// Debug allows RevealAll and XRay, which show where the mines of a Game
+	// are, e.g. for teaching, debugging solvers or building boards.  Without
+	// it they return ErrNotDebug.  It does not hide the mines anywhere else:
+	// the Replay and Archive of a game hold its layout while it is still
+	// being played, so they must be kept from the player until it is over.
+	Debug bool
+}
+
+// Minefield describes the layout of all the blocks.  The blocks are stored a
+// byte each, by row: a 1000x1000 board with 100,000 mines holds 1MB, where
+// allocating each block on its own took 68MB and 1.9 million allocations.
+//
+// Minefield used to be a map[Position]*Block used by value.  The compat
+// package keeps its NewMinefield, Select, ToggleFlag and Display working, so
+// callers can move over to the methods of *Minefield a piece at a time.
+type Minefield struct {
+	// cells holds every block by row, unless the minefield is sparse, when
+	// blocks holds only the blocks that have been set
+	cells         []Block
+	blocks        map[Position]*Block
+	neighborhood  Neighborhood
+	width, height int
+
+	// sparse leaves out the blocks without mines in their proximity until
+	// they are needed
+	sparse bool
+
+	// flagLimit keeps the flags from outnumbering the mines
+	flagLimit bool
+
+	// touched holds the positions of the blocks handed out to be changed
+	// since they were last taken, while they are tracked; all is set when
+	// every block may have changed
+	touched map[Position]bool
+	all     bool
+}
+
+// NewMinefield generates a new minefield using the random mine selector.  It
+// returns a *Minefield; compat.NewMinefield returns the original Minefield.
+func NewMinefield(width, height, mines uint) (*Minefield, error) {
+	return NewMinefieldConfig(Config{Width: width, Height: height, Mines: mines})
+}
+
+// NewMinefieldConfig generates a new minefield as described by the config.
+func NewMinefieldConfig(cfg Config) (*Minefield, error) {
+	return NewMinefieldContext(context.Background(), cfg)
+}
+
+// NewMinefieldContext generates a new minefield as described by the config,
+// giving up with the context's error once it is done.  The proximities are
+// counted by a goroutine per CPU, so the Neighborhood must be safe for
+// concurrent use.  A Selector that is still running when the context is done
+// is left to finish in the background.
+func NewMinefieldContext(ctx context.Context, cfg Config) (*Minefield, error) {
+	if cfg.Selector == nil {
+		cfg.Selector = RandomSelector
+	}
+	if cfg.Neighborhood == nil {
+		cfg.Neighborhood = Surrounding
+	}
+	mf := newMinefield(cfg.Neighborhood)
+	mf.sparse = cfg.Sparse
+	mf.flagLimit = cfg.FlagLimit
+	return mf.initContext(ctx, cfg.Width, cfg.Height, cfg.Mines, cfg.Selector)
+}
+
+// newMinefield returns an empty minefield.
+func newMinefield(neighborhood Neighborhood) *Minefield {
+	return &Minefield{neighborhood: neighborhood}
 }
 
 // init initializes the minefield.
-func (mf Minefield) init(width, height, mines uint, selector Selector) (Minefield, error) {
-	minefield, err := selector(width, height, mines)
+func (mf *Minefield) init(width, height, mines uint, selector Selector) (*Minefield, error) {
+	return mf.initContext(context.Background(), width, height, mines, selector)
+}
+
+// initContext initializes the minefield until the context is done.
+func (mf *Minefield) initContext(ctx context.Context, width, height, mines uint, selector Selector) (*Minefield, error) {
+	minefield, err := selectContext(ctx, width, height, mines, selector)
 	if err != nil {
 		return nil, err
 	} else if len(minefield) != int(mines) {
 		return nil, ErrBadCount
 	}
+	mf.width, mf.height = int(width), int(height)
+	if mf.sparse {
+		mf.blocks = make(map[Position]*Block)
+	} else {
+		mf.cells = make([]Block, width*height)
+	}
 
 	// set the mines on the map
 	for _, mine := range minefield {
 		// make sure we don't have bogus mines
-		if mine.X < 0 || mine.X >= int(width) || mine.Y < 0 || mine.Y >= int(height) {
-			return nil, ErrOutOfBounds
-		} else if block := mf[mine]; block != nil && block.proximity == Mine {
-			return nil, ErrDupPoint
-		}
-
-		mf[mine] = NewBlock(Mine)
-		for deltaX := -1; deltaX <= 1; deltaX++ {
-			if x := mine.X + deltaX; x >= 0 && x < int(width) {
-				for deltaY := -1; deltaY <= 1; deltaY++ {
-					if deltaX == 0 && deltaY == 0 {
-						continue
-					} else if y := mine.Y + deltaY; y >= 0 && y < int(height) {
-						if block := mf[Position{x, y}]; block != nil {
-							if block.proximity != Mine {
-								block.proximity++
-							}
-						} else {
-							mf[Position{x, y}] = NewBlock(1)
-						}
-					}
-				}
-			}
+		block, ok := mf.block(mine)
+		if !ok {
+			return nil, mf.outOfBounds(mine)
+		} else if block.proximity() == Mine {
+			return nil, &DuplicatePointError{mine}
 		}
+		*block = packProximity(Mine)
 	}
 
-	// set the remaining blocks
-	for x := 0; x < int(width); x++ {
-		for y := 0; y < int(height); y++ {
-			if _, ok := mf[Position{x, y}]; !ok {
-				mf[Position{x, y}] = NewBlock(0)
+	if mf.sparse {
+		if err := mf.countMines(); err != nil {
+			return nil, err
+		}
+		return mf, nil
+	}
+
+	// count the mines within each neighborhood, then set the remaining blocks
+	proximities, err := mf.proximities(ctx, int(width), int(height))
+	if err != nil {
+		return nil, err
+	}
+	for y, row := range proximities {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for x, proximity := range row {
+			if proximity > MaxProximity {
+				return nil, ErrProximityFull
+			} else if proximity != Mine {
+				mf.cells[y*mf.width+x] = packProximity(proximity)
 			}
 		}
 	}
 	return mf, nil
 }
 
-// Select will select an individual block and return the proximity to its
-// neighboring mines.  If the proximity is 0, then Select will recursively
-// reveal its neighbors as well.
-func (mf Minefield) Select(x, y int) (int, error) {
-	pos := Position{x, y}
-	block, ok := mf[pos]
-	if !ok {
-		return 0, ErrOutOfBounds
+// selectContext runs the selector, returning early with the context's error
+// once it is done.
+func selectContext(ctx context.Context, width, height, mines uint, selector Selector) ([]Position, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	} else if ctx.Done() == nil {
+		return selector(width, height, mines)
 	}
 
-	proximity := block.Select()
-	if proximity == 0 {
-		for deltaX := -1; deltaX <= 1; deltaX++ {
-			for deltaY := -1; deltaY <= 1; deltaY++ {
-				if deltaX == 0 && deltaY == 0 {
-					continue
-				}
-				mf.Select(x+deltaX, y+deltaY)
+	type result struct {
+		positions []Position
+		err       error
+	}
+	done := make(chan result, 1)
+	go func() {
+		positions, err := selector(width, height, mines)
+		done <- result{positions, err}
+	}()
+	select {
+	case r := <-done:
+		return r.positions, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// proximities counts the mines within the neighborhood of each block, by row,
+// splitting the rows between a goroutine per CPU.  Mines are counted as Mine.
+// Only the mines may have been set.
+func (mf *Minefield) proximities(ctx context.Context, width, height int) ([][]int, error) {
+	proximities := make([][]int, height)
+	rows := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.GOMAXPROCS(0); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := range rows {
+				proximities[y] = mf.rowProximities(width, y)
+			}
+		}()
+	}
+
+	var err error
+feed:
+	for y := 0; y < height; y++ {
+		select {
+		case rows <- y:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		}
+	}
+	close(rows)
+	wg.Wait()
+	return proximities, err
+}
+
+// rowProximities counts the mines within the neighborhood of each block on
+// the row.
+func (mf *Minefield) rowProximities(width, y int) []int {
+	row := make([]int, width)
+	for x := range row {
+		pos := Position{X: x, Y: y}
+		if block, _ := mf.peek(pos); block.proximity() == Mine {
+			row[x] = Mine
+			continue
+		}
+		for _, neighbor := range mf.neighborhood.Neighbors(pos) {
+			if block, ok := mf.peek(neighbor); ok && block.proximity() == Mine {
+				row[x]++
 			}
 		}
-	} else if proximity == Mine {
-		for _, block := range mf {
-			if block.proximity == Mine {
-				block.Select()
+	}
+	return row
+}
+
+// countMines sets the blocks with mines in their proximity, leaving out the
+// rest.
+func (mf *Minefield) countMines() error {
+	for _, mine := range mf.mines() {
+		for _, neighbor := range mf.neighborhood.Neighbors(mine) {
+			block, ok := mf.block(neighbor)
+			if !ok || block.proximity() == Mine {
+				continue
+			} else if block.proximity() == MaxProximity {
+				return ErrProximityFull
 			}
+			*block++
+		}
+	}
+	return nil
+}
+
+// block returns the block at the position, setting the block of a sparse
+// minefield if it was left out.
+func (mf *Minefield) block(pos Position) (*Block, bool) {
+	block, ok := mf.peek(pos)
+	if ok && mf.sparse {
+		mf.blocks[pos] = block
+	}
+	if ok && mf.touched != nil {
+		mf.touched[pos] = true
+	}
+	return block, ok
+}
+
+// touches returns the positions of the blocks that may have changed since it
+// was last called, tracking them from then on, or false if any block may
+// have changed.
+func (mf *Minefield) touches() (map[Position]bool, bool) {
+	touched, ok := mf.touched, mf.touched != nil && !mf.all
+	mf.touched, mf.all = make(map[Position]bool), false
+	return touched, ok
+}
+
+// untrack stops tracking the blocks that may have changed.
+func (mf *Minefield) untrack() {
+	mf.touched, mf.all = nil, false
+}
+
+// peek returns the block at the position for reading only; changes to a
+// block that was left out of a sparse minefield are lost.
+func (mf *Minefield) peek(pos Position) (*Block, bool) {
+	if !mf.contains(pos) {
+		return nil, false
+	} else if !mf.sparse {
+		return &mf.cells[pos.Y*mf.width+pos.X], true
+	} else if block, ok := mf.blocks[pos]; ok {
+		return block, true
+	}
+	return NewBlock(0), true
+}
+
+// contains returns true if the position is on the minefield.
+func (mf *Minefield) contains(pos Position) bool {
+	return pos.X >= 0 && pos.X < mf.width && pos.Y >= 0 && pos.Y < mf.height
+}
+
+// each calls the function with every block, by row.  Blocks that were left
+// out of a sparse minefield are for reading only.
+func (mf *Minefield) each(fn func(pos Position, block *Block)) {
+	for y := 0; y < mf.height; y++ {
+		for x := 0; x < mf.width; x++ {
+			pos := Position{X: x, Y: y}
+			block, _ := mf.peek(pos)
+			fn(pos, block)
 		}
 	}
-	return proximity, nil
 }
 
-// ToggleFlag toggles the flag on a particular mine.
-func (mf Minefield) ToggleFlag(x, y int) {
-	if block, ok := mf[Position{x, y}]; ok {
-		block.ToggleFlag()
+// stored calls the function with every block that is stored, which is every
+// block unless the minefield is sparse, in no particular order.  The blocks
+// left out of a sparse minefield are hidden 0s.
+func (mf *Minefield) stored(fn func(pos Position, block *Block)) {
+	if mf.sparse {
+		for pos, block := range mf.blocks {
+			fn(pos, block)
+		}
+		return
+	}
+	for i := range mf.cells {
+		fn(Position{X: i % mf.width, Y: i / mf.width}, &mf.cells[i])
+	}
+}
+
+// SelectKind is what happened to the block a Minefield selected.
+type SelectKind int
+
+const (
+	// SelectRevealed revealed the proximity of a block without a mine, along
+	// with its neighbors if it was a 0.
+	SelectRevealed SelectKind = iota
+
+	// SelectMine revealed a mine, and with it every other mine.
+	SelectMine
+
+	// SelectBlocked did nothing, because the block is flagged.
+	SelectBlocked
+
+	// SelectAlreadyRevealed did nothing, because the block was revealed
+	// before.
+	SelectAlreadyRevealed
+)
+
+// String returns the name of the kind.
+func (k SelectKind) String() string {
+	switch k {
+	case SelectRevealed:
+		return "revealed"
+	case SelectMine:
+		return "mine"
+	case SelectBlocked:
+		return "blocked"
+	case SelectAlreadyRevealed:
+		return "already revealed"
+	}
+	return "unknown"
+}
+
+// SelectResult is the outcome of selecting a block of a Minefield.
+type SelectResult struct {
+	Kind SelectKind
+
+	// Value is the proximity of the block, or Mine.  It is 0 when the
+	// selection was blocked by a flag.
+	Value int
+
+	// Revealed is the number of blocks the selection revealed, counting the
+	// neighbors revealed around a 0 but not the other mines.
+	Revealed int
+}
+
+// Select will select an individual block and report what it revealed.  If
+// the proximity is 0, then Select will recursively reveal its neighbors as
+// well.
+func (mf *Minefield) Select(x, y int) (SelectResult, error) {
+	pos := Position{X: x, Y: y}
+	proximity, revealed, err := mf.reveal(pos)
+	if err != nil {
+		return SelectResult{}, err
+	}
+
+	result := SelectResult{Value: proximity, Revealed: revealed}
+	switch proximity {
+	case Mine:
+		result.Kind = SelectMine
+		mf.revealMines()
+	case Flagged:
+		result.Kind, result.Value = SelectBlocked, 0
+	case Checked:
+		block, _ := mf.peek(pos)
+		result.Kind, result.Value = SelectAlreadyRevealed, block.proximity()
+	}
+	return result, nil
+}
+
+// reveal selects the block at the position, recursively revealing the
+// neighbors of any 0, and returns the number of blocks it revealed.
+func (mf *Minefield) reveal(pos Position) (proximity, revealed int, err error) {
+	block, ok := mf.block(pos)
+	if !ok {
+		return 0, 0, mf.outOfBounds(pos)
 	}
+
+	proximity = block.Select()
+	if proximity == Checked || proximity == Flagged {
+		return proximity, 0, nil
+	}
+	revealed = 1
+	if proximity == 0 {
+		for _, neighbor := range mf.neighborhood.Neighbors(pos) {
+			_, n, _ := mf.reveal(neighbor)
+			revealed += n
+		}
+	}
+	return proximity, revealed, nil
+}
+
+// revealMines selects every mine on the minefield.
+func (mf *Minefield) revealMines() {
+	mf.stored(func(pos Position, block *Block) {
+		if block.proximity() == Mine {
+			block.Select()
+		}
+	})
+}
+
+// Width returns the number of blocks in each row of the minefield.
+func (mf *Minefield) Width() int {
+	return mf.width
+}
+
+// Height returns the number of rows of the minefield.
+func (mf *Minefield) Height() int {
+	return mf.height
+}
+
+// size returns the width and height of the minefield.
+func (mf *Minefield) size() (width, height int) {
+	return mf.width, mf.height
+}
+
+// safe returns the number of blocks that are not mines.
+func (mf *Minefield) safe() int {
+	safe := mf.width * mf.height
+	mf.stored(func(pos Position, block *Block) {
+		if block.proximity() == Mine {
+			safe--
+		}
+	})
+	return safe
+}
+
+// flagMines flags every mine that has not already been flagged or selected.
+func (mf *Minefield) flagMines() {
+	mf.stored(func(pos Position, block *Block) {
+		if block.proximity() == Mine && !block.flagged() {
+			block.ToggleFlag()
+		}
+	})
+}
+
+// mines returns the positions of every mine, ordered by row.
+func (mf *Minefield) mines() []Position {
+	var mines []Position
+	mf.stored(func(pos Position, block *Block) {
+		if block.proximity() == Mine {
+			mines = append(mines, pos)
+		}
+	})
+	sortPositions(mines)
+	return mines
+}
+
+// sortPositions orders the positions by row.
+func sortPositions(positions []Position) {
+	sort.Slice(positions, func(i, j int) bool {
+		return less(positions[i], positions[j])
+	})
+}
+
+// revealed returns the number of selected blocks that are not mines.
+func (mf *Minefield) revealed() int {
+	count := 0
+	mf.stored(func(pos Position, block *Block) {
+		if block.proximity() != Mine && block.checked() {
+			count++
+		}
+	})
+	return count
+}
+
+// cleared returns true when every block that is not a mine has been selected.
+func (mf *Minefield) cleared() bool {
+	return mf.revealed() == mf.safe()
+}
+
+// Clone returns a deep copy of the minefield, which can be played without
+// affecting the original.
+func (mf *Minefield) Clone() *Minefield {
+	clone := *mf
+	clone.touched, clone.all = nil, false
+	clone.cells = slices.Clone(mf.cells)
+	if mf.blocks != nil {
+		clone.blocks = make(map[Position]*Block, len(mf.blocks))
+		for pos, block := range mf.blocks {
+			copied := *block
+			clone.blocks[pos] = &copied
+		}
+	}
+	return &clone
+}
+
+// Reset hides every block and removes every flag, so that the minefield can
+// be played again from the start.
+func (mf *Minefield) Reset() {
+	mf.stored(func(pos Position, block *Block) {
+		block.setStatus(statusHidden)
+	})
+	mf.all = true
+}
+
+// CellState is whether a block is hidden, flagged or revealed.
+type CellState int
+
+const (
+	CellHidden CellState = iota
+	CellFlagged
+	CellRevealed
+	CellQuestioned
+)
+
+// String returns the name of the state.
+func (s CellState) String() string {
+	switch s {
+	case CellHidden:
+		return "hidden"
+	case CellFlagged:
+		return "flagged"
+	case CellRevealed:
+		return "revealed"
+	case CellQuestioned:
+		return "questioned"
+	}
+	return "unknown"
+}
+
+// state returns the state of the block.
+func (b *Block) state() CellState {
+	if b.checked() {
+		return CellRevealed
+	} else if b.flagged() {
+		return CellFlagged
+	} else if b.questioned() {
+		return CellQuestioned
+	}
+	return CellHidden
+}
+
+// ToggleFlag toggles the flag on a particular block and returns the state it
+// was left in, which is CellRevealed if the block was revealed and so could
+// not be flagged.  It returns ErrFlagLimit if the minefield has a flag limit
+// that the flag would exceed.
+func (mf *Minefield) ToggleFlag(x, y int) (CellState, error) {
+	pos := Position{X: x, Y: y}
+	block, ok := mf.block(pos)
+	if !ok {
+		return CellHidden, mf.outOfBounds(pos)
+	} else if err := mf.canFlag(block); err != nil {
+		return block.state(), err
+	}
+	block.ToggleFlag()
+	return block.state(), nil
+}
+
+// canFlag returns ErrFlagLimit if flagging the block would put more flags on
+// the minefield than mines.
+func (mf *Minefield) canFlag(block *Block) error {
+	if mf.flagLimit && !block.flagged() && !block.checked() && mf.Flags() >= len(mf.mines()) {
+		return ErrFlagLimit
+	}
+	return nil
+}
+
+// Flags returns the number of flagged blocks.
+func (mf *Minefield) Flags() int {
+	count := 0
+	mf.stored(func(pos Position, block *Block) {
+		if block.flagged() {
+			count++
+		}
+	})
+	return count
 }
 
 // Display returns the current state of all the blocks.
-func (mf Minefield) Display() map[Position]int {
+func (mf *Minefield) Display() map[Position]int {
 	display := make(map[Position]int)
-	for pos, block := range mf {
+	mf.each(func(pos Position, block *Block) {
 		display[pos] = block.Check()
-	}
+	})
 	return display
-}
\ No newline at end of file
+}
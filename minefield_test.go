@@ -1,7 +1,10 @@
 package gominesweeper
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	. "gopkg.in/check.v1"
 )
@@ -35,6 +38,20 @@ var _ = Suite(&MSSuite{
 })
 
 func (s *MSSuite) BenchmarkSelector(c *C) {
+	for i := 0; i < c.N; i++ {
+		if _, err := s.selector(30, 16, 99); err != nil {
+			c.Fatal(err)
+		}
+	}
+}
+
+// allBlocks returns every block of the minefield.
+func allBlocks(mf *Minefield) map[Position]*Block {
+	blocks := make(map[Position]*Block)
+	mf.each(func(pos Position, block *Block) {
+		blocks[pos] = block
+	})
+	return blocks
 }
 
 func (s *MSSuite) TestSelector(c *C) {
@@ -59,6 +76,23 @@ func (s *MSSuite) TestSelector(c *C) {
 	}
 }
 
+func (s *MSSuite) TestSeededSelector(c *C) {
+	points, err := SeededSelector(42)(9, 9, 10)
+	c.Assert(err, IsNil)
+	c.Check(points, HasLen, 10)
+
+	again, err := SeededSelector(42)(9, 9, 10)
+	c.Assert(err, IsNil)
+	c.Check(again, DeepEquals, points)
+
+	other, err := SeededSelector(43)(9, 9, 10)
+	c.Assert(err, IsNil)
+	c.Check(other, Not(DeepEquals), points)
+
+	_, err = SeededSelector(42)(2, 2, 5)
+	c.Check(err, Equals, ErrExceedDimensions)
+}
+
 func (s *MSSuite) TestBlock(c *C) {
 	b := NewBlock(2)
 	c.Check(b.Check(), Equals, Unknown)
@@ -70,155 +104,333 @@ func (s *MSSuite) TestBlock(c *C) {
 	c.Check(b.Select(), Equals, 2)
 	c.Check(b.Check(), Equals, 2)
 	c.Check(b.Select(), Equals, Checked)
+
+	// the proximity and status share a byte
+	for _, proximity := range []int{0, 8, MaxProximity, Mine} {
+		b := NewBlock(proximity)
+		b.ToggleQuestion()
+		c.Check(b.Check(), Equals, Questioned)
+		c.Check(b.Select(), Equals, proximity)
+		b.Explode()
+		if proximity == Mine {
+			c.Check(b.Check(), Equals, Exploded)
+		} else {
+			c.Check(b.Check(), Equals, proximity)
+		}
+		c.Check(b.proximity(), Equals, proximity)
+	}
+}
+
+func (s *MSSuite) TestMinefield_ProximityFull(c *C) {
+	// a radius of 3 has 48 neighbors, more than a block can count
+	mines := Radius(3).Neighbors(Position{X: 3, Y: 3})
+	selector := func(width, height, max uint) ([]Position, error) {
+		return mines, nil
+	}
+	cfg := Config{Width: 7, Height: 7, Mines: 48, Neighborhood: Radius(3), Selector: selector}
+	_, err := NewMinefieldConfig(cfg)
+	c.Check(err, Equals, ErrProximityFull)
+	cfg.Sparse = true
+	_, err = NewMinefieldConfig(cfg)
+	c.Check(err, Equals, ErrProximityFull)
+
+	// up to 30 is fine
+	cfg.Mines, mines = 30, mines[:30]
+	minefield, err := NewMinefieldConfig(cfg)
+	c.Assert(err, IsNil)
+	c.Check(minefield.Display(), HasLen, 49)
+	block, _ := minefield.peek(Position{X: 3, Y: 3})
+	c.Check(block.proximity(), Equals, MaxProximity)
 }
 
 func (s *MSSuite) BenchmarkMinefield(c *C) {
+	for i := 0; i < c.N; i++ {
+		if _, err := NewMinefieldConfig(Config{Width: 30, Height: 16, Mines: 99, Selector: s.selector}); err != nil {
+			c.Fatal(err)
+		}
+	}
 }
 
 func (s *MSSuite) TestMinefield(c *C) {
 	// mismatch points
-	_, err := Minefield(make(map[Position]*Block)).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+	_, err := newMinefield(Surrounding).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
 		return []Position{}, nil
 	})
 	c.Check(err, Equals, ErrBadCount)
 
 	// duplicate points
-	_, err = Minefield(make(map[Position]*Block)).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
-		return []Position{{1, 2}, {3, 4}, {0, 0}, {1, 2}, {4, 0}}, nil
+	_, err = newMinefield(Surrounding).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 1, Y: 2}, {X: 4, Y: 0}}, nil
 	})
-	c.Check(err, Equals, ErrDupPoint)
+	c.Check(err, DeepEquals, &DuplicatePointError{Position{X: 1, Y: 2}})
+	c.Check(errors.Is(err, ErrDupPoint), Equals, true)
 
 	// out of bounds
-	_, err = Minefield(make(map[Position]*Block)).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
-		return []Position{{1, 2}, {3, 4}, {0, 0}, {5, 7}, {4, 0}}, nil
+	_, err = newMinefield(Surrounding).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 5, Y: 7}, {X: 4, Y: 0}}, nil
 	})
-	c.Assert(err, Equals, ErrOutOfBounds)
+	c.Assert(err, DeepEquals, &OutOfBoundsError{Position{X: 5, Y: 7}, 5, 5})
+	c.Check(errors.Is(err, ErrOutOfBounds), Equals, true)
+	c.Check(err, ErrorMatches, `point \(5,7\) is out of bounds of 5x5`)
 
 	// success
-	expected := Minefield(map[Position]*Block{
-		Position{0, 0}: NewBlock(Mine), Position{0, 1}: NewBlock(2), Position{0, 2}: NewBlock(1), Position{0, 3}: NewBlock(1), Position{0, 4}: NewBlock(0),
-		Position{1, 0}: NewBlock(2), Position{1, 1}: NewBlock(3), Position{1, 2}: NewBlock(Mine), Position{1, 3}: NewBlock(1), Position{1, 4}: NewBlock(0),
-		Position{2, 0}: NewBlock(1), Position{2, 1}: NewBlock(Mine), Position{2, 2}: NewBlock(2), Position{2, 3}: NewBlock(2), Position{2, 4}: NewBlock(1),
-		Position{3, 0}: NewBlock(2), Position{3, 1}: NewBlock(2), Position{3, 2}: NewBlock(1), Position{3, 3}: NewBlock(1), Position{3, 4}: NewBlock(Mine),
-		Position{4, 0}: NewBlock(Mine), Position{4, 1}: NewBlock(1), Position{4, 2}: NewBlock(0), Position{4, 3}: NewBlock(1), Position{4, 4}: NewBlock(1),
-	})
-	minefield, err := Minefield(make(map[Position]*Block)).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
-		return []Position{{1, 2}, {3, 4}, {0, 0}, {2, 1}, {4, 0}}, nil
+	expected := map[Position]*Block{
+		Position{X: 0, Y: 0}: NewBlock(Mine), Position{X: 0, Y: 1}: NewBlock(2), Position{X: 0, Y: 2}: NewBlock(1), Position{X: 0, Y: 3}: NewBlock(1), Position{X: 0, Y: 4}: NewBlock(0),
+		Position{X: 1, Y: 0}: NewBlock(2), Position{X: 1, Y: 1}: NewBlock(3), Position{X: 1, Y: 2}: NewBlock(Mine), Position{X: 1, Y: 3}: NewBlock(1), Position{X: 1, Y: 4}: NewBlock(0),
+		Position{X: 2, Y: 0}: NewBlock(1), Position{X: 2, Y: 1}: NewBlock(Mine), Position{X: 2, Y: 2}: NewBlock(2), Position{X: 2, Y: 3}: NewBlock(2), Position{X: 2, Y: 4}: NewBlock(1),
+		Position{X: 3, Y: 0}: NewBlock(2), Position{X: 3, Y: 1}: NewBlock(2), Position{X: 3, Y: 2}: NewBlock(1), Position{X: 3, Y: 3}: NewBlock(1), Position{X: 3, Y: 4}: NewBlock(Mine),
+		Position{X: 4, Y: 0}: NewBlock(Mine), Position{X: 4, Y: 1}: NewBlock(1), Position{X: 4, Y: 2}: NewBlock(0), Position{X: 4, Y: 3}: NewBlock(1), Position{X: 4, Y: 4}: NewBlock(1),
+	}
+	minefield, err := newMinefield(Surrounding).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
 	})
 	c.Assert(err, IsNil)
-	c.Check(minefield, DeepEquals, expected)
+	c.Check(allBlocks(minefield), DeepEquals, expected)
+}
+
+func (s *MSSuite) TestMinefield_Context(c *C) {
+	// a large board counts the same proximities in parallel
+	cfg := Config{Width: 300, Height: 200, Mines: 12000, Selector: SeededSelector(42)}
+	minefield, err := NewMinefieldContext(context.Background(), cfg)
+	c.Assert(err, IsNil)
+	c.Assert(minefield.cells, HasLen, 300*200)
+	for pos, block := range allBlocks(minefield) {
+		if block.proximity() == Mine {
+			continue
+		}
+		proximity := 0
+		for _, neighbor := range Surrounding.Neighbors(pos) {
+			if other, ok := minefield.peek(neighbor); ok && other.proximity() == Mine {
+				proximity++
+			}
+		}
+		c.Assert(block.proximity(), Equals, proximity, Commentf("block %v", pos))
+	}
+
+	// a cancelled context gives up before selecting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewMinefieldContext(ctx, cfg)
+	c.Check(err, Equals, context.Canceled)
+
+	// a slow selector is abandoned once the context times out
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	release := make(chan struct{})
+	defer close(release)
+	cfg.Selector = func(width, height, max uint) ([]Position, error) {
+		<-release
+		return nil, nil
+	}
+	_, err = NewMinefieldContext(ctx, cfg)
+	c.Check(err, Equals, context.DeadlineExceeded)
+}
+
+func (s *MSSuite) TestMinefield_Sparse(c *C) {
+	cfg := Config{Width: 5, Height: 5, Mines: 5, Selector: func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+	}}
+	dense, err := NewGame(cfg)
+	c.Assert(err, IsNil)
+	cfg.Sparse = true
+	sparse, err := NewGame(cfg)
+	c.Assert(err, IsNil)
+
+	// only the mines and their numbers are stored
+	c.Check(sparse.minefield.blocks, HasLen, 22)
+	c.Check(sparse.Display(), DeepEquals, dense.Display())
+	c.Check(sparse.Progress(), DeepEquals, dense.Progress())
+
+	for _, game := range []*Game{dense, sparse} {
+		_, err = game.Select(4, 2)
+		c.Assert(err, IsNil)
+		c.Assert(game.ToggleFlag(0, 4), IsNil)
+		_, err = game.Select(1, 4)
+		c.Assert(err, IsNil)
+	}
+	c.Check(sparse.minefield.blocks, HasLen, 25)
+	c.Check(sparse.Display(), DeepEquals, dense.Display())
+	c.Check(sparse.Progress(), DeepEquals, dense.Progress())
+
+	_, err = sparse.Select(5, 0)
+	c.Check(err, DeepEquals, &OutOfBoundsError{Position{X: 5, Y: 0}, 5, 5})
+	_, err = sparse.Select(0, -1)
+	c.Check(err, DeepEquals, &OutOfBoundsError{Position{X: 0, Y: -1}, 5, 5})
+	c.Check(sparse.ToggleFlag(-1, -1), DeepEquals, &OutOfBoundsError{Position{X: -1, Y: -1}, 5, 5})
 }
 
 func (s *MSSuite) TestMinefield_Select(c *C) {
-	minefield, err := Minefield(make(map[Position]*Block)).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
-		return []Position{{1, 2}, {3, 4}, {0, 0}, {2, 1}, {4, 0}}, nil
+	minefield, err := newMinefield(Surrounding).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
 	})
 	c.Assert(err, IsNil)
+	c.Check(minefield.Width(), Equals, 5)
+	c.Check(minefield.Height(), Equals, 5)
 
 	// out of bounds
-	position, err := minefield.Select(2, 10)
-	c.Assert(err, Equals, ErrOutOfBounds)
+	_, err = minefield.Select(2, 10)
+	c.Assert(err, DeepEquals, &OutOfBoundsError{Position{X: 2, Y: 10}, 5, 5})
+	_, err = minefield.Select(-1, 0)
+	c.Check(err, DeepEquals, &OutOfBoundsError{Position{X: -1, Y: 0}, 5, 5})
 
-	position, err = minefield.Select(0, 1)
+	result, err := minefield.Select(0, 1)
 	c.Assert(err, IsNil)
-	c.Assert(position, Equals, 2)
-	position, err = minefield.Select(0, 1)
+	c.Check(result, Equals, SelectResult{Kind: SelectRevealed, Value: 2, Revealed: 1})
+	result, err = minefield.Select(0, 1)
 	c.Assert(err, IsNil)
-	c.Assert(position, Equals, Checked)
-	position, err = minefield.Select(4, 2)
+	c.Check(result, Equals, SelectResult{Kind: SelectAlreadyRevealed, Value: 2})
+	result, err = minefield.Select(4, 2)
 	c.Assert(err, IsNil)
-	c.Assert(position, Equals, 0)
-	position, err = minefield.Select(0, 0)
+	c.Check(result, Equals, SelectResult{Kind: SelectRevealed, Value: 0, Revealed: 6})
+	result, err = minefield.Select(0, 0)
 	c.Assert(err, IsNil)
-	c.Assert(position, Equals, Mine)
+	c.Check(result, Equals, SelectResult{Kind: SelectMine, Value: Mine, Revealed: 1})
+	c.Check(result.Kind.String(), Equals, "mine")
 }
 
 func (s *MSSuite) TestMinefield_ToggleFlag(c *C) {
-	minefield, err := Minefield(make(map[Position]*Block)).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
-		return []Position{{1, 2}, {3, 4}, {0, 0}, {2, 1}, {4, 0}}, nil
+	minefield, err := newMinefield(Surrounding).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
 	})
 	c.Assert(err, IsNil)
 
-	minefield.ToggleFlag(0, 1)
-	position, err := minefield.Select(0, 1)
+	state, err := minefield.ToggleFlag(0, 1)
+	c.Assert(err, IsNil)
+	c.Check(state, Equals, CellFlagged)
+	result, err := minefield.Select(0, 1)
+	c.Assert(err, IsNil)
+	c.Check(result, Equals, SelectResult{Kind: SelectBlocked})
+	state, err = minefield.ToggleFlag(0, 1)
 	c.Assert(err, IsNil)
-	c.Assert(position, Equals, Flagged)
-	minefield.ToggleFlag(0, 1)
-	position, err = minefield.Select(0, 1)
+	c.Check(state, Equals, CellHidden)
+	result, err = minefield.Select(0, 1)
 	c.Assert(err, IsNil)
-	c.Assert(position, Equals, 2)
+	c.Check(result, Equals, SelectResult{Kind: SelectRevealed, Value: 2, Revealed: 1})
+
+	// revealed blocks cannot be flagged
+	state, err = minefield.ToggleFlag(0, 1)
+	c.Assert(err, IsNil)
+	c.Check(state, Equals, CellRevealed)
+	c.Check(state.String(), Equals, "revealed")
+
+	_, err = minefield.ToggleFlag(5, 0)
+	c.Check(err, DeepEquals, &OutOfBoundsError{Position{X: 5, Y: 0}, 5, 5})
+
+	// with a flag limit there are never more flags than mines
+	minefield.flagLimit = true
+	for x := 0; x < 5; x++ {
+		_, err = minefield.ToggleFlag(x, 4)
+		c.Assert(err, IsNil)
+	}
+	c.Check(minefield.Flags(), Equals, 5)
+	state, err = minefield.ToggleFlag(0, 3)
+	c.Check(err, Equals, ErrFlagLimit)
+	c.Check(state, Equals, CellHidden)
+	state, err = minefield.ToggleFlag(0, 4)
+	c.Assert(err, IsNil)
+	c.Check(state, Equals, CellHidden)
 }
 
 func (s *MSSuite) TestMinefield_Display(c *C) {
-	minefield, err := Minefield(make(map[Position]*Block)).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
-		return []Position{{1, 2}, {3, 4}, {0, 0}, {2, 1}, {4, 0}}, nil
+	minefield, err := newMinefield(Surrounding).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
 	})
 	c.Assert(err, IsNil)
 
 	expected := map[Position]int{
-		Position{0, 0}: Unknown, Position{0, 1}: Unknown, Position{0, 2}: Unknown, Position{0, 3}: Unknown, Position{0, 4}: Unknown,
-		Position{1, 0}: Unknown, Position{1, 1}: Unknown, Position{1, 2}: Unknown, Position{1, 3}: Unknown, Position{1, 4}: Unknown,
-		Position{2, 0}: Unknown, Position{2, 1}: Unknown, Position{2, 2}: Unknown, Position{2, 3}: Unknown, Position{2, 4}: Unknown,
-		Position{3, 0}: Unknown, Position{3, 1}: Unknown, Position{3, 2}: Unknown, Position{3, 3}: Unknown, Position{3, 4}: Unknown,
-		Position{4, 0}: Unknown, Position{4, 1}: Unknown, Position{4, 2}: Unknown, Position{4, 3}: Unknown, Position{4, 4}: Unknown,
+		Position{X: 0, Y: 0}: Unknown, Position{X: 0, Y: 1}: Unknown, Position{X: 0, Y: 2}: Unknown, Position{X: 0, Y: 3}: Unknown, Position{X: 0, Y: 4}: Unknown,
+		Position{X: 1, Y: 0}: Unknown, Position{X: 1, Y: 1}: Unknown, Position{X: 1, Y: 2}: Unknown, Position{X: 1, Y: 3}: Unknown, Position{X: 1, Y: 4}: Unknown,
+		Position{X: 2, Y: 0}: Unknown, Position{X: 2, Y: 1}: Unknown, Position{X: 2, Y: 2}: Unknown, Position{X: 2, Y: 3}: Unknown, Position{X: 2, Y: 4}: Unknown,
+		Position{X: 3, Y: 0}: Unknown, Position{X: 3, Y: 1}: Unknown, Position{X: 3, Y: 2}: Unknown, Position{X: 3, Y: 3}: Unknown, Position{X: 3, Y: 4}: Unknown,
+		Position{X: 4, Y: 0}: Unknown, Position{X: 4, Y: 1}: Unknown, Position{X: 4, Y: 2}: Unknown, Position{X: 4, Y: 3}: Unknown, Position{X: 4, Y: 4}: Unknown,
 	}
 	actual := minefield.Display()
 	c.Assert(actual, DeepEquals, expected)
 
 	minefield.ToggleFlag(0, 3)
 
-	expected[Position{0, 3}] = Flagged
+	expected[Position{X: 0, Y: 3}] = Flagged
 	actual = minefield.Display()
 	c.Assert(actual, DeepEquals, expected)
 
-	proximity, err := minefield.Select(4, 2)
+	result, err := minefield.Select(4, 2)
 	c.Assert(err, IsNil)
-	c.Assert(proximity, Equals, 0)
-
-	expected[Position{4, 2}] = 0
-	expected[Position{4, 3}] = 1
-	expected[Position{3, 3}] = 1
-	expected[Position{3, 2}] = 1
-	expected[Position{3, 1}] = 2
-	expected[Position{4, 1}] = 1
+	c.Assert(result.Value, Equals, 0)
+
+	expected[Position{X: 4, Y: 2}] = 0
+	expected[Position{X: 4, Y: 3}] = 1
+	expected[Position{X: 3, Y: 3}] = 1
+	expected[Position{X: 3, Y: 2}] = 1
+	expected[Position{X: 3, Y: 1}] = 2
+	expected[Position{X: 4, Y: 1}] = 1
 	actual = minefield.Display()
 	c.Assert(actual, DeepEquals, expected)
 
-	proximity, err = minefield.Select(4, 2)
+	result, err = minefield.Select(4, 2)
 	c.Assert(err, IsNil)
-	c.Assert(proximity, Equals, Checked)
+	c.Assert(result.Kind, Equals, SelectAlreadyRevealed)
 	actual = minefield.Display()
 	c.Assert(actual, DeepEquals, expected)
 
-	proximity, err = minefield.Select(4, 4)
+	result, err = minefield.Select(4, 4)
 	c.Assert(err, IsNil)
-	c.Assert(proximity, Equals, 1)
+	c.Assert(result.Value, Equals, 1)
 
-	expected[Position{4, 4}] = 1
+	expected[Position{X: 4, Y: 4}] = 1
 	actual = minefield.Display()
 	c.Assert(actual, DeepEquals, expected)
 
-	proximity, err = minefield.Select(0, 4)
+	result, err = minefield.Select(0, 4)
 	c.Assert(err, IsNil)
-	c.Assert(proximity, Equals, 0)
+	c.Assert(result.Value, Equals, 0)
 
-	expected[Position{0, 4}] = 0
-	expected[Position{1, 4}] = 0
-	expected[Position{2, 4}] = 1
-	expected[Position{2, 3}] = 2
-	expected[Position{1, 3}] = 1
+	expected[Position{X: 0, Y: 4}] = 0
+	expected[Position{X: 1, Y: 4}] = 0
+	expected[Position{X: 2, Y: 4}] = 1
+	expected[Position{X: 2, Y: 3}] = 2
+	expected[Position{X: 1, Y: 3}] = 1
 	actual = minefield.Display()
 	c.Assert(actual, DeepEquals, expected)
 
-	proximity, err = minefield.Select(0, 0)
+	result, err = minefield.Select(0, 0)
 	c.Assert(err, IsNil)
-	c.Assert(proximity, Equals, Mine)
+	c.Assert(result.Value, Equals, Mine)
 
-	expected[Position{0, 0}] = Mine
-	expected[Position{4, 0}] = Mine
-	expected[Position{1, 2}] = Mine
-	expected[Position{2, 1}] = Mine
-	expected[Position{3, 4}] = Mine
+	expected[Position{X: 0, Y: 0}] = Mine
+	expected[Position{X: 4, Y: 0}] = Mine
+	expected[Position{X: 1, Y: 2}] = Mine
+	expected[Position{X: 2, Y: 1}] = Mine
+	expected[Position{X: 3, Y: 4}] = Mine
 	actual = minefield.Display()
 	c.Assert(actual, DeepEquals, expected)
-}
\ No newline at end of file
+}
+
+func (s *MSSuite) TestMinefield_Clone(c *C) {
+	minefield, err := newMinefield(Surrounding).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+	})
+	c.Assert(err, IsNil)
+	_, err = minefield.Select(4, 2)
+	c.Assert(err, IsNil)
+	minefield.ToggleFlag(0, 0)
+
+	// the clone starts out the same but is played on separately
+	clone := minefield.Clone()
+	c.Check(clone.Display(), DeepEquals, minefield.Display())
+	_, err = clone.Select(0, 4)
+	c.Assert(err, IsNil)
+	clone.ToggleFlag(0, 0)
+	c.Check(clone.Display()[Position{X: 0, Y: 4}], Equals, 0)
+	c.Check(minefield.Display()[Position{X: 0, Y: 4}], Equals, Unknown)
+	c.Check(minefield.Display()[Position{X: 0, Y: 0}], Equals, Flagged)
+
+	// reset hides everything again, keeping the mines
+	clone.Reset()
+	for pos, value := range clone.Display() {
+		c.Check(value, Equals, Unknown, Commentf("block %v", pos))
+	}
+	c.Check(clone.mines(), DeepEquals, minefield.mines())
+	result, err := clone.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(result.Revealed, Equals, 6)
+}
@@ -0,0 +1,164 @@
+package minegen
+
+import (
+	"sort"
+	"strings"
+)
+
+// Neighborhood decides which blocks count towards a block's number, and which
+// blocks are revealed alongside a 0.
+type Neighborhood interface {
+	Neighbors(pos Position) []Position
+}
+
+// surrounding is the neighborhood of the 8 blocks that touch a block, used by
+// boards without one.
+type surrounding struct{}
+
+func (surrounding) Neighbors(pos Position) []Position {
+	neighbors := make([]Position, 0, 8)
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx != 0 || dy != 0 {
+				neighbors = append(neighbors, Position{pos.X + dx, pos.Y + dy})
+			}
+		}
+	}
+	return neighbors
+}
+
+// Board is a minefield with its mines placed, as much of one as the searches
+// need to play it.
+type Board struct {
+	Width, Height int
+
+	// Mines are the positions of the mines, ordered by row.
+	Mines []Position
+
+	// Neighborhood decides which blocks border each other; the 8 blocks
+	// surrounding each block if it is nil.
+	Neighborhood Neighborhood
+}
+
+// Generate places the mines of a board of the size with the selector.  It
+// returns ErrBadCount if the selector places the wrong number of mines, and
+// ErrOutOfBounds or ErrDupPoint for a mine off the board or placed twice.
+func Generate(width, height, mines uint, selector Selector) (Board, error) {
+	positions, err := selector(width, height, mines)
+	if err != nil {
+		return Board{}, err
+	} else if len(positions) != int(mines) {
+		return Board{}, ErrBadCount
+	}
+
+	board := Board{Width: int(width), Height: int(height), Mines: append([]Position(nil), positions...)}
+	seen := make(map[Position]bool, len(positions))
+	for _, pos := range board.Mines {
+		if !board.contains(pos) {
+			return Board{}, ErrOutOfBounds
+		} else if seen[pos] {
+			return Board{}, ErrDupPoint
+		}
+		seen[pos] = true
+	}
+	sort.Slice(board.Mines, func(i, j int) bool {
+		return less(board.Mines[i], board.Mines[j])
+	})
+	return board, nil
+}
+
+// Selector returns a selector that places the board's mines.
+func (b Board) Selector() Selector {
+	return func(width, height, max uint) ([]Position, error) {
+		return append([]Position(nil), b.Mines...), nil
+	}
+}
+
+// String returns the board as a grid of rows, with '*' for a mine, '.' for a
+// 0 and the number of any other block, or '+' if it is more than 9.
+func (b Board) String() string {
+	f := b.field()
+	var grid strings.Builder
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			switch n := f.numbers[y*b.Width+x]; {
+			case n < 0:
+				grid.WriteByte('*')
+			case n == 0:
+				grid.WriteByte('.')
+			case n <= 9:
+				grid.WriteByte('0' + byte(n))
+			default:
+				grid.WriteByte('+')
+			}
+		}
+		grid.WriteByte('\n')
+	}
+	return grid.String()
+}
+
+// contains returns true if the position is on the board.
+func (b Board) contains(pos Position) bool {
+	return pos.X >= 0 && pos.X < b.Width && pos.Y >= 0 && pos.Y < b.Height
+}
+
+// field is a board with the number of every block worked out.
+type field struct {
+	Board
+
+	// numbers holds the number of mines bordering each block by row, or -1
+	// for a mine.
+	numbers []int
+}
+
+// field counts the numbers of the board's blocks.
+func (b Board) field() *field {
+	if b.Neighborhood == nil {
+		b.Neighborhood = surrounding{}
+	}
+	f := &field{Board: b, numbers: make([]int, b.Width*b.Height)}
+	for _, mine := range b.Mines {
+		f.numbers[f.index(mine)] = -1
+	}
+	for i := range f.numbers {
+		if f.numbers[i] < 0 {
+			continue
+		}
+		for _, neighbor := range f.neighbors(Position{i % b.Width, i / b.Width}) {
+			if f.number(neighbor) < 0 {
+				f.numbers[i]++
+			}
+		}
+	}
+	return f
+}
+
+// index returns the index of the position in the numbers.
+func (f *field) index(pos Position) int {
+	return pos.Y*f.Width + pos.X
+}
+
+// number returns the number of the block at the position, or -1 for a mine.
+func (f *field) number(pos Position) int {
+	return f.numbers[f.index(pos)]
+}
+
+// neighbors returns the blocks bordering the position that are on the board.
+func (f *field) neighbors(pos Position) []Position {
+	var neighbors []Position
+	for _, neighbor := range f.Neighborhood.Neighbors(pos) {
+		if f.contains(neighbor) {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors
+}
+
+// less returns true if the position comes before the other when ordered by
+// row.
+func less(p, other Position) bool {
+	if p.Y != other.Y {
+		return p.Y < other.Y
+	}
+	return p.X < other.X
+}
@@ -0,0 +1,56 @@
+package minegen
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+// fixed returns a selector that places the mines.
+func fixed(mines ...Position) Selector {
+	return func(width, height, max uint) ([]Position, error) {
+		return mines, nil
+	}
+}
+
+// knight is the neighborhood of the blocks a knight's move away.
+type knight struct{}
+
+func (knight) Neighbors(pos Position) []Position {
+	return []Position{
+		{pos.X + 1, pos.Y + 2}, {pos.X + 2, pos.Y + 1}, {pos.X + 2, pos.Y - 1}, {pos.X + 1, pos.Y - 2},
+		{pos.X - 1, pos.Y - 2}, {pos.X - 2, pos.Y - 1}, {pos.X - 2, pos.Y + 1}, {pos.X - 1, pos.Y + 2},
+	}
+}
+
+func (s *GenSuite) TestGenerate(c *C) {
+	board, err := Generate(5, 4, 3, Seeded(7))
+	c.Assert(err, IsNil)
+	c.Check(board.Width, Equals, 5)
+	c.Check(board.Height, Equals, 4)
+	c.Check(board.Mines, HasLen, 3)
+	c.Check(validPlacement(board.Mines, 5, 4, 3), Equals, true)
+
+	// the board places the same mines again
+	again, err := Generate(5, 4, 3, board.Selector())
+	c.Assert(err, IsNil)
+	c.Check(again, DeepEquals, board)
+
+	_, err = Generate(2, 2, 4, Seeded(7))
+	c.Check(err, Equals, ErrExceedDimensions)
+	_, err = Generate(3, 3, 2, fixed(Position{0, 0}))
+	c.Check(err, Equals, ErrBadCount)
+	_, err = Generate(3, 3, 1, fixed(Position{3, 0}))
+	c.Check(err, Equals, ErrOutOfBounds)
+	_, err = Generate(3, 3, 2, fixed(Position{1, 1}, Position{1, 1}))
+	c.Check(err, Equals, ErrDupPoint)
+}
+
+func (s *GenSuite) TestBoardString(c *C) {
+	board, err := Generate(3, 3, 2, fixed(Position{2, 2}, Position{0, 0}))
+	c.Assert(err, IsNil)
+	c.Check(board.Mines, DeepEquals, []Position{{0, 0}, {2, 2}})
+	c.Check(board.String(), Equals, "*1.\n121\n.1*\n")
+
+	// the numbers count the mines of the neighborhood
+	board.Neighborhood = knight{}
+	c.Check(board.String(), Equals, "*1.\n1.1\n.1*\n")
+}
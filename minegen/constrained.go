@@ -0,0 +1,203 @@
+package minegen
+
+// placementAttempts is the number of shuffles Constrained tries before giving
+// up.
+const placementAttempts = 10
+
+// PlacementRule decides whether a mine may be placed at the position, given
+// the mines placed so far.
+type PlacementRule func(mines map[Position]bool, pos Position) bool
+
+// NoClusters forbids any 2x2 square made up entirely of mines.
+func NoClusters() PlacementRule {
+	return func(mines map[Position]bool, pos Position) bool {
+		for deltaX := -1; deltaX <= 0; deltaX++ {
+			for deltaY := -1; deltaY <= 0; deltaY++ {
+				corner := Position{pos.X + deltaX, pos.Y + deltaY}
+				full := true
+				for _, p := range []Position{corner, {corner.X + 1, corner.Y}, {corner.X, corner.Y + 1}, {corner.X + 1, corner.Y + 1}} {
+					if p != pos && !mines[p] {
+						full = false
+					}
+				}
+				if full {
+					return false
+				}
+			}
+		}
+		return true
+	}
+}
+
+// MaxPerRow allows at most n mines in any row.
+func MaxPerRow(n int) PlacementRule {
+	return func(mines map[Position]bool, pos Position) bool {
+		count := 0
+		for mine := range mines {
+			if mine.Y == pos.Y {
+				count++
+			}
+		}
+		return count < n
+	}
+}
+
+// MaxPerColumn allows at most n mines in any column.
+func MaxPerColumn(n int) PlacementRule {
+	return func(mines map[Position]bool, pos Position) bool {
+		count := 0
+		for mine := range mines {
+			if mine.X == pos.X {
+				count++
+			}
+		}
+		return count < n
+	}
+}
+
+// MinSpacing keeps mines at least d blocks apart in either direction, so that
+// d of 2 leaves every mine without a neighboring mine.
+func MinSpacing(d int) PlacementRule {
+	return func(mines map[Position]bool, pos Position) bool {
+		for mine := range mines {
+			if abs(mine.X-pos.X) < d && abs(mine.Y-pos.Y) < d {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// SafeAround keeps the position and the blocks surrounding it free of mines,
+// so that selecting it first opens an opening.
+func SafeAround(start Position) PlacementRule {
+	return func(mines map[Position]bool, pos Position) bool {
+		return abs(pos.X-start.X) > 1 || abs(pos.Y-start.Y) > 1
+	}
+}
+
+// Symmetry mirrors the mines of a board, so that every mine has a mine at each
+// of its images.
+type Symmetry int
+
+const (
+	// NoSymmetry places every mine on its own.
+	NoSymmetry Symmetry = iota
+
+	// Horizontal mirrors the mines from left to right.
+	Horizontal
+
+	// Vertical mirrors the mines from top to bottom.
+	Vertical
+
+	// Rotational turns the mines half way around the center of the board.
+	Rotational
+)
+
+// Images returns the position together with the distinct positions that
+// mirror it on a board of the size.
+func (s Symmetry) Images(width, height uint, pos Position) []Position {
+	var image Position
+	switch s {
+	case Horizontal:
+		image = Position{int(width) - 1 - pos.X, pos.Y}
+	case Vertical:
+		image = Position{pos.X, int(height) - 1 - pos.Y}
+	case Rotational:
+		image = Position{int(width) - 1 - pos.X, int(height) - 1 - pos.Y}
+	default:
+		return []Position{pos}
+	}
+	if image == pos {
+		return []Position{pos}
+	}
+	return []Position{pos, image}
+}
+
+// Constrained places the mines at random, as determined by the seed, while
+// following every rule.  It returns ErrUnplaceable if it cannot find such a
+// placement.
+func Constrained(seed int64, rules ...PlacementRule) Selector {
+	return func(width, height, max uint) ([]Position, error) {
+		return ConstrainedFrom(SeededSource(seed), rules...)(width, height, max)
+	}
+}
+
+// ConstrainedFrom places the mines like Constrained, drawing from the source
+// instead of a seed.
+func ConstrainedFrom(src RandSource, rules ...PlacementRule) Selector {
+	return SymmetricFrom(src, NoSymmetry, rules...)
+}
+
+// Symmetric places the mines like Constrained with the symmetry, so that every
+// image of a mine follows the rules too.  A board with no block that mirrors
+// itself can only hold an even number of mines.
+func Symmetric(seed int64, symmetry Symmetry, rules ...PlacementRule) Selector {
+	return func(width, height, max uint) ([]Position, error) {
+		return SymmetricFrom(SeededSource(seed), symmetry, rules...)(width, height, max)
+	}
+}
+
+// SymmetricFrom places the mines like Symmetric, drawing from the source
+// instead of a seed.
+func SymmetricFrom(src RandSource, symmetry Symmetry, rules ...PlacementRule) Selector {
+	return func(width, height, max uint) ([]Position, error) {
+		if width*height <= max {
+			return nil, ErrExceedDimensions
+		}
+
+		for attempt := 0; attempt < placementAttempts; attempt++ {
+			if points, ok := place(src, width, height, max, symmetry, rules); ok {
+				return points, nil
+			}
+		}
+		return nil, ErrUnplaceable
+	}
+}
+
+// place greedily places the mines in a random order together with their
+// images, skipping any position where a mine or one of its images would break
+// a rule or go over the number of mines.
+func place(src RandSource, width, height, max uint, symmetry Symmetry, rules []PlacementRule) ([]Position, bool) {
+	mines := make(map[Position]bool)
+	points := make([]Position, 0, max)
+	for _, i := range Perm(src, int(width*height)) {
+		if len(points) == int(max) {
+			break
+		}
+
+		pos := Position{i % int(width), i / int(width)}
+		images := symmetry.Images(width, height, pos)
+		if mines[pos] || len(points)+len(images) > int(max) {
+			continue
+		}
+		allowed := true
+		for j, image := range images {
+			for _, rule := range rules {
+				if !rule(mines, image) {
+					allowed = false
+					break
+				}
+			}
+			if !allowed {
+				for _, placed := range images[:j] {
+					delete(mines, placed)
+				}
+				break
+			}
+			mines[image] = true
+		}
+		if allowed {
+			points = append(points, images...)
+		}
+	}
+	return points, len(points) == int(max)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
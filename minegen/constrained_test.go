@@ -0,0 +1,83 @@
+package minegen
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *GenSuite) TestConstrained(c *C) {
+	// 2 per row and column on a 5x5 board leaves no room for 11 mines
+	_, err := Constrained(1, MaxPerRow(2), MaxPerColumn(2))(5, 5, 11)
+	c.Check(err, Equals, ErrUnplaceable)
+	_, err = Constrained(1)(2, 2, 5)
+	c.Check(err, Equals, ErrExceedDimensions)
+
+	points, err := Constrained(1, MaxPerRow(2), MaxPerColumn(2))(5, 5, 8)
+	c.Assert(err, IsNil)
+	c.Check(points, HasLen, 8)
+	rows, columns := make(map[int]int), make(map[int]int)
+	for _, point := range points {
+		rows[point.Y]++
+		columns[point.X]++
+	}
+	for i := 0; i < 5; i++ {
+		c.Check(rows[i] <= 2, Equals, true)
+		c.Check(columns[i] <= 2, Equals, true)
+	}
+
+	// composes with seeding
+	again, err := Constrained(1, MaxPerRow(2), MaxPerColumn(2))(5, 5, 8)
+	c.Assert(err, IsNil)
+	c.Check(again, DeepEquals, points)
+
+	points, err = Constrained(2, MinSpacing(2))(9, 9, 10)
+	c.Assert(err, IsNil)
+	for i, a := range points {
+		for _, b := range points[i+1:] {
+			c.Check(abs(a.X-b.X) >= 2 || abs(a.Y-b.Y) >= 2, Equals, true, Commentf("%v and %v", a, b))
+		}
+	}
+}
+
+func (s *GenSuite) TestNoClusters(c *C) {
+	rule := NoClusters()
+	mines := map[Position]bool{{0, 0}: true, {1, 0}: true, {0, 1}: true}
+	c.Check(rule(mines, Position{1, 1}), Equals, false)
+	c.Check(rule(mines, Position{2, 1}), Equals, true)
+	c.Check(rule(map[Position]bool{{1, 1}: true, {2, 1}: true, {2, 2}: true}, Position{1, 2}), Equals, false)
+}
+
+func (s *GenSuite) TestSymmetric(c *C) {
+	c.Check(Horizontal.Images(5, 4, Position{1, 2}), DeepEquals, []Position{{1, 2}, {3, 2}})
+	c.Check(Vertical.Images(5, 4, Position{1, 2}), DeepEquals, []Position{{1, 2}, {1, 1}})
+	c.Check(Rotational.Images(5, 4, Position{1, 2}), DeepEquals, []Position{{1, 2}, {3, 1}})
+	c.Check(Horizontal.Images(5, 4, Position{2, 2}), DeepEquals, []Position{{2, 2}})
+	c.Check(NoSymmetry.Images(5, 4, Position{1, 2}), DeepEquals, []Position{{1, 2}})
+
+	for _, symmetry := range []Symmetry{Horizontal, Vertical, Rotational} {
+		for _, mines := range []uint{10, 11} {
+			points, err := Symmetric(1, symmetry, NoClusters())(9, 9, mines)
+			c.Assert(err, IsNil)
+			c.Check(points, HasLen, int(mines))
+			placed := make(map[Position]bool)
+			for _, point := range points {
+				placed[point] = true
+			}
+			for _, point := range points {
+				for _, image := range symmetry.Images(9, 9, point) {
+					c.Check(placed[image], Equals, true, Commentf("%v of %v", image, point))
+				}
+			}
+		}
+	}
+
+	// an even board has no block on its axis to take an odd mine
+	_, err := Symmetric(1, Horizontal)(4, 4, 3)
+	c.Check(err, Equals, ErrUnplaceable)
+
+	// without symmetry it places the same mines as Constrained
+	want, err := Constrained(2, MinSpacing(2))(9, 9, 10)
+	c.Assert(err, IsNil)
+	got, err := Symmetric(2, NoSymmetry, MinSpacing(2))(9, 9, 10)
+	c.Assert(err, IsNil)
+	c.Check(got, DeepEquals, want)
+}
@@ -0,0 +1,74 @@
+// Package minegen generates minefields without playing them: placing mines at
+// random, from a seed or a RandSource, and by placement rules and symmetries,
+// searching for boards that can be cleared without guessing, and targeting a
+// band of difficulty.  It depends on nothing but the standard library and the
+// minesolve package, which it deduces with, so that puzzle sites and datasets
+// that only need boards do not pull in game, session or server code.
+//
+// Every generator is a Selector, so its boards can also be played:
+//
+//	selector := minegen.NoGuess(seed, minegen.Position{X: 4, Y: 4})
+//	board, err := minegen.Generate(9, 9, 10, selector)
+//	...
+//	cfg := gominesweeper.Config{Width: 9, Height: 9, Mines: 10, Selector: selector}
+//
+// The API of this package is stable: new generators may be added, but the
+// existing ones keep placing the same mines for the same seeds.
+package minegen
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrExceedDimensions = errors.New("size exceeds max dimensions")
+	ErrOutOfBounds      = errors.New("point is out of bounds")
+	ErrBadCount         = errors.New("points not equal to specification")
+	ErrDupPoint         = errors.New("duplicate point found")
+	ErrUnplaceable      = errors.New("mines cannot be placed within the rules")
+)
+
+// Position represents an point on the X,Y axis
+type Position struct {
+	X, Y int
+}
+
+// Selector is a custom mine selector that given a width, height, and max
+// will return a set of positions for placing mines.
+type Selector func(width, height, max uint) ([]Position, error)
+
+// Random places the mines at random.
+func Random(width, height, max uint) ([]Position, error) {
+	return Seeded(time.Now().UnixNano())(width, height, max)
+}
+
+// Seeded places the mines at random, in the same positions for the same seed
+// and dimensions.
+func Seeded(seed int64) Selector {
+	return func(width, height, max uint) ([]Position, error) {
+		return From(SeededSource(seed))(width, height, max)
+	}
+}
+
+// From places the mines at random, drawing from the source, so every
+// minefield it places takes new numbers from it.
+func From(src RandSource) Selector {
+	return func(width, height, max uint) ([]Position, error) {
+		size := width * height
+		if size <= max {
+			return nil, ErrExceedDimensions
+		}
+		scope := make([]uint, size)
+		for i := range scope {
+			scope[i] = uint(i)
+			j := src.Intn(i + 1)
+			scope[i], scope[j] = scope[j], scope[i]
+		}
+		points := make([]Position, max)
+		for i := range points {
+			points[i] = Position{int(scope[i] % width), int(scope[i] / width)}
+		}
+		return points, nil
+	}
+}
@@ -0,0 +1,30 @@
+package minegen
+
+import (
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+type GenSuite struct{}
+
+var _ = Suite(&GenSuite{})
+
+func (s *GenSuite) TestSeeded(c *C) {
+	points, err := Seeded(7)(5, 4, 3)
+	c.Assert(err, IsNil)
+	c.Check(validPlacement(points, 5, 4, 3), Equals, true)
+	again, err := Seeded(7)(5, 4, 3)
+	c.Assert(err, IsNil)
+	c.Check(again, DeepEquals, points)
+
+	points, err = Random(5, 4, 3)
+	c.Assert(err, IsNil)
+	c.Check(validPlacement(points, 5, 4, 3), Equals, true)
+
+	_, err = Seeded(7)(2, 2, 4)
+	c.Check(err, Equals, ErrExceedDimensions)
+}
@@ -0,0 +1,97 @@
+package minegen
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math"
+	"math/big"
+	"math/rand"
+)
+
+// RandSource is a source of random numbers for placing mines and breaking
+// ties.  A *rand.Rand from math/rand is a RandSource.
+type RandSource interface {
+	// Int63 returns a non-negative random number.
+	Int63() int64
+
+	// Intn returns a random number from 0 up to but not including n, which
+	// must be positive.
+	Intn(n int) int
+}
+
+// SeededSource returns a source that always gives the same numbers for the
+// same seed, as used by Seeded and the other seeded placements.
+func SeededSource(seed int64) RandSource {
+	return rand.New(rand.NewSource(seed))
+}
+
+// CryptoSource returns a source that draws from crypto/rand, so that boards
+// cannot be predicted from the ones before them, e.g. for tournaments.
+func CryptoSource() RandSource {
+	return cryptoSource{}
+}
+
+type cryptoSource struct{}
+
+func (cryptoSource) Int63() int64 {
+	var b [8]byte
+	crand.Read(b[:])
+	return int64(binary.BigEndian.Uint64(b[:]) & math.MaxInt64)
+}
+
+func (cryptoSource) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	v, err := crand.Int(crand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic(err)
+	}
+	return int(v.Int64())
+}
+
+// FixedSource returns a source that gives the numbers in turn, starting over
+// once they run out, so that tests and fuzzers can decide exactly where the
+// mines go.  Signs are dropped, and Intn gives each number modulo n.  Without
+// any numbers it always gives 0.
+func FixedSource(numbers ...int64) RandSource {
+	return &fixedSource{numbers: numbers}
+}
+
+type fixedSource struct {
+	numbers []int64
+	next    int
+}
+
+func (s *fixedSource) Int63() int64 {
+	if len(s.numbers) == 0 {
+		return 0
+	}
+	n := s.numbers[s.next%len(s.numbers)]
+	s.next++
+	if n == math.MinInt64 {
+		return math.MaxInt64
+	} else if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func (s *fixedSource) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	return int(s.Int63() % int64(n))
+}
+
+// Perm returns a random permutation of the numbers from 0 up to n, drawn the
+// same way as the Perm of math/rand so that seeded placements do not change.
+func Perm(src RandSource, n int) []int {
+	m := make([]int, n)
+	for i := 0; i < n; i++ {
+		j := src.Intn(i + 1)
+		m[i] = m[j]
+		m[j] = i
+	}
+	return m
+}
@@ -0,0 +1,94 @@
+package minegen
+
+import (
+	"math"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *GenSuite) TestFrom(c *C) {
+	// the seeded source places the same mines as Seeded
+	want, err := Seeded(42)(9, 9, 10)
+	c.Assert(err, IsNil)
+	got, err := From(SeededSource(42))(9, 9, 10)
+	c.Assert(err, IsNil)
+	c.Check(got, DeepEquals, want)
+
+	// a fixed source of zeros moves the last block to the front each time
+	points, err := From(FixedSource())(3, 3, 2)
+	c.Assert(err, IsNil)
+	c.Check(points, DeepEquals, []Position{{2, 2}, {0, 0}})
+
+	_, err = From(FixedSource())(2, 2, 5)
+	c.Check(err, Equals, ErrExceedDimensions)
+
+	// the crypto source places distinct mines within the minefield
+	points, err = From(CryptoSource())(9, 9, 10)
+	c.Assert(err, IsNil)
+	c.Check(validPlacement(points, 9, 9, 10), Equals, true)
+
+	points, err = ConstrainedFrom(CryptoSource(), MinSpacing(2))(9, 9, 10)
+	c.Assert(err, IsNil)
+	c.Check(validPlacement(points, 9, 9, 10), Equals, true)
+}
+
+func (s *GenSuite) TestFixedSource(c *C) {
+	src := FixedSource(3, -7, math.MinInt64)
+	c.Check(src.Int63(), Equals, int64(3))
+	c.Check(src.Int63(), Equals, int64(7))
+	c.Check(src.Int63(), Equals, int64(math.MaxInt64))
+	c.Check(src.Intn(2), Equals, 1)
+	c.Check(src.Intn(5), Equals, 2)
+
+	c.Check(FixedSource().Int63(), Equals, int64(0))
+	c.Check(func() { FixedSource(1).Intn(0) }, PanicMatches, "invalid argument to Intn")
+}
+
+func (s *GenSuite) TestCryptoSource(c *C) {
+	src := CryptoSource()
+	for i := 0; i < 100; i++ {
+		c.Check(src.Int63() >= 0, Equals, true)
+		n := src.Intn(3)
+		c.Check(n >= 0 && n < 3, Equals, true)
+	}
+}
+
+// validPlacement returns true if there are max distinct points within the
+// dimensions.
+func validPlacement(points []Position, width, height, max int) bool {
+	seen := make(map[Position]bool)
+	for _, point := range points {
+		if point.X < 0 || point.X >= width || point.Y < 0 || point.Y >= height || seen[point] {
+			return false
+		}
+		seen[point] = true
+	}
+	return len(points) == max
+}
+
+func FuzzFrom(f *testing.F) {
+	f.Add(uint8(9), uint8(9), uint8(10), int64(1), int64(-2))
+	f.Fuzz(func(t *testing.T, width, height, mines uint8, a, b int64) {
+		// every sequence of numbers places the mines within the minefield,
+		// and places them the same way again
+		w, h := uint(width%32)+1, uint(height%32)+1
+		max := uint(mines) % (w * h)
+		points, err := From(FixedSource(a, b))(w, h, max)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !validPlacement(points, int(w), int(h), int(max)) {
+			t.Fatalf("invalid placement %v on %dx%d", points, w, h)
+		}
+		again, _ := From(FixedSource(a, b))(w, h, max)
+		if len(again) != len(points) {
+			t.Fatalf("placed %v, then %v", points, again)
+		}
+		for i := range again {
+			if again[i] != points[i] {
+				t.Fatalf("placed %v, then %v", points, again)
+			}
+		}
+	})
+}
@@ -0,0 +1,278 @@
+package minegen
+
+import (
+	"sort"
+
+	"github.com/smousa/go-minesweeper/minesolve"
+)
+
+// guessWeight is how much 3BV a forced guess adds to a Difficulty's score.
+const guessWeight = 10
+
+// Difficulty rates how hard a board is to clear.
+type Difficulty struct {
+	// BBBV is the 3BV of the board, and Openings is the number of its
+	// connected groups of 0s.
+	BBBV, Openings int
+
+	// Guesses is the number of times the solver had to guess while clearing
+	// the board, counting the first selection unless it could open an
+	// opening.
+	Guesses int
+
+	// Frontier is the most hidden blocks bordering revealed numbers that the
+	// solver faced when it had to guess.
+	Frontier int
+
+	// Score combines the rest into a single rating, for comparing boards of
+	// the same size: the 3BV plus 10 for every guess.
+	Score int
+}
+
+// Rate rates the difficulty of clearing the board.  The solver starts in its
+// largest opening, deduces what it can with minesolve.Deduce, and always
+// guesses right among the blocks that are the least likely to be mines.
+func Rate(b Board) Difficulty {
+	f := b.field()
+	var start *Position
+	if openings := f.openings(); len(openings) > 0 {
+		largest := openings[0]
+		for _, opening := range openings {
+			if len(opening) > len(largest) {
+				largest = opening
+			}
+		}
+		start = &largest[0]
+	}
+	return f.rate(start)
+}
+
+// RateFrom rates the difficulty of clearing the board like Rate, but with the
+// solver starting at the given block, which must not be a mine.  The first
+// selection is not counted as a guess.
+func RateFrom(b Board, start Position) Difficulty {
+	f := b.field()
+	if !f.contains(start) || f.number(start) < 0 {
+		return f.rate(nil)
+	}
+	return f.rate(&start)
+}
+
+// rate rates the difficulty of clearing the board from the start, or from a
+// guess if there is none.
+func (f *field) rate(start *Position) Difficulty {
+	var d Difficulty
+	openings := f.openings()
+	d.BBBV, d.Openings = f.bbbv(openings), len(openings)
+
+	p := &play{field: f, revealed: make([]bool, len(f.numbers)), left: len(f.numbers) - len(f.Mines)}
+	if start == nil {
+		d.Guesses++
+		safest := p.safest(p.deduce())
+		start = &safest
+	}
+	p.reveal(*start)
+
+	for p.left > 0 {
+		solution := p.deduce()
+		safe := solution.Safe
+		if len(safe) == 0 {
+			d.Guesses++
+			d.Frontier = max(d.Frontier, frontier(solution))
+			safest := p.safest(solution)
+			safe = []minesolve.Cell{{X: safest.X, Y: safest.Y}}
+		}
+		for _, c := range safe {
+			p.reveal(Position{c.X, c.Y})
+		}
+	}
+	d.Score = d.BBBV + guessWeight*d.Guesses
+	return d
+}
+
+// openings returns the connected groups of 0s on the board, each ordered by
+// row and ordered by their first position.
+func (f *field) openings() [][]Position {
+	var openings [][]Position
+	seen := make([]bool, len(f.numbers))
+	for i, n := range f.numbers {
+		if n != 0 || seen[i] {
+			continue
+		}
+		seen[i] = true
+		opening := []Position{{i % f.Width, i / f.Width}}
+		for j := 0; j < len(opening); j++ {
+			for _, neighbor := range f.neighbors(opening[j]) {
+				if k := f.index(neighbor); f.numbers[k] == 0 && !seen[k] {
+					seen[k] = true
+					opening = append(opening, neighbor)
+				}
+			}
+		}
+		sort.Slice(opening, func(a, b int) bool {
+			return less(opening[a], opening[b])
+		})
+		openings = append(openings, opening)
+	}
+	sort.Slice(openings, func(a, b int) bool {
+		return less(openings[a][0], openings[b][0])
+	})
+	return openings
+}
+
+// bbbv returns the 3BV of the board with the openings, which is the least
+// number of selections needed to clear it: each opening counts once and so
+// does every number that does not border an opening.
+func (f *field) bbbv(openings [][]Position) int {
+	total := len(openings)
+	for i, n := range f.numbers {
+		if n > 0 && !f.bordersOpening(Position{i % f.Width, i / f.Width}) {
+			total++
+		}
+	}
+	return total
+}
+
+// bordersOpening returns true if the position neighbors a 0.
+func (f *field) bordersOpening(pos Position) bool {
+	for _, neighbor := range f.neighbors(pos) {
+		if f.number(neighbor) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// play is a board being cleared by the solver.
+type play struct {
+	*field
+	revealed []bool
+
+	// left is the number of blocks without mines still hidden
+	left int
+}
+
+// reveal reveals the block at the position, along with the blocks around it
+// if it is a 0.
+func (p *play) reveal(pos Position) {
+	stack := []Position{pos}
+	for len(stack) > 0 {
+		pos, stack = stack[len(stack)-1], stack[:len(stack)-1]
+		i := p.index(pos)
+		if p.revealed[i] || p.numbers[i] < 0 {
+			continue
+		}
+		p.revealed[i] = true
+		p.left--
+		if p.numbers[i] == 0 {
+			stack = append(stack, p.neighbors(pos)...)
+		}
+	}
+}
+
+// deduce works out what it can of the revealed numbers, taken by row so that
+// they are always deduced the same way.  Nothing is deduced if they
+// contradict each other.
+func (p *play) deduce() minesolve.Solution {
+	problem := minesolve.Problem{Mines: -1}
+	for i, n := range p.numbers {
+		if !p.revealed[i] {
+			continue
+		}
+		c := minesolve.Constraint{Mines: n}
+		for _, neighbor := range p.neighbors(Position{i % p.Width, i / p.Width}) {
+			if !p.revealed[p.index(neighbor)] {
+				c.Cells = append(c.Cells, minesolve.Cell{X: neighbor.X, Y: neighbor.Y})
+			}
+		}
+		if len(c.Cells) > 0 {
+			problem.Constraints = append(problem.Constraints, c)
+		}
+	}
+	solution, err := minesolve.Deduce(problem)
+	if err != nil {
+		return minesolve.Solution{Constraints: problem.Constraints}
+	}
+	return solution
+}
+
+// safest returns the hidden block without a mine that is the least likely to
+// be a mine after the deduction, first by row.
+func (p *play) safest(solution minesolve.Solution) Position {
+	var best Position
+	bestOdds := 2.0
+	probabilities := p.probabilities(solution)
+	for i, n := range p.numbers {
+		if p.revealed[i] || n < 0 {
+			continue
+		}
+		pos := Position{i % p.Width, i / p.Width}
+		if odds := probabilities[minesolve.Cell{X: pos.X, Y: pos.Y}]; odds < bestOdds {
+			best, bestOdds = pos, odds
+		}
+	}
+	return best
+}
+
+// probabilities returns the odds of every hidden block being a mine, counting
+// every placement of the remaining mines as equally likely.  If they are too
+// entangled to count, the odds of a block are the worst of the constraints
+// around it, or the share of the mines left among the hidden blocks.
+func (p *play) probabilities(solution minesolve.Solution) map[minesolve.Cell]float64 {
+	odds := make(map[minesolve.Cell]float64)
+	for _, c := range solution.Safe {
+		odds[c] = 0
+	}
+	for _, c := range solution.Mines {
+		odds[c] = 1
+	}
+	problem := minesolve.Problem{Mines: len(p.Mines) - len(solution.Mines), Constraints: solution.Constraints}
+	for i := range p.numbers {
+		c := minesolve.Cell{X: i % p.Width, Y: i / p.Width}
+		if _, deduced := odds[c]; !p.revealed[i] && !deduced {
+			problem.Unknown = append(problem.Unknown, c)
+		}
+	}
+
+	counted, err := minesolve.Solve(problem)
+	for _, c := range problem.Unknown {
+		if err == nil && counted.Exact {
+			odds[c] = counted.Probabilities[c]
+		} else {
+			odds[c] = risk(problem, c)
+		}
+	}
+	return odds
+}
+
+// risk returns the worst odds of the constraints around the cell, or the share
+// of the mines among the unknown cells if no constraint covers it.
+func risk(problem minesolve.Problem, cell minesolve.Cell) float64 {
+	worst, bordered := 0.0, false
+	for _, c := range problem.Constraints {
+		for _, other := range c.Cells {
+			if other == cell {
+				bordered = true
+				worst = max(worst, float64(c.Mines)/float64(len(c.Cells)))
+			}
+		}
+	}
+	if bordered {
+		return worst
+	} else if len(problem.Unknown) == 0 {
+		return 1
+	}
+	return float64(problem.Mines) / float64(len(problem.Unknown))
+}
+
+// frontier returns the number of hidden cells that the solution's
+// constraints cover.
+func frontier(solution minesolve.Solution) int {
+	bordered := make(map[minesolve.Cell]bool)
+	for _, c := range solution.Constraints {
+		for _, cell := range c.Cells {
+			bordered[cell] = true
+		}
+	}
+	return len(bordered)
+}
@@ -0,0 +1,32 @@
+package minegen
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *GenSuite) TestRate(c *C) {
+	// the 5x5 board of the core's tests, with the larger opening in the
+	// bottom left corner
+	board, err := Generate(5, 5, 5, fixed(Position{0, 0}, Position{4, 0}, Position{2, 1}, Position{1, 2}, Position{3, 4}))
+	c.Assert(err, IsNil)
+	c.Check(Rate(board), DeepEquals, Difficulty{BBBV: 10, Openings: 2, Guesses: 3, Frontier: 14, Score: 40})
+
+	// a board that is one big opening needs no guesses
+	board, err = Generate(4, 4, 1, fixed(Position{3, 3}))
+	c.Assert(err, IsNil)
+	c.Check(Rate(board), DeepEquals, Difficulty{BBBV: 1, Openings: 1, Score: 1})
+}
+
+func (s *GenSuite) TestRateFrom(c *C) {
+	board, err := Generate(4, 4, 1, fixed(Position{3, 3}))
+	c.Assert(err, IsNil)
+
+	// the opening clears everything, but the number next to the mine
+	// leaves the solver guessing
+	c.Check(RateFrom(board, Position{0, 0}).Guesses, Equals, 0)
+	c.Check(RateFrom(board, Position{3, 2}).Guesses > 0, Equals, true)
+
+	// starting on a mine or out of bounds starts with a guess instead
+	c.Check(RateFrom(board, Position{3, 3}).Guesses, Equals, 1)
+	c.Check(RateFrom(board, Position{9, 9}).Guesses, Equals, 1)
+}
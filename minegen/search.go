@@ -0,0 +1,88 @@
+package minegen
+
+// maxAttempts is the number of boards a search tries before giving up.
+const maxAttempts = 1000
+
+// NoGuess places the mines so that the board can be cleared from the start
+// position by deduction alone, trying boards seeded from the seed until one
+// can.  It returns ErrUnplaceable if none of them can.
+func NoGuess(seed int64, start Position, rules ...PlacementRule) Selector {
+	return noGuess(seeded(seed), start, NoSymmetry, rules)
+}
+
+// NoGuessSymmetric places the mines like NoGuess, mirrored with the symmetry.
+// The images of the start are kept safe as well.
+func NoGuessSymmetric(seed int64, start Position, symmetry Symmetry, rules ...PlacementRule) Selector {
+	return noGuess(seeded(seed), start, symmetry, rules)
+}
+
+// NoGuessFrom places the mines like NoGuess, with the seeds of the boards it
+// tries drawn from the source.
+func NoGuessFrom(src RandSource, start Position, rules ...PlacementRule) Selector {
+	return noGuess(func() RandSource { return src }, start, NoSymmetry, rules)
+}
+
+// noGuess searches for a board without guesses from the start.
+func noGuess(rand func() RandSource, start Position, symmetry Symmetry, rules []PlacementRule) Selector {
+	rules = append([]PlacementRule{SafeAround(start)}, rules...)
+	return search(rand, func(seed int64) Selector { return Symmetric(seed, symmetry, rules...) }, func(b Board) bool {
+		return RateFrom(b, start).Guesses == 0
+	})
+}
+
+// Band is a range of difficulty scores, including both ends.
+type Band struct {
+	Min, Max int
+}
+
+// Contains returns true if the difficulty's score is within the band.
+func (b Band) Contains(d Difficulty) bool {
+	return d.Score >= b.Min && d.Score <= b.Max
+}
+
+// Targeted places the mines with the selectors the source makes from seeds
+// drawn from the seed, until the board's difficulty is within the band.  It
+// returns ErrUnplaceable if none of the boards tried are.
+func Targeted(seed int64, band Band, source func(seed int64) Selector) Selector {
+	return targeted(seeded(seed), band, source)
+}
+
+// TargetedFrom places the mines like Targeted, with the seeds of the boards
+// it tries drawn from the source.
+func TargetedFrom(src RandSource, band Band, source func(seed int64) Selector) Selector {
+	return targeted(func() RandSource { return src }, band, source)
+}
+
+// targeted searches for a board within the band.
+func targeted(rand func() RandSource, band Band, source func(seed int64) Selector) Selector {
+	return search(rand, source, func(b Board) bool {
+		return band.Contains(Rate(b))
+	})
+}
+
+// seeded returns a function that starts the seeded source over each time, so
+// that a search places the same mines every time.
+func seeded(seed int64) func() RandSource {
+	return func() RandSource { return SeededSource(seed) }
+}
+
+// search returns a selector that tries the boards of the source, with seeds
+// drawn from the random source it is given for each board, until one is
+// accepted.
+func search(rand func() RandSource, source func(seed int64) Selector, accept func(Board) bool) Selector {
+	return func(width, height, max uint) ([]Position, error) {
+		r := rand()
+		for attempt := 0; attempt < maxAttempts; attempt++ {
+			board, err := Generate(width, height, max, source(r.Int63()))
+			if err == ErrUnplaceable {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			if accept(board) {
+				return board.Mines, nil
+			}
+		}
+		return nil, ErrUnplaceable
+	}
+}
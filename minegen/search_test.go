@@ -0,0 +1,54 @@
+package minegen
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *GenSuite) TestNoGuess(c *C) {
+	start := Position{4, 4}
+	for seed := int64(0); seed < 5; seed++ {
+		board, err := Generate(9, 9, 10, NoGuess(seed, start))
+		c.Assert(err, IsNil)
+		c.Check(RateFrom(board, start).Guesses, Equals, 0, Commentf("seed %d", seed))
+
+		// the start opens an opening
+		c.Check(board.field().number(start), Equals, 0)
+	}
+
+	// a board too full to open cannot be found
+	_, err := Generate(3, 3, 1, NoGuess(0, Position{1, 1}))
+	c.Check(err, Equals, ErrUnplaceable)
+
+	// a crypto source still finds a board that needs no guesses
+	board, err := Generate(9, 9, 10, NoGuessFrom(CryptoSource(), start))
+	c.Assert(err, IsNil)
+	c.Check(RateFrom(board, start).Guesses, Equals, 0)
+}
+
+func (s *GenSuite) TestNoGuessSymmetric(c *C) {
+	start := Position{4, 4}
+	board, err := Generate(9, 9, 10, NoGuessSymmetric(1, start, Rotational))
+	c.Assert(err, IsNil)
+	c.Check(RateFrom(board, start).Guesses, Equals, 0)
+
+	// the mines are the same turned upside down
+	f := board.field()
+	for _, mine := range board.Mines {
+		c.Check(f.number(Position{8 - mine.X, 8 - mine.Y}), Equals, -1)
+	}
+}
+
+func (s *GenSuite) TestTargeted(c *C) {
+	band := Band{Min: 20, Max: 30}
+	board, err := Generate(9, 9, 10, Targeted(3, band, Seeded))
+	c.Assert(err, IsNil)
+	c.Check(band.Contains(Rate(board)), Equals, true)
+
+	_, err = Generate(3, 3, 1, Targeted(3, Band{Min: 1000, Max: 2000}, Seeded))
+	c.Check(err, Equals, ErrUnplaceable)
+
+	// the seeded source tries the same boards as the seed
+	got, err := Generate(9, 9, 10, TargetedFrom(SeededSource(3), band, Seeded))
+	c.Assert(err, IsNil)
+	c.Check(got, DeepEquals, board)
+}
@@ -0,0 +1,66 @@
+package minesolve
+
+// The values of a BoardView's cells that are not revealed numbers.
+const (
+	Hidden  = -1
+	Flagged = -2
+)
+
+// BoardView is a board on a grid as a player sees it, where each block
+// borders the 8 that surround it.
+type BoardView struct {
+	// Mines is the number of mines on the whole board, or -1 if it is not
+	// known.
+	Mines int
+
+	// Cells holds the rows of the board from top to bottom.  A cell is
+	// Hidden, Flagged or the number of mines around it when revealed.
+	Cells [][]int
+}
+
+// Problem returns the problem of solving the board.  Flags are taken to be
+// on mines.
+func (b BoardView) Problem() Problem {
+	p := Problem{Mines: b.Mines}
+	for y, row := range b.Cells {
+		for x, value := range row {
+			switch value {
+			case Hidden:
+				p.Unknown = append(p.Unknown, Cell{x, y})
+			case Flagged:
+				if p.Mines > 0 {
+					p.Mines--
+				}
+			default:
+				c := Constraint{Mines: value}
+				for _, neighbor := range b.neighbors(Cell{x, y}) {
+					switch b.Cells[neighbor.Y][neighbor.X] {
+					case Hidden:
+						c.Cells = append(c.Cells, neighbor)
+					case Flagged:
+						c.Mines--
+					}
+				}
+				if len(c.Cells) > 0 || c.Mines != 0 {
+					p.Constraints = append(p.Constraints, c)
+				}
+			}
+		}
+	}
+	return p
+}
+
+// neighbors returns the cells around the cell that are on the board.
+func (b BoardView) neighbors(cell Cell) []Cell {
+	var neighbors []Cell
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			x, y := cell.X+dx, cell.Y+dy
+			if dx == 0 && dy == 0 || y < 0 || y >= len(b.Cells) || x < 0 || x >= len(b.Cells[y]) {
+				continue
+			}
+			neighbors = append(neighbors, Cell{x, y})
+		}
+	}
+	return neighbors
+}
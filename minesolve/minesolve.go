@@ -0,0 +1,322 @@
+// Package minesolve works out which hidden blocks of a minesweeper board are
+// safe, which are mines and how likely the rest are to be mines.  It knows
+// nothing of how the board came about, so it can solve boards from anywhere:
+// a BoardView read off a screen, or the constraints of a board with unusual
+// neighborhoods.
+//
+//	solution, err := minesolve.Solve(minesolve.BoardView{
+//		Mines: 10,
+//		Cells: [][]int{...},
+//	}.Problem())
+package minesolve
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// maxSteps is the most steps Solve takes to count the placements of mines
+// before settling for odds that are not exact.
+const maxSteps = 1 << 22
+
+// certain is how close odds must be to 0 or 1 to be taken as certain.
+const certain = 1e-12
+
+var ErrInconsistent = errors.New("no placement of mines agrees with the constraints")
+
+// Cell is the position of a block.
+type Cell struct {
+	X, Y int
+}
+
+// less returns true if the cell comes before the other when ordered by row.
+func (c Cell) less(other Cell) bool {
+	if c.Y != other.Y {
+		return c.Y < other.Y
+	}
+	return c.X < other.X
+}
+
+// Constraint is a set of hidden cells that hold a number of mines, such as
+// the hidden neighbors of a revealed number less the flags around it.
+type Constraint struct {
+	Cells []Cell
+	Mines int
+}
+
+// Problem is what is known of a board.
+type Problem struct {
+	// Unknown are every hidden cell that may or may not be a mine, whether
+	// or not a constraint covers it.
+	Unknown []Cell
+
+	Constraints []Constraint
+
+	// Mines is the number of mines among the unknown cells, or -1 if it is
+	// not known.  Without it the cells that no constraint covers are left
+	// without odds, and the rest are weighed as if mines were plentiful.
+	Mines int
+}
+
+// Solution is what can be worked out of a problem.
+type Solution struct {
+	// Safe and Mines are the cells known to be safe and known to be mines,
+	// ordered by row.
+	Safe, Mines []Cell
+
+	// Probabilities holds the odds of each unknown cell being a mine.
+	Probabilities map[Cell]float64
+
+	// Exact is true if the odds count every placement of the mines as
+	// equally likely.  When there are too many placements to count, the
+	// odds are the worst of the constraints covering each cell instead.
+	Exact bool
+
+	// Constraints are what is left of the problem's constraints once the
+	// cells deduced to be safe or mines are taken out of them.
+	Constraints []Constraint
+}
+
+// constraint is a constraint on the cells that are not yet known.
+type constraint struct {
+	cells map[Cell]bool
+	mines int
+}
+
+// solver holds what is known while solving.
+type solver struct {
+	safe, mines map[Cell]bool
+	constraints []constraint
+}
+
+// Solve works out the problem, returning ErrInconsistent if its constraints
+// cannot all be met.
+func Solve(p Problem) (Solution, error) {
+	s, err := newSolver(p)
+	if err != nil {
+		return Solution{}, err
+	}
+
+	unknown := append([]Cell(nil), p.Unknown...)
+	sortCells(unknown)
+	mines := p.Mines
+	if mines >= 0 {
+		mines -= len(s.mines)
+	}
+	solution := Solution{
+		Probabilities: make(map[Cell]float64, len(unknown)),
+		Exact:         true,
+		Constraints:   s.remaining(),
+	}
+	if components, ok := s.components(); ok {
+		if err := weigh(components, s.free(unknown), mines, solution.Probabilities); err != nil {
+			return Solution{}, err
+		}
+	} else {
+		solution.Exact = false
+		s.estimate(s.free(unknown), mines, solution.Probabilities)
+	}
+
+	for _, cell := range unknown {
+		switch odds, ok := solution.Probabilities[cell]; {
+		case s.safe[cell] || ok && odds < certain:
+			solution.Probabilities[cell] = 0
+			solution.Safe = append(solution.Safe, cell)
+		case s.mines[cell] || ok && odds > 1-certain:
+			solution.Probabilities[cell] = 1
+			solution.Mines = append(solution.Mines, cell)
+		}
+	}
+	return solution, nil
+}
+
+// Deduce works out only what follows from the constraints one or two at a
+// time, without counting the placements of mines or looking at the number of
+// mines, which is much quicker than Solve.  Its solution has no odds.  It
+// returns ErrInconsistent if the constraints cannot all be met.
+func Deduce(p Problem) (Solution, error) {
+	s, err := newSolver(p)
+	if err != nil {
+		return Solution{}, err
+	}
+	return Solution{Safe: sortedKeys(s.safe), Mines: sortedKeys(s.mines), Constraints: s.remaining()}, nil
+}
+
+// newSolver deduces as much of the problem as it can.
+func newSolver(p Problem) (*solver, error) {
+	s := &solver{safe: make(map[Cell]bool), mines: make(map[Cell]bool)}
+	for _, c := range p.Constraints {
+		cells := make(map[Cell]bool, len(c.Cells))
+		for _, cell := range c.Cells {
+			cells[cell] = true
+		}
+		s.constraints = append(s.constraints, constraint{cells, c.Mines})
+	}
+	for {
+		learned, err := s.deduce()
+		if err != nil {
+			return nil, err
+		} else if !learned {
+			return s, nil
+		}
+	}
+}
+
+// remaining returns the constraints that are left, with their cells ordered
+// by row.
+func (s *solver) remaining() []Constraint {
+	constraints := make([]Constraint, len(s.constraints))
+	for i, c := range s.constraints {
+		constraints[i] = Constraint{Cells: sortedKeys(c.cells), Mines: c.mines}
+	}
+	return constraints
+}
+
+// deduce settles the constraints that are all mines or all safe, along with
+// the differences between constraints that contain others, and returns true
+// if anything new was learned.
+func (s *solver) deduce() (bool, error) {
+	// drop the cells that are known from every constraint
+	constraints := s.constraints[:0]
+	for _, c := range s.constraints {
+		for cell := range c.cells {
+			if s.mines[cell] {
+				c.mines--
+				delete(c.cells, cell)
+			} else if s.safe[cell] {
+				delete(c.cells, cell)
+			}
+		}
+		if c.mines < 0 || c.mines > len(c.cells) {
+			return false, ErrInconsistent
+		} else if len(c.cells) > 0 {
+			constraints = append(constraints, c)
+		}
+	}
+	s.constraints = constraints
+
+	learned := false
+	for _, c := range s.constraints {
+		learned = s.settle(c) || learned
+	}
+	if learned {
+		return true, nil
+	}
+
+	for _, a := range s.constraints {
+		for _, b := range s.constraints {
+			if len(a.cells) >= len(b.cells) || !contains(b.cells, a.cells) {
+				continue
+			}
+			diff := constraint{cells: make(map[Cell]bool), mines: b.mines - a.mines}
+			for cell := range b.cells {
+				if !a.cells[cell] {
+					diff.cells[cell] = true
+				}
+			}
+			if diff.mines < 0 || diff.mines > len(diff.cells) {
+				return false, ErrInconsistent
+			}
+			learned = s.settle(diff) || learned
+		}
+	}
+	return learned, nil
+}
+
+// settle marks every cell of the constraint as safe when it holds no mines,
+// or as a mine when it is full of them.
+func (s *solver) settle(c constraint) bool {
+	var into map[Cell]bool
+	switch c.mines {
+	case 0:
+		into = s.safe
+	case len(c.cells):
+		into = s.mines
+	default:
+		return false
+	}
+
+	learned := false
+	for cell := range c.cells {
+		if !into[cell] {
+			into[cell] = true
+			learned = true
+		}
+	}
+	return learned
+}
+
+// free returns the unknown cells that no constraint covers and that are not
+// known.
+func (s *solver) free(unknown []Cell) []Cell {
+	covered := make(map[Cell]bool)
+	for _, c := range s.constraints {
+		for cell := range c.cells {
+			covered[cell] = true
+		}
+	}
+	var free []Cell
+	for _, cell := range unknown {
+		if !covered[cell] && !s.safe[cell] && !s.mines[cell] {
+			free = append(free, cell)
+		}
+	}
+	return free
+}
+
+// estimate sets the odds of each cell to the worst of the constraints that
+// cover it, and of the free cells to the mines left over among them.
+func (s *solver) estimate(free []Cell, mines int, probabilities map[Cell]float64) {
+	covered := 0
+	for _, c := range s.constraints {
+		odds := float64(c.mines) / float64(len(c.cells))
+		for cell := range c.cells {
+			if old, ok := probabilities[cell]; !ok {
+				covered++
+				probabilities[cell] = odds
+			} else if odds > old {
+				probabilities[cell] = odds
+			}
+		}
+	}
+	if mines < 0 || len(free) == 0 {
+		return
+	}
+	// the constraints are expected to hold their share of the mines
+	left := float64(mines)
+	for _, c := range s.constraints {
+		left -= float64(c.mines) * float64(len(c.cells)) / float64(covered)
+	}
+	odds := math.Min(math.Max(left/float64(len(free)), 0), 1)
+	for _, cell := range free {
+		probabilities[cell] = odds
+	}
+}
+
+// contains returns true if every cell of the subset is in the set.
+func contains(set, subset map[Cell]bool) bool {
+	for cell := range subset {
+		if !set[cell] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedKeys returns the cells of the set, ordered by row.
+func sortedKeys(set map[Cell]bool) []Cell {
+	cells := make([]Cell, 0, len(set))
+	for cell := range set {
+		cells = append(cells, cell)
+	}
+	sortCells(cells)
+	return cells
+}
+
+// sortCells orders the cells by row.
+func sortCells(cells []Cell) {
+	sort.Slice(cells, func(i, j int) bool {
+		return cells[i].less(cells[j])
+	})
+}
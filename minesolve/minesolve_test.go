@@ -0,0 +1,103 @@
+package minesolve
+
+import (
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type SolveSuite struct{}
+
+var _ = Suite(&SolveSuite{})
+
+func (s *SolveSuite) TestSolve_Board(c *C) {
+	solution, err := Solve(BoardView{
+		Mines: 1,
+		Cells: [][]int{
+			{Hidden, Hidden, Hidden},
+			{1, 1, 1},
+		},
+	}.Problem())
+	c.Assert(err, IsNil)
+	c.Check(solution.Exact, Equals, true)
+	c.Check(solution.Safe, DeepEquals, []Cell{{X: 0, Y: 0}, {X: 2, Y: 0}})
+	c.Check(solution.Mines, DeepEquals, []Cell{{X: 1, Y: 0}})
+	c.Check(solution.Probabilities, DeepEquals, map[Cell]float64{
+		{X: 0, Y: 0}: 0,
+		{X: 1, Y: 0}: 1,
+		{X: 2, Y: 0}: 0,
+	})
+
+	// flags are taken to be on mines
+	solution, err = Solve(BoardView{
+		Mines: 2,
+		Cells: [][]int{
+			{Flagged, Hidden, Hidden},
+			{2, 2, 1},
+		},
+	}.Problem())
+	c.Assert(err, IsNil)
+	c.Check(solution.Safe, DeepEquals, []Cell{{X: 2, Y: 0}})
+	c.Check(solution.Mines, DeepEquals, []Cell{{X: 1, Y: 0}})
+
+	_, err = Solve(BoardView{Mines: 1, Cells: [][]int{{0, Flagged}}}.Problem())
+	c.Check(err, Equals, ErrInconsistent)
+}
+
+func (s *SolveSuite) TestSolve_Probabilities(c *C) {
+	a, b := Cell{X: 0, Y: 0}, Cell{X: 1, Y: 0}
+	free := []Cell{{X: 5, Y: 5}, {X: 6, Y: 5}, {X: 7, Y: 5}}
+	problem := Problem{
+		Unknown:     append([]Cell{a, b}, free...),
+		Constraints: []Constraint{{Cells: []Cell{a, b}, Mines: 1}},
+		Mines:       2,
+	}
+	solution, err := Solve(problem)
+	c.Assert(err, IsNil)
+	c.Check(solution.Exact, Equals, true)
+	c.Check(solution.Safe, HasLen, 0)
+	c.Check(solution.Mines, HasLen, 0)
+	c.Check(solution.Probabilities[a], Equals, 0.5)
+	c.Check(solution.Probabilities[b], Equals, 0.5)
+	for _, cell := range free {
+		c.Check(solution.Probabilities[cell], Equals, 1.0/3)
+	}
+
+	// without the number of mines the free cells have no odds
+	problem.Mines = -1
+	solution, err = Solve(problem)
+	c.Assert(err, IsNil)
+	c.Check(solution.Probabilities, HasLen, 2)
+	c.Check(solution.Probabilities[a], Equals, 0.5)
+
+	// there are not enough mines for the constraint
+	problem.Mines = 0
+	_, err = Solve(problem)
+	c.Check(err, Equals, ErrInconsistent)
+}
+
+func (s *SolveSuite) TestDeduce(c *C) {
+	a, b, d := Cell{X: 0, Y: 0}, Cell{X: 1, Y: 0}, Cell{X: 2, Y: 0}
+	solution, err := Deduce(Problem{
+		Constraints: []Constraint{
+			{Cells: []Cell{a, b}, Mines: 1},
+			{Cells: []Cell{a, b, d}, Mines: 2},
+			{Cells: []Cell{b, {X: 3, Y: 0}}, Mines: 1},
+		},
+		Mines: -1,
+	})
+	c.Assert(err, IsNil)
+	c.Check(solution.Safe, HasLen, 0)
+	c.Check(solution.Mines, DeepEquals, []Cell{d})
+	c.Check(solution.Probabilities, IsNil)
+	c.Check(solution.Constraints, DeepEquals, []Constraint{
+		{Cells: []Cell{a, b}, Mines: 1},
+		{Cells: []Cell{a, b}, Mines: 1},
+		{Cells: []Cell{b, {X: 3, Y: 0}}, Mines: 1},
+	})
+
+	_, err = Deduce(Problem{Constraints: []Constraint{{Cells: []Cell{a}, Mines: 2}}})
+	c.Check(err, Equals, ErrInconsistent)
+}
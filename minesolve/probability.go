@@ -0,0 +1,240 @@
+package minesolve
+
+import (
+	"math"
+)
+
+// component is a group of cells covered by constraints that depend on each
+// other, with the ways mines can be placed among them.
+type component struct {
+	cells       []Cell
+	constraints []constraint
+
+	// ways counts the placements of each number of mines, and hits counts
+	// for each number of mines the placements with a mine on each cell.
+	ways []float64
+	hits [][]float64
+}
+
+// components splits the constraints into groups that share no cells, and
+// counts the placements of mines in each.  It returns false if there are too
+// many placements to count.
+func (s *solver) components() ([]*component, bool) {
+	var components []*component
+	owner := make(map[Cell]*component)
+	for _, c := range s.constraints {
+		var into *component
+		for cell := range c.cells {
+			other := owner[cell]
+			if other == nil || other == into {
+				continue
+			} else if into == nil {
+				into = other
+				continue
+			}
+			// merge the other component into this one
+			into.cells = append(into.cells, other.cells...)
+			into.constraints = append(into.constraints, other.constraints...)
+			for _, cell := range other.cells {
+				owner[cell] = into
+			}
+			other.cells = nil
+		}
+		if into == nil {
+			into = &component{}
+			components = append(components, into)
+		}
+		into.constraints = append(into.constraints, c)
+		for cell := range c.cells {
+			if owner[cell] == nil {
+				owner[cell] = into
+				into.cells = append(into.cells, cell)
+			}
+		}
+	}
+
+	merged, steps := components[:0], maxSteps
+	for _, comp := range components {
+		if comp.cells == nil {
+			continue
+		}
+		sortCells(comp.cells)
+		if steps = comp.count(steps); steps < 0 {
+			return nil, false
+		}
+		merged = append(merged, comp)
+	}
+	return merged, true
+}
+
+// count enumerates every placement of mines among the component's cells that
+// agrees with its constraints, within the steps, and returns the steps left or
+// -1 if it ran out.
+func (comp *component) count(steps int) int {
+	n := len(comp.cells)
+	comp.ways = make([]float64, n+1)
+	comp.hits = make([][]float64, n+1)
+	for k := range comp.hits {
+		comp.hits[k] = make([]float64, n)
+	}
+
+	index := make(map[Cell]int, n)
+	for i, cell := range comp.cells {
+		index[cell] = i
+	}
+	// need and open are the mines each constraint still needs and the cells
+	// it has that are still open
+	need := make([]int, len(comp.constraints))
+	open := make([]int, len(comp.constraints))
+	of := make([][]int, n)
+	for j, c := range comp.constraints {
+		need[j], open[j] = c.mines, len(c.cells)
+		for cell := range c.cells {
+			of[index[cell]] = append(of[index[cell]], j)
+		}
+	}
+
+	placed := make([]bool, n)
+	var place func(i, mines int)
+	place = func(i, mines int) {
+		if steps--; steps < 0 {
+			return
+		} else if i == n {
+			comp.ways[mines]++
+			for p, mine := range placed {
+				if mine {
+					comp.hits[mines][p]++
+				}
+			}
+			return
+		}
+		for _, mine := range []bool{false, true} {
+			fits := true
+			for _, j := range of[i] {
+				if mine && need[j] == 0 || !mine && need[j] == open[j] {
+					fits = false
+				}
+			}
+			if !fits {
+				continue
+			}
+			placed[i] = mine
+			for _, j := range of[i] {
+				open[j]--
+				if mine {
+					need[j]--
+				}
+			}
+			if mine {
+				place(i+1, mines+1)
+			} else {
+				place(i+1, mines)
+			}
+			for _, j := range of[i] {
+				open[j]++
+				if mine {
+					need[j]++
+				}
+			}
+		}
+		placed[i] = false
+	}
+	place(0, 0)
+	return max(steps, -1)
+}
+
+// convolve returns the number of ways to place each number of mines across
+// the components, leaving out the component to skip.
+func convolve(components []*component, skip *component) []float64 {
+	ways := []float64{1}
+	for _, comp := range components {
+		if comp == skip {
+			continue
+		}
+		next := make([]float64, len(ways)+len(comp.ways)-1)
+		for a, x := range ways {
+			for b, y := range comp.ways {
+				next[a+b] += x * y
+			}
+		}
+		ways = next
+	}
+	return ways
+}
+
+// freeWeights returns the relative number of ways to place the rest of the
+// mines among the free cells once k have been placed in the components, for
+// every k up to total.  When the number of mines is not known, every k is
+// weighed the same.
+func freeWeights(total, free, mines int) []float64 {
+	weights := make([]float64, total+1)
+	if mines < 0 {
+		for k := range weights {
+			weights[k] = 1
+		}
+		return weights
+	}
+
+	logs := make([]float64, total+1)
+	best := math.Inf(-1)
+	for k := range logs {
+		logs[k] = math.Inf(-1)
+		if rest := mines - k; rest >= 0 && rest <= free {
+			logs[k] = logChoose(free, rest)
+			best = math.Max(best, logs[k])
+		}
+	}
+	for k, l := range logs {
+		if !math.IsInf(l, -1) {
+			weights[k] = math.Exp(l - best)
+		}
+	}
+	return weights
+}
+
+// weigh sets the odds of every cell of the components and, if the number of
+// mines is known, of the free cells.  It returns ErrInconsistent if no
+// placement of the mines agrees with the constraints.
+func weigh(components []*component, free []Cell, mines int, probabilities map[Cell]float64) error {
+	all := convolve(components, nil)
+	weights := freeWeights(len(all)-1, len(free), mines)
+	total, expected := 0.0, 0.0
+	for k, ways := range all {
+		total += ways * weights[k]
+		if len(free) > 0 {
+			expected += ways * weights[k] * float64(mines-k) / float64(len(free))
+		}
+	}
+	if total == 0 {
+		return ErrInconsistent
+	}
+
+	for _, comp := range components {
+		others := convolve(components, comp)
+		for p, cell := range comp.cells {
+			hits := 0.0
+			for k, row := range comp.hits {
+				for o, ways := range others {
+					hits += row[p] * ways * weights[k+o]
+				}
+			}
+			probabilities[cell] = hits / total
+		}
+	}
+
+	// the free cells share the mines left over
+	if mines >= 0 {
+		for _, cell := range free {
+			probabilities[cell] = expected / total
+		}
+	}
+	return nil
+}
+
+// logChoose returns the natural log of n choose k.
+func logChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
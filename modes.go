@@ -0,0 +1,148 @@
+package gominesweeper
+
+import (
+	"time"
+)
+
+// Mode is how a game is played to its end.
+type Mode int
+
+const (
+	// Classic ends a game once its Win condition is met or it is lost.
+	Classic Mode = iota
+
+	// TimeAttack ends a game once it has been played for the TimeLimit,
+	// clearing as much of the minefield as possible before then.  The game
+	// is won when the time runs out, unless a mine has gone off, and is
+	// scored by the 3BV solved.
+	TimeAttack
+
+	// ClickLimited loses a game once it has made Clicks moves without
+	// meeting its Win condition.  Every move counts, whatever it does.
+	ClickLimited
+)
+
+// MarshalText encodes the mode as its name.
+func (m Mode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText decodes the mode from its name.
+func (m *Mode) UnmarshalText(text []byte) error {
+	for _, mode := range []Mode{Classic, TimeAttack, ClickLimited} {
+		if mode.String() == string(text) {
+			*m = mode
+			return nil
+		}
+	}
+	return ErrUnknownName
+}
+
+// String returns the name of the mode.
+func (m Mode) String() string {
+	switch m {
+	case Classic:
+		return "classic"
+	case TimeAttack:
+		return "time attack"
+	case ClickLimited:
+		return "click limited"
+	}
+	return "unknown"
+}
+
+// EndReason is why a game ended.
+type EndReason int
+
+const (
+	// NotEnded is the reason of a game that is still being played.
+	NotEnded EndReason = iota
+
+	// WinMet ended the game when its Win condition was met.
+	WinMet
+
+	// Detonated ended the game when its last life was lost to a mine.
+	Detonated
+
+	// ActionsUsed ended the game when its limited Actions ran out.
+	ActionsUsed
+
+	// TimeUp ended a time attack when its TimeLimit was reached.
+	TimeUp
+
+	// ClicksUsed ended a click limited game when its Clicks ran out.
+	ClicksUsed
+
+	// RevealedAll ended a debug game when RevealAll was called.
+	RevealedAll
+)
+
+// MarshalText encodes the reason as its name.
+func (r EndReason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// String returns the name of the reason.
+func (r EndReason) String() string {
+	switch r {
+	case NotEnded:
+		return "not ended"
+	case WinMet:
+		return "win met"
+	case Detonated:
+		return "detonated"
+	case ActionsUsed:
+		return "actions used"
+	case TimeUp:
+		return "time up"
+	case ClicksUsed:
+		return "clicks used"
+	case RevealedAll:
+		return "revealed all"
+	}
+	return "unknown"
+}
+
+// Mode returns the mode the game is played in.
+func (g *Game) Mode() Mode {
+	return g.config.Mode
+}
+
+// Clicks returns the number of moves left in a click limited game, or 0 in
+// any other mode.
+func (g *Game) Clicks() uint {
+	return g.clicks
+}
+
+// TimeLeft returns the time left in a time attack, or 0 in any other mode.
+func (g *Game) TimeLeft() time.Duration {
+	if g.config.Mode != TimeAttack {
+		return 0
+	}
+	return max(g.config.TimeLimit-g.Elapsed(), 0)
+}
+
+// timeUp ends a time attack that has been played for its time limit, as of
+// the moment the time ran out.
+func (g *Game) timeUp() {
+	if g.config.Mode != TimeAttack || g.state != Playing || g.start.IsZero() || g.Elapsed() < g.config.TimeLimit {
+		return
+	}
+	g.state, g.reason = Won, TimeUp
+	g.finish(g.start.Add(g.config.TimeLimit))
+}
+
+// spendClick uses up one of the moves of a click limited game, ending it as
+// lost once they run out.
+func (g *Game) spendClick() {
+	if g.config.Mode != ClickLimited {
+		return
+	}
+	if g.clicks > 0 {
+		g.clicks--
+	}
+	if g.state == Playing && g.clicks == 0 {
+		g.minefield.revealMines()
+		g.state, g.reason = Lost, ClicksUsed
+	}
+}
@@ -0,0 +1,97 @@
+package gominesweeper
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_TimeAttack(c *C) {
+	now := time.Unix(0, 0)
+	game := newTestGame(c, Config{Mode: TimeAttack, TimeLimit: time.Minute})
+	game.clock = func() time.Time { return now }
+	c.Check(game.Mode(), Equals, TimeAttack)
+	c.Check(game.TimeLeft(), Equals, time.Minute)
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	now = now.Add(40 * time.Second)
+	_, err = game.Select(0, 4)
+	c.Assert(err, IsNil)
+	c.Check(game.TimeLeft(), Equals, 20*time.Second)
+	c.Check(game.Tick(), Equals, Playing)
+
+	// the time runs out in between moves
+	now = now.Add(30 * time.Second)
+	_, err = game.Select(0, 1)
+	c.Check(err, Equals, ErrGameOver)
+	c.Check(game.State(), Equals, Won)
+	c.Check(game.Elapsed(), Equals, time.Minute)
+	c.Check(game.TimeLeft(), Equals, time.Duration(0))
+
+	end := game.EndState()
+	c.Check(end.Mode, Equals, TimeAttack)
+	c.Check(end.Reason, Equals, TimeUp)
+	c.Check(end.Moves, Equals, 2)
+	_, solved := game.minefield.bbbv()
+	c.Check(end.Solved, Equals, solved)
+	c.Check(end.Solved > 0, Equals, true)
+
+	// a mine still loses
+	game = newTestGame(c, Config{Mode: TimeAttack, TimeLimit: time.Minute})
+	game.clock = fakeClock()
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(game.EndState().Reason, Equals, Detonated)
+	c.Check(game.Tick(), Equals, Lost)
+
+	// a time attack needs a time limit
+	_, err = NewGame(Config{Width: 5, Height: 5, Mines: 5, Mode: TimeAttack})
+	c.Check(err, Equals, ErrNoLimit)
+	_, err = NewGame(Config{Width: 5, Height: 5, Mines: 5, Mode: TimeAttack, TimeLimit: -time.Second})
+	c.Check(err, Equals, ErrNoLimit)
+}
+
+func (s *MSSuite) TestGame_ClickLimited(c *C) {
+	game := newTestGame(c, Config{Mode: ClickLimited, Clicks: 3})
+	c.Check(game.Clicks(), Equals, uint(3))
+
+	// every move counts, including flags
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	c.Check(game.Clicks(), Equals, uint(1))
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(game.State(), Equals, Lost)
+	c.Check(game.EndState().Reason, Equals, ClicksUsed)
+	c.Check(game.EndState().Moves, Equals, 3)
+
+	// winning on the last click wins
+	game = newTestGame(c, Config{Mode: ClickLimited, Clicks: 3, Win: RevealTarget(Position{X: 0, Y: 4})})
+	_, err = game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(game.State(), Equals, Playing)
+	_, err = game.Select(1, 1)
+	c.Assert(err, IsNil)
+	_, err = game.Select(0, 4)
+	c.Assert(err, IsNil)
+	c.Check(game.State(), Equals, Won)
+	c.Check(game.EndState().Reason, Equals, WinMet)
+
+	// a click limited game needs clicks
+	_, err = NewGame(Config{Width: 5, Height: 5, Mines: 5, Mode: ClickLimited})
+	c.Check(err, Equals, ErrNoLimit)
+}
+
+func (s *MSSuite) TestMode_Text(c *C) {
+	for _, mode := range []Mode{Classic, TimeAttack, ClickLimited} {
+		text, err := mode.MarshalText()
+		c.Assert(err, IsNil)
+		var decoded Mode
+		c.Assert(decoded.UnmarshalText(text), IsNil)
+		c.Check(decoded, Equals, mode)
+	}
+	var mode Mode
+	c.Check(mode.UnmarshalText([]byte("bogus")), Equals, ErrUnknownName)
+	c.Check(ClicksUsed.String(), Equals, "clicks used")
+}
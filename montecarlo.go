@@ -0,0 +1,207 @@
+package gominesweeper
+
+import (
+	"encoding/json"
+
+	"github.com/smousa/go-minesweeper/minegen"
+)
+
+// Confidence is how the odds of a hint were worked out.
+type Confidence int
+
+const (
+	// Exact odds were deduced by the solver, and are either 0 or 1.
+	Exact Confidence = iota
+
+	// Simulated odds were estimated from the accepted iterations.
+	Simulated
+
+	// Heuristic odds are the solver's Risk, used when no iteration was
+	// accepted.
+	Heuristic
+)
+
+// MarshalText encodes the confidence as its name.
+func (c Confidence) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes the confidence from its name.
+func (c *Confidence) UnmarshalText(text []byte) error {
+	for _, confidence := range []Confidence{Exact, Simulated, Heuristic} {
+		if confidence.String() == string(text) {
+			*c = confidence
+			return nil
+		}
+	}
+	return ErrUnknownName
+}
+
+// String returns the name of the confidence.
+func (c Confidence) String() string {
+	switch c {
+	case Exact:
+		return "exact"
+	case Simulated:
+		return "simulated"
+	case Heuristic:
+		return "heuristic"
+	}
+	return "unknown"
+}
+
+// Hint is the odds of a hidden block being a mine.
+type Hint struct {
+	Risk       float64    `json:"risk"`
+	Confidence Confidence `json:"confidence"`
+
+	// Samples is the number of accepted iterations a Simulated risk is
+	// based on.
+	Samples int `json:"samples,omitempty"`
+}
+
+// Estimate is a Monte Carlo estimate of how likely each hidden block is to be
+// a mine.  The Seed and Iterations it was made with are kept alongside it, so
+// that anyone can make the same estimate again to check it.
+type Estimate struct {
+	Seed       int64 `json:"seed"`
+	Iterations int   `json:"iterations"`
+
+	// Accepted is the number of iterations that placed the mines in a way
+	// that agreed with every revealed number.
+	Accepted int `json:"accepted"`
+
+	// Hints holds the odds of a mine for every hidden block.  They are
+	// encoded as a list ordered by row, each with the x and y of its block.
+	Hints map[Position]Hint `json:"-"`
+}
+
+// positionedHint is a hint as it is encoded, alongside its block.
+type positionedHint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	Hint
+}
+
+// estimateJSON is an estimate as it is encoded.
+type estimateJSON struct {
+	Seed       int64            `json:"seed"`
+	Iterations int              `json:"iterations"`
+	Accepted   int              `json:"accepted"`
+	Hints      []positionedHint `json:"hints"`
+}
+
+// MarshalJSON encodes the estimate with its hints ordered by row.
+func (e Estimate) MarshalJSON() ([]byte, error) {
+	positions := make([]Position, 0, len(e.Hints))
+	for pos := range e.Hints {
+		positions = append(positions, pos)
+	}
+	sortPositions(positions)
+
+	encoded := estimateJSON{
+		Seed:       e.Seed,
+		Iterations: e.Iterations,
+		Accepted:   e.Accepted,
+		Hints:      make([]positionedHint, len(positions)),
+	}
+	for i, pos := range positions {
+		encoded.Hints[i] = positionedHint{X: pos.X, Y: pos.Y, Hint: e.Hints[pos]}
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes an estimate encoded with MarshalJSON.
+func (e *Estimate) UnmarshalJSON(data []byte) error {
+	var decoded estimateJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*e = Estimate{
+		Seed:       decoded.Seed,
+		Iterations: decoded.Iterations,
+		Accepted:   decoded.Accepted,
+		Hints:      make(map[Position]Hint, len(decoded.Hints)),
+	}
+	for _, hint := range decoded.Hints {
+		e.Hints[Position{X: hint.X, Y: hint.Y}] = hint.Hint
+	}
+	return nil
+}
+
+// Estimate places the remaining mines at random among the hidden blocks for
+// the number of iterations, using the seed, and estimates the odds of each
+// block from the placements that agree with every revealed number.
+func (s *Solver) Estimate(seed int64, iterations int) Estimate {
+	estimate := Estimate{
+		Seed:       seed,
+		Iterations: iterations,
+		Hints:      make(map[Position]Hint),
+	}
+
+	// the hidden blocks are ordered so that the seed places the mines in
+	// the same way every time
+	var hidden []Position
+	mines := s.snapshot.Mines
+	for pos, value := range s.snapshot.Blocks {
+		if s.hidden(pos) {
+			hidden = append(hidden, pos)
+		} else if s.known(pos) {
+			mines--
+		}
+		if (value == Unknown || value == Questioned) && (s.safe[pos] || s.mines[pos]) {
+			estimate.Hints[pos] = Hint{Risk: s.Risk(pos), Confidence: Exact}
+		}
+	}
+	sortPositions(hidden)
+
+	counts := make([]int, len(hidden))
+	if mines >= 0 && mines <= len(hidden) {
+		src := SeededSource(seed)
+		placed := make(map[Position]bool, mines)
+		for i := 0; i < iterations; i++ {
+			clear(placed)
+			order := minegen.Perm(src, len(hidden))
+			for _, j := range order[:mines] {
+				placed[hidden[j]] = true
+			}
+			if !s.agrees(placed) {
+				continue
+			}
+			estimate.Accepted++
+			for _, j := range order[:mines] {
+				counts[j]++
+			}
+		}
+	}
+
+	for i, pos := range hidden {
+		if estimate.Accepted == 0 {
+			estimate.Hints[pos] = Hint{Risk: s.Risk(pos), Confidence: Heuristic}
+		} else {
+			estimate.Hints[pos] = Hint{
+				Risk:       float64(counts[i]) / float64(estimate.Accepted),
+				Confidence: Simulated,
+				Samples:    estimate.Accepted,
+			}
+		}
+	}
+	return estimate
+}
+
+// agrees returns true if the mines placed among the hidden blocks agree with
+// every constraint.
+func (s *Solver) agrees(placed map[Position]bool) bool {
+	for _, c := range s.constraints {
+		mines := 0
+		for pos := range c.positions {
+			if placed[pos] {
+				mines++
+			}
+		}
+		if mines != c.mines {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,207 @@
+package gominesweeper
+
+import (
+	"errors"
+)
+
+// errIgnored is returned by a move that does nothing and is not recorded.
+var errIgnored = errors.New("move ignored")
+
+// MoveResult is the outcome of a move made with Apply.
+type MoveResult struct {
+	// Value is what the move found at its block: for a reveal the
+	// proximity, Mine, Checked or Flagged as returned by Select, and for any
+	// other move the block's value in Display afterwards.
+	Value int
+
+	// Revealed is the number of blocks the move revealed.
+	Revealed int
+
+	// State is the state of the game after the move.
+	State State
+}
+
+// Apply makes the move on the game.  Every move goes through Apply, which
+// checks that it may be made, makes it, and then records it and sends it to
+// the subscribers.  It returns ErrUnknownAction for an action it does not
+// know.
+func (g *Game) Apply(move Move) (MoveResult, error) {
+	if g.config.Metrics != nil {
+		return g.measure(move)
+	}
+	return g.apply(move)
+}
+
+// apply makes the move on the game.
+func (g *Game) apply(move Move) (MoveResult, error) {
+	if err := g.unpause(); err != nil {
+		return MoveResult{}, err
+	} else if g.expire(); g.state != Playing {
+		return MoveResult{}, ErrGameOver
+	} else if err := g.admit(move); err != nil {
+		return MoveResult{}, err
+	} else if err := g.strict(move.Position, move.Action); err != nil {
+		return MoveResult{}, err
+	}
+
+	var result MoveResult
+	var err error
+	switch move.Action {
+	case Reveal:
+		result, err = g.reveal(move.Position)
+	case Flag:
+		result, err = g.flag(move.Position)
+	case Defuse, Detonate:
+		result, err = g.defuse(move.Position, move.Action == Defuse)
+	case Chord:
+		result, err = g.chord(move.Position)
+	case Question:
+		result, err = g.question(move.Position)
+	default:
+		return MoveResult{}, ErrUnknownAction
+	}
+	if err == errIgnored {
+		return result, nil
+	} else if err != nil {
+		return MoveResult{}, err
+	}
+
+	g.checkWin()
+	g.runOut()
+	g.spendClick()
+	g.record(move)
+	result.State = g.state
+	return result, nil
+}
+
+// reveal selects the block at the position.
+func (g *Game) reveal(pos Position) (MoveResult, error) {
+	if err := g.openFirst(pos); err != nil {
+		return MoveResult{}, err
+	}
+	proximity, revealed, err := g.minefield.reveal(pos)
+	if err != nil {
+		return MoveResult{}, err
+	}
+
+	if proximity == Mine {
+		g.explode(pos)
+	}
+	if g.config.ScoreAttack {
+		g.scoreReveal(proximity, revealed)
+	}
+	if g.config.Actions > 0 && proximity != Checked && proximity != Flagged {
+		g.spendAction()
+	}
+	if g.config.AutoFlag && g.state == Playing {
+		g.autoFlag()
+	}
+	return MoveResult{Value: proximity, Revealed: revealed}, nil
+}
+
+// flag toggles the flag on the block at the position.
+func (g *Game) flag(pos Position) (MoveResult, error) {
+	block, ok := g.minefield.block(pos)
+	if !ok {
+		return MoveResult{}, g.minefield.outOfBounds(pos)
+	} else if g.defused[pos] {
+		return MoveResult{Value: Defused, State: g.state}, errIgnored
+	} else if err := g.minefield.canFlag(block); err != nil {
+		return MoveResult{}, err
+	}
+	block.ToggleFlag()
+	if g.config.DefuseTime > 0 {
+		g.startDefusal(pos, block.flagged())
+	}
+	if g.config.ScoreAttack && block.flagged() && block.proximity() != Mine {
+		g.combo = 0
+	}
+	return MoveResult{Value: g.value(pos)}, nil
+}
+
+// defuse ends the defusal of the flag at the position.
+func (g *Game) defuse(pos Position, success bool) (MoveResult, error) {
+	if _, ok := g.defusing[pos]; !ok {
+		return MoveResult{}, ErrNotDefusing
+	}
+	delete(g.defusing, pos)
+	g.endDefusal(pos, success)
+	return MoveResult{Value: g.value(pos)}, nil
+}
+
+// chord reveals the hidden neighbors of the revealed proximity at the
+// position, once it has as many flags around it as mines.  Mines that have
+// gone off count as flags.
+func (g *Game) chord(pos Position) (MoveResult, error) {
+	block, ok := g.minefield.peek(pos)
+	if !ok {
+		return MoveResult{}, g.minefield.outOfBounds(pos)
+	}
+
+	var hidden []Position
+	flags := 0
+	for _, neighbor := range g.minefield.neighborhood.Neighbors(pos) {
+		if other, ok := g.minefield.peek(neighbor); !ok {
+			continue
+		} else if other.flagged() || other.exploded() {
+			flags++
+		} else if !other.checked() {
+			hidden = append(hidden, neighbor)
+		}
+	}
+	result := MoveResult{}
+	if !block.checked() || block.proximity() <= 0 || flags != block.proximity() {
+		result.Value = g.value(pos)
+		return result, nil
+	}
+
+	for _, neighbor := range hidden {
+		proximity, revealed, _ := g.minefield.reveal(neighbor)
+		result.Revealed += revealed
+		if proximity == Mine {
+			if g.explode(neighbor); g.state != Playing {
+				break
+			}
+		}
+		if g.config.ScoreAttack {
+			g.scoreReveal(proximity, revealed)
+		}
+	}
+	if g.config.Actions > 0 && len(hidden) > 0 {
+		g.spendAction()
+	}
+	if g.config.AutoFlag && g.state == Playing {
+		g.autoFlag()
+	}
+	result.Value = g.value(pos)
+	return result, nil
+}
+
+// question toggles the question mark on the block at the position, which
+// only marks it for the player.
+func (g *Game) question(pos Position) (MoveResult, error) {
+	block, ok := g.minefield.block(pos)
+	if !ok {
+		return MoveResult{}, g.minefield.outOfBounds(pos)
+	}
+	block.ToggleQuestion()
+	return MoveResult{Value: g.value(pos)}, nil
+}
+
+// value returns the value of the block at the position in Display.
+func (g *Game) value(pos Position) int {
+	block, ok := g.minefield.peek(pos)
+	if !ok {
+		return Unknown
+	}
+	value := block.Check()
+	switch {
+	case g.defused[pos]:
+		return Defused
+	case g.state == Lost && block.flagged() && block.proximity() != Mine:
+		return WrongFlag
+	case g.config.Blind && value >= 0:
+		return Revealed
+	}
+	return value
+}
@@ -0,0 +1,68 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_Apply(c *C) {
+	game := newTestGame(c, Config{})
+	game.clock = fakeClock()
+
+	result, err := game.Apply(Move{Reveal, Position{X: 4, Y: 2}})
+	c.Assert(err, IsNil)
+	c.Check(result, Equals, MoveResult{Value: 0, Revealed: 6, State: Playing})
+
+	result, err = game.Apply(Move{Flag, Position{X: 0, Y: 0}})
+	c.Assert(err, IsNil)
+	c.Check(result, Equals, MoveResult{Value: Flagged, State: Playing})
+
+	_, err = game.Apply(Move{Action(99), Position{X: 1, Y: 1}})
+	c.Check(err, Equals, ErrUnknownAction)
+	c.Check(game.Replay().Moves, HasLen, 2)
+}
+
+func (s *MSSuite) TestGame_Chord(c *C) {
+	game := newTestGame(c, Config{})
+	game.clock = fakeClock()
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+
+	// not enough flags around the 1
+	result, err := game.Apply(Move{Chord, Position{X: 4, Y: 3}})
+	c.Assert(err, IsNil)
+	c.Check(result, Equals, MoveResult{Value: 1, State: Playing})
+	c.Check(game.Display()[Position{X: 4, Y: 4}], Equals, Unknown)
+
+	c.Assert(game.ToggleFlag(3, 4), IsNil)
+	result, err = game.Apply(Move{Chord, Position{X: 4, Y: 3}})
+	c.Assert(err, IsNil)
+	c.Check(result, Equals, MoveResult{Value: 1, Revealed: 1, State: Playing})
+	c.Check(game.Display()[Position{X: 4, Y: 4}], Equals, 1)
+
+	// a wrong flag sets off the mine next to it
+	c.Assert(game.ToggleFlag(2, 2), IsNil)
+	result, err = game.Apply(Move{Chord, Position{X: 3, Y: 2}})
+	c.Assert(err, IsNil)
+	c.Check(result.State, Equals, Lost)
+}
+
+func (s *MSSuite) TestGame_Question(c *C) {
+	game := newTestGame(c, Config{})
+	game.clock = fakeClock()
+
+	result, err := game.Apply(Move{Question, Position{X: 0, Y: 4}})
+	c.Assert(err, IsNil)
+	c.Check(result.Value, Equals, Questioned)
+	c.Check(game.Display()[Position{X: 0, Y: 4}], Equals, Questioned)
+
+	// flagging replaces the question mark
+	c.Assert(game.ToggleFlag(0, 4), IsNil)
+	c.Assert(game.ToggleFlag(0, 4), IsNil)
+	c.Check(game.Display()[Position{X: 0, Y: 4}], Equals, Unknown)
+
+	_, err = game.Apply(Move{Question, Position{X: 1, Y: 3}})
+	c.Assert(err, IsNil)
+	_, err = game.Select(1, 3)
+	c.Assert(err, IsNil)
+	c.Check(game.Display()[Position{X: 1, Y: 3}], Equals, 1)
+}
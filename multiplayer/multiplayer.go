@@ -0,0 +1,237 @@
+// Package multiplayer lets several players share one minesweeper game.
+package multiplayer
+
+import (
+	"errors"
+	"sync"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+var (
+	ErrNoPlayers     = errors.New("match has no players")
+	ErrDupPlayer     = errors.New("duplicate player found")
+	ErrUnknownPlayer = errors.New("player is not in the match")
+	ErrNotYourTurn   = errors.New("it is another player's turn")
+	ErrNotAllowed    = errors.New("player's role does not allow it")
+)
+
+// Capability is something a player's role allows them to do.
+type Capability uint
+
+const (
+	// CanMove allows selecting blocks and toggling flags.
+	CanMove Capability = 1 << iota
+
+	// CanSeeHints allows getting Hints.
+	CanSeeHints
+
+	// Everyone is the role every player starts with.
+	Everyone = CanMove | CanSeeHints
+
+	// Spotter sees hints but cannot move, and Clicker moves without hints.
+	Spotter = CanSeeHints
+	Clicker = CanMove
+)
+
+// Mode decides how the players share the game.
+type Mode int
+
+const (
+	// Coop lets every player move at any time, working together to win.
+	Coop Mode = iota
+
+	// Turns has the players take turns selecting blocks, each scoring the
+	// blocks they reveal.
+	Turns
+
+	// Race lets every player move at any time, each scoring the blocks they
+	// reveal.
+	Race
+)
+
+// Move is a move made by a player.
+type Move struct {
+	Player string
+	gominesweeper.Move
+}
+
+// Match is a game shared by several players.  It is safe for concurrent use,
+// so that players can move at the same time, as long as the game is only
+// played through the match.
+type Match struct {
+	mu      sync.Mutex
+	game    *gominesweeper.Game
+	mode    Mode
+	players []string
+	turn    int
+	scores  map[string]int
+	roles   map[string]Capability
+	moves   []Move
+}
+
+// New starts a match on the game between the players.  In Turns, the players
+// take their turns in the order given.
+func New(game *gominesweeper.Game, mode Mode, players ...string) (*Match, error) {
+	if len(players) == 0 {
+		return nil, ErrNoPlayers
+	}
+
+	m := &Match{
+		game:    game,
+		mode:    mode,
+		players: append([]string(nil), players...),
+		scores:  make(map[string]int),
+		roles:   make(map[string]Capability),
+	}
+	for _, player := range players {
+		if _, ok := m.scores[player]; ok {
+			return nil, ErrDupPlayer
+		}
+		m.scores[player] = 0
+		m.roles[player] = Everyone
+	}
+	return m, nil
+}
+
+// Game returns the game being played.  Its methods are not guarded by the
+// match, so it must not be used while players may be moving.
+func (m *Match) Game() *gominesweeper.Game {
+	return m.game
+}
+
+// SetRole limits what the player may do to the capabilities of the role.
+func (m *Match) SetRole(player string, role Capability) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.roles[player]; !ok {
+		return ErrUnknownPlayer
+	}
+	m.roles[player] = role
+	return nil
+}
+
+// Hints returns how likely each hidden block is to be a mine, as estimated
+// by the solver with the simulation seed and iterations, if the player's role
+// can see hints.
+func (m *Match) Hints(player string, seed int64, iterations int) (gominesweeper.Estimate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if role, ok := m.roles[player]; !ok {
+		return gominesweeper.Estimate{}, ErrUnknownPlayer
+	} else if role&CanSeeHints == 0 {
+		return gominesweeper.Estimate{}, ErrNotAllowed
+	}
+	return m.game.Solver().Estimate(seed, iterations), nil
+}
+
+// Turn returns the player whose turn it is in Turns, or "" in any other mode.
+func (m *Match) Turn() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.mode != Turns {
+		return ""
+	}
+	return m.players[m.turn]
+}
+
+// Select reveals the block at the given position for the player, passing the
+// turn on to the next player in Turns.
+func (m *Match) Select(player string, x, y int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.check(player); err != nil {
+		return 0, err
+	}
+
+	move := gominesweeper.Move{Action: gominesweeper.Reveal, Position: gominesweeper.Position{X: x, Y: y}}
+	result, err := m.game.Apply(move)
+	if err != nil {
+		return 0, err
+	}
+
+	// a mine is revealed too, but does not score
+	if result.Value != gominesweeper.Mine {
+		m.scores[player] += result.Revealed
+	}
+	m.moves = append(m.moves, Move{player, move})
+	if m.mode == Turns {
+		m.turn = (m.turn + 1) % len(m.players)
+	}
+	return result.Value, nil
+}
+
+// ToggleFlag toggles the flag at the given position for the player.  Flags do
+// not end the player's turn.
+func (m *Match) ToggleFlag(player string, x, y int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.check(player); err != nil {
+		return err
+	}
+
+	if err := m.game.ToggleFlag(x, y); err != nil {
+		return err
+	}
+	m.moves = append(m.moves, Move{player, gominesweeper.Move{Action: gominesweeper.Flag, Position: gominesweeper.Position{X: x, Y: y}}})
+	return nil
+}
+
+// Moves returns every move made in the match so far.
+func (m *Match) Moves() []Move {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	moves := make([]Move, len(m.moves))
+	copy(moves, m.moves)
+	return moves
+}
+
+// Scores returns the number of blocks each player has revealed.
+func (m *Match) Scores() map[string]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	scores := make(map[string]int, len(m.scores))
+	for player, score := range m.scores {
+		scores[player] = score
+	}
+	return scores
+}
+
+// Winners returns the players that won the match once the game is over.  In
+// Coop every player wins or loses together, otherwise the players with the
+// highest score win.
+func (m *Match) Winners() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch state := m.game.State(); {
+	case state == gominesweeper.Playing:
+		return nil
+	case m.mode == Coop && state == gominesweeper.Won:
+		return append([]string(nil), m.players...)
+	case m.mode == Coop:
+		return nil
+	}
+
+	best := -1
+	var winners []string
+	for _, player := range m.players {
+		if score := m.scores[player]; score > best {
+			best, winners = score, []string{player}
+		} else if score == best {
+			winners = append(winners, player)
+		}
+	}
+	return winners
+}
+
+// check returns an error if the player may not move.
+func (m *Match) check(player string) error {
+	if role, ok := m.roles[player]; !ok {
+		return ErrUnknownPlayer
+	} else if role&CanMove == 0 {
+		return ErrNotAllowed
+	} else if m.mode == Turns && m.players[m.turn] != player {
+		return ErrNotYourTurn
+	}
+	return nil
+}
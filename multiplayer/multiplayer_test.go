@@ -0,0 +1,189 @@
+package multiplayer
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+type MatchSuite struct {
+	game *gominesweeper.Game
+}
+
+var _ = Suite(&MatchSuite{})
+
+func (s *MatchSuite) SetUpTest(c *C) {
+	game, err := gominesweeper.NewGame(gominesweeper.Config{
+		Width:  5,
+		Height: 5,
+		Mines:  5,
+		Selector: func(width, height, max uint) ([]gominesweeper.Position, error) {
+			return []gominesweeper.Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+		},
+	})
+	c.Assert(err, IsNil)
+	s.game = game
+}
+
+func (s *MatchSuite) TestNew(c *C) {
+	_, err := New(s.game, Coop)
+	c.Check(err, Equals, ErrNoPlayers)
+	_, err = New(s.game, Coop, "alice", "bob", "alice")
+	c.Check(err, Equals, ErrDupPlayer)
+}
+
+func (s *MatchSuite) TestTurns(c *C) {
+	m, err := New(s.game, Turns, "alice", "bob")
+	c.Assert(err, IsNil)
+	c.Check(m.Turn(), Equals, "alice")
+
+	_, err = m.Select("bob", 4, 2)
+	c.Check(err, Equals, ErrNotYourTurn)
+	_, err = m.Select("carol", 4, 2)
+	c.Check(err, Equals, ErrUnknownPlayer)
+
+	// flags do not end the turn
+	c.Assert(m.ToggleFlag("alice", 0, 0), IsNil)
+	proximity, err := m.Select("alice", 4, 2)
+	c.Assert(err, IsNil)
+	c.Check(proximity, Equals, 0)
+	c.Check(m.Turn(), Equals, "bob")
+
+	// out of bounds does not end the turn
+	_, err = m.Select("bob", 9, 9)
+	c.Check(errors.Is(err, gominesweeper.ErrOutOfBounds), Equals, true)
+	c.Check(m.Turn(), Equals, "bob")
+
+	_, err = m.Select("bob", 1, 1)
+	c.Assert(err, IsNil)
+	c.Check(m.Turn(), Equals, "alice")
+	c.Check(m.Winners(), IsNil)
+
+	_, err = m.Select("alice", 4, 0)
+	c.Assert(err, IsNil)
+	c.Check(m.Scores(), DeepEquals, map[string]int{"alice": 6, "bob": 1})
+	c.Check(m.Winners(), DeepEquals, []string{"alice"})
+
+	c.Check(m.Moves(), DeepEquals, []Move{
+		{"alice", gominesweeper.Move{Action: gominesweeper.Flag, Position: gominesweeper.Position{X: 0, Y: 0}}},
+		{"alice", gominesweeper.Move{Action: gominesweeper.Reveal, Position: gominesweeper.Position{X: 4, Y: 2}}},
+		{"bob", gominesweeper.Move{Action: gominesweeper.Reveal, Position: gominesweeper.Position{X: 1, Y: 1}}},
+		{"alice", gominesweeper.Move{Action: gominesweeper.Reveal, Position: gominesweeper.Position{X: 4, Y: 0}}},
+	})
+}
+
+func (s *MatchSuite) TestRace(c *C) {
+	m, err := New(s.game, Race, "alice", "bob")
+	c.Assert(err, IsNil)
+	c.Check(m.Turn(), Equals, "")
+
+	_, err = m.Select("bob", 0, 4)
+	c.Assert(err, IsNil)
+	_, err = m.Select("bob", 4, 2)
+	c.Assert(err, IsNil)
+	_, err = m.Select("alice", 1, 1)
+	c.Assert(err, IsNil)
+	_, err = m.Select("alice", 0, 0)
+	c.Assert(err, IsNil)
+	c.Check(m.Scores(), DeepEquals, map[string]int{"alice": 1, "bob": 12})
+	c.Check(m.Winners(), DeepEquals, []string{"bob"})
+}
+
+func (s *MatchSuite) TestRaceConcurrent(c *C) {
+	m, err := New(s.game, Race, "alice", "bob")
+	c.Assert(err, IsNil)
+
+	// both players race to reveal every block that is not a mine
+	mines := map[gominesweeper.Position]bool{{X: 1, Y: 2}: true, {X: 3, Y: 4}: true, {X: 0, Y: 0}: true, {X: 2, Y: 1}: true, {X: 4, Y: 0}: true}
+	var wg sync.WaitGroup
+	for _, player := range []string{"alice", "bob"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := 0; y < 5; y++ {
+				for x := 0; x < 5; x++ {
+					if !mines[gominesweeper.Position{X: x, Y: y}] {
+						m.Select(player, x, y)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	scores := m.Scores()
+	c.Check(scores["alice"]+scores["bob"], Equals, 20)
+	c.Check(m.Winners(), Not(HasLen), 0)
+}
+
+func (s *MatchSuite) TestRaceBlind(c *C) {
+	game, err := gominesweeper.NewGame(gominesweeper.Config{
+		Width:  5,
+		Height: 5,
+		Mines:  5,
+		Blind:  true,
+		Selector: func(width, height, max uint) ([]gominesweeper.Position, error) {
+			return []gominesweeper.Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+		},
+	})
+	c.Assert(err, IsNil)
+	players := []string{"alice", "bob"}
+	m, err := New(game, Race, players...)
+	c.Assert(err, IsNil)
+	players[0] = "carol"
+
+	_, err = m.Select("bob", 4, 2)
+	c.Assert(err, IsNil)
+	_, err = m.Select("alice", 1, 1)
+	c.Assert(err, IsNil)
+	c.Check(m.Scores(), DeepEquals, map[string]int{"alice": 1, "bob": 6})
+	_, err = m.Select("carol", 0, 4)
+	c.Check(err, Equals, ErrUnknownPlayer)
+}
+
+func (s *MatchSuite) TestCoop(c *C) {
+	m, err := New(s.game, Coop, "alice", "bob")
+	c.Assert(err, IsNil)
+
+	for i, pos := range []gominesweeper.Position{
+		{X: 4, Y: 2}, {X: 0, Y: 4}, {X: 0, Y: 1}, {X: 0, Y: 2}, {X: 1, Y: 0},
+		{X: 1, Y: 1}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 3, Y: 0}, {X: 4, Y: 4},
+	} {
+		c.Check(m.Winners(), IsNil)
+		_, err := m.Select([]string{"alice", "bob"}[i%2], pos.X, pos.Y)
+		c.Assert(err, IsNil)
+	}
+	c.Check(m.Winners(), DeepEquals, []string{"alice", "bob"})
+	c.Check(m.Scores(), DeepEquals, map[string]int{"alice": 10, "bob": 10})
+}
+
+func (s *MatchSuite) TestRoles(c *C) {
+	m, err := New(s.game, Coop, "spotter", "clicker")
+	c.Assert(err, IsNil)
+	c.Assert(m.SetRole("spotter", Spotter), IsNil)
+	c.Assert(m.SetRole("clicker", Clicker), IsNil)
+	c.Check(m.SetRole("carol", Spotter), Equals, ErrUnknownPlayer)
+
+	_, err = m.Select("spotter", 4, 2)
+	c.Check(err, Equals, ErrNotAllowed)
+	c.Check(m.ToggleFlag("spotter", 0, 0), Equals, ErrNotAllowed)
+	_, err = m.Hints("clicker", 1, 100)
+	c.Check(err, Equals, ErrNotAllowed)
+	_, err = m.Hints("carol", 1, 100)
+	c.Check(err, Equals, ErrUnknownPlayer)
+
+	_, err = m.Select("clicker", 4, 2)
+	c.Assert(err, IsNil)
+	hints, err := m.Hints("spotter", 1, 1000)
+	c.Assert(err, IsNil)
+	c.Check(hints.Seed, Equals, int64(1))
+	c.Check(hints.Iterations, Equals, 1000)
+	c.Check(hints.Hints, HasLen, 19)
+	c.Check(hints.Hints[gominesweeper.Position{X: 2, Y: 0}], Equals, gominesweeper.Hint{Risk: 0, Confidence: gominesweeper.Exact})
+	c.Check(hints.Hints[gominesweeper.Position{X: 2, Y: 1}], Equals, gominesweeper.Hint{Risk: 1, Confidence: gominesweeper.Exact})
+}
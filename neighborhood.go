@@ -0,0 +1,54 @@
+package gominesweeper
+
+// Neighborhood decides which positions are adjacent to a given position.  The
+// minefield discards any neighbors that fall outside of its bounds.
+//
+// Neighborhoods are expected to be symmetric: if b is a neighbor of a, then a
+// is also a neighbor of b.
+type Neighborhood interface {
+	Neighbors(pos Position) []Position
+}
+
+// Deltas is a Neighborhood made up of fixed offsets from the position.
+type Deltas []Position
+
+// Neighbors returns the position shifted by each of the deltas.
+func (d Deltas) Neighbors(pos Position) []Position {
+	neighbors := make([]Position, len(d))
+	for i, delta := range d {
+		neighbors[i] = Position{X: pos.X + delta.X, Y: pos.Y + delta.Y}
+	}
+	return neighbors
+}
+
+var (
+	// Surrounding is the classic neighborhood of the 8 blocks that touch a
+	// position on either a side or a corner.
+	Surrounding Neighborhood = Radius(1)
+
+	// Orthogonal is the neighborhood of the 4 blocks that share a side with
+	// a position.
+	Orthogonal Neighborhood = Deltas{{X: 0, Y: -1}, {X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}}
+
+	// Knight is the neighborhood of the 8 blocks that are a knight's move
+	// away from a position.
+	Knight Neighborhood = Deltas{
+		{X: -1, Y: -2}, {X: 1, Y: -2}, {X: -2, Y: -1}, {X: 2, Y: -1},
+		{X: -2, Y: 1}, {X: 2, Y: 1}, {X: -1, Y: 2}, {X: 1, Y: 2},
+	}
+)
+
+// Radius returns the neighborhood of all blocks within r steps of a position
+// in any direction, excluding the position itself.
+func Radius(r int) Deltas {
+	var deltas Deltas
+	for deltaX := -r; deltaX <= r; deltaX++ {
+		for deltaY := -r; deltaY <= r; deltaY++ {
+			if deltaX == 0 && deltaY == 0 {
+				continue
+			}
+			deltas = append(deltas, Position{X: deltaX, Y: deltaY})
+		}
+	}
+	return deltas
+}
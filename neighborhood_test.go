@@ -0,0 +1,69 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestNeighborhood(c *C) {
+	c.Check(Surrounding.Neighbors(Position{X: 2, Y: 2}), HasLen, 8)
+	c.Check(Radius(2).Neighbors(Position{X: 2, Y: 2}), HasLen, 24)
+	c.Check(Orthogonal.Neighbors(Position{X: 2, Y: 2}), DeepEquals, []Position{{X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 2}, {X: 2, Y: 3}})
+	c.Check(Knight.Neighbors(Position{X: 0, Y: 0}), DeepEquals, []Position{
+		{X: -1, Y: -2}, {X: 1, Y: -2}, {X: -2, Y: -1}, {X: 2, Y: -1},
+		{X: -2, Y: 1}, {X: 2, Y: 1}, {X: -1, Y: 2}, {X: 1, Y: 2},
+	})
+}
+
+func (s *MSSuite) TestMinefield_Orthogonal(c *C) {
+	minefield, err := newMinefield(Orthogonal).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+	})
+	c.Assert(err, IsNil)
+
+	expected := map[Position]*Block{
+		Position{X: 0, Y: 0}: NewBlock(Mine), Position{X: 0, Y: 1}: NewBlock(1), Position{X: 0, Y: 2}: NewBlock(1), Position{X: 0, Y: 3}: NewBlock(0), Position{X: 0, Y: 4}: NewBlock(0),
+		Position{X: 1, Y: 0}: NewBlock(1), Position{X: 1, Y: 1}: NewBlock(2), Position{X: 1, Y: 2}: NewBlock(Mine), Position{X: 1, Y: 3}: NewBlock(1), Position{X: 1, Y: 4}: NewBlock(0),
+		Position{X: 2, Y: 0}: NewBlock(1), Position{X: 2, Y: 1}: NewBlock(Mine), Position{X: 2, Y: 2}: NewBlock(2), Position{X: 2, Y: 3}: NewBlock(0), Position{X: 2, Y: 4}: NewBlock(1),
+		Position{X: 3, Y: 0}: NewBlock(1), Position{X: 3, Y: 1}: NewBlock(1), Position{X: 3, Y: 2}: NewBlock(0), Position{X: 3, Y: 3}: NewBlock(1), Position{X: 3, Y: 4}: NewBlock(Mine),
+		Position{X: 4, Y: 0}: NewBlock(Mine), Position{X: 4, Y: 1}: NewBlock(1), Position{X: 4, Y: 2}: NewBlock(0), Position{X: 4, Y: 3}: NewBlock(0), Position{X: 4, Y: 4}: NewBlock(1),
+	}
+	c.Check(allBlocks(minefield), DeepEquals, expected)
+
+	// only orthogonal neighbors are revealed
+	result, err := minefield.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(result.Value, Equals, 0)
+
+	display := minefield.Display()
+	c.Check(display[Position{X: 4, Y: 2}], Equals, 0)
+	c.Check(display[Position{X: 3, Y: 2}], Equals, 0)
+	c.Check(display[Position{X: 4, Y: 3}], Equals, 0)
+	c.Check(display[Position{X: 4, Y: 1}], Equals, 1)
+	c.Check(display[Position{X: 3, Y: 1}], Equals, 1)
+	c.Check(display[Position{X: 2, Y: 2}], Equals, 2)
+	c.Check(display[Position{X: 3, Y: 3}], Equals, 1)
+	c.Check(display[Position{X: 4, Y: 4}], Equals, 1)
+	c.Check(display[Position{X: 2, Y: 3}], Equals, Unknown)
+}
+
+func (s *MSSuite) TestMinefield_Config(c *C) {
+	minefield, err := NewMinefieldConfig(Config{
+		Width:        5,
+		Height:       5,
+		Mines:        5,
+		Neighborhood: Knight,
+		Selector: func(width, height, max uint) ([]Position, error) {
+			return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+		},
+	})
+	c.Assert(err, IsNil)
+	c.Check(allBlocks(minefield)[Position{X: 4, Y: 2}].proximity(), Equals, 2)
+	c.Check(allBlocks(minefield)[Position{X: 1, Y: 3}].proximity(), Equals, 2)
+	c.Check(allBlocks(minefield)[Position{X: 1, Y: 0}].proximity(), Equals, 0)
+
+	// defaults to the random selector and surrounding neighborhood
+	minefield, err = NewMinefieldConfig(Config{Width: 5, Height: 5, Mines: 5})
+	c.Assert(err, IsNil)
+	c.Check(minefield.cells, HasLen, 25)
+	c.Check(minefield.neighborhood, DeepEquals, Surrounding)
+}
@@ -0,0 +1,181 @@
+// Package notation writes whole games as short lines of text that can be
+// shared in chat or on forums and played back exactly.
+//
+// A game is written as its board followed by its moves, separated by spaces:
+//
+//	9x9/10@42 R4,4 F0,1 R8,8
+//
+// The board is the width, height and number of mines, then after the '@'
+// either the seed of the SeededSelector that placed the mines or the position
+// of every mine, as in 5x5/5@0,0;4,0;2,1;1,2;3,4.  Each move is a letter for
+// its action followed by its position: R to reveal, F to flag, D to defuse,
+// X to detonate, C to chord and Q to mark with a question mark.  Only the classic rules are written; games with lives or
+// other variants are played back by the default rules.
+package notation
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+var ErrSyntax = errors.New("malformed notation")
+
+// letters are the letters of each action.
+var letters = map[gominesweeper.Action]byte{
+	gominesweeper.Reveal:   'R',
+	gominesweeper.Flag:     'F',
+	gominesweeper.Defuse:   'D',
+	gominesweeper.Detonate: 'X',
+	gominesweeper.Chord:    'C',
+	gominesweeper.Question: 'Q',
+}
+
+// Game is a game as written in notation.
+type Game struct {
+	Width, Height, Mines uint
+
+	// Seed places the mines with the SeededSelector, unless there is a
+	// Layout of every mine.
+	Seed   int64
+	Layout []gominesweeper.Position
+
+	Moves []gominesweeper.Move
+}
+
+// FromReplay returns the game of the replay, with the layout of its mines.
+func FromReplay(replay gominesweeper.Replay) Game {
+	moves := make([]gominesweeper.Move, len(replay.Moves))
+	for i, record := range replay.Moves {
+		moves[i] = record.Move
+	}
+	return Game{
+		Width:  replay.Rules.Width,
+		Height: replay.Rules.Height,
+		Mines:  replay.Rules.Mines,
+		Layout: replay.Layout,
+		Moves:  moves,
+	}
+}
+
+// Config returns the config of the game's board.
+func (g Game) Config() gominesweeper.Config {
+	cfg := gominesweeper.Config{Width: g.Width, Height: g.Height, Mines: g.Mines}
+	if g.Layout != nil {
+		layout := g.Layout
+		cfg.Selector = func(width, height, max uint) ([]gominesweeper.Position, error) {
+			return layout, nil
+		}
+	} else {
+		cfg.Selector = gominesweeper.SeededSelector(g.Seed)
+	}
+	return cfg
+}
+
+// Play starts the game and makes every move, stopping at the first move that
+// fails.
+func (g Game) Play() (*gominesweeper.Game, error) {
+	game, err := gominesweeper.NewGame(g.Config())
+	if err != nil {
+		return nil, err
+	}
+	for _, move := range g.Moves {
+		if _, err := game.Apply(move); err != nil {
+			return game, err
+		}
+	}
+	return game, nil
+}
+
+// Format writes the game in notation.
+func Format(g Game) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%dx%d/%d@", g.Width, g.Height, g.Mines)
+	if g.Layout != nil {
+		for i, mine := range g.Layout {
+			if i > 0 {
+				b.WriteByte(';')
+			}
+			fmt.Fprintf(&b, "%d,%d", mine.X, mine.Y)
+		}
+	} else {
+		b.WriteString(strconv.FormatInt(g.Seed, 10))
+	}
+	for _, move := range g.Moves {
+		fmt.Fprintf(&b, " %c%d,%d", letters[move.Action], move.X, move.Y)
+	}
+	return b.String()
+}
+
+// Parse reads a game written in notation, or returns ErrSyntax.
+func Parse(s string) (Game, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return Game{}, ErrSyntax
+	}
+
+	var g Game
+	size, board, ok := strings.Cut(fields[0], "@")
+	if !ok {
+		return Game{}, ErrSyntax
+	} else if _, err := fmt.Sscanf(size, "%dx%d/%d", &g.Width, &g.Height, &g.Mines); err != nil {
+		return Game{}, ErrSyntax
+	}
+	if strings.Contains(board, ",") {
+		for _, field := range strings.Split(board, ";") {
+			mine, err := parsePosition(field)
+			if err != nil {
+				return Game{}, err
+			}
+			g.Layout = append(g.Layout, mine)
+		}
+	} else {
+		seed, err := strconv.ParseInt(board, 10, 64)
+		if err != nil {
+			return Game{}, ErrSyntax
+		}
+		g.Seed = seed
+	}
+
+	for _, field := range fields[1:] {
+		move, err := parseMove(field)
+		if err != nil {
+			return Game{}, err
+		}
+		g.Moves = append(g.Moves, move)
+	}
+	return g, nil
+}
+
+// parseMove reads a move such as R3,4.
+func parseMove(s string) (gominesweeper.Move, error) {
+	if s == "" {
+		return gominesweeper.Move{}, ErrSyntax
+	}
+	for action, letter := range letters {
+		if s[0] == letter {
+			pos, err := parsePosition(s[1:])
+			return gominesweeper.Move{Action: action, Position: pos}, err
+		}
+	}
+	return gominesweeper.Move{}, ErrSyntax
+}
+
+// parsePosition reads a position such as 3,4.
+func parsePosition(s string) (gominesweeper.Position, error) {
+	x, y, ok := strings.Cut(s, ",")
+	if !ok {
+		return gominesweeper.Position{}, ErrSyntax
+	}
+	var pos gominesweeper.Position
+	var errX, errY error
+	pos.X, errX = strconv.Atoi(x)
+	pos.Y, errY = strconv.Atoi(y)
+	if errX != nil || errY != nil {
+		return gominesweeper.Position{}, ErrSyntax
+	}
+	return pos, nil
+}
@@ -0,0 +1,65 @@
+package notation
+
+import (
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type NotationSuite struct{}
+
+var _ = Suite(&NotationSuite{})
+
+func (s *NotationSuite) TestFormat(c *C) {
+	g := Game{
+		Width: 5, Height: 5, Mines: 5,
+		Layout: []gominesweeper.Position{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 4}},
+		Moves: []gominesweeper.Move{
+			{Action: gominesweeper.Reveal, Position: gominesweeper.Position{X: 4, Y: 2}},
+			{Action: gominesweeper.Flag, Position: gominesweeper.Position{X: 4, Y: 0}},
+			{Action: gominesweeper.Reveal, Position: gominesweeper.Position{X: 0, Y: 4}},
+		},
+	}
+	text := Format(g)
+	c.Check(text, Equals, "5x5/5@0,0;4,0;2,1;1,2;3,4 R4,2 F4,0 R0,4")
+	parsed, err := Parse(text)
+	c.Assert(err, IsNil)
+	c.Check(parsed, DeepEquals, g)
+
+	seeded, err := Parse("9x9/10@-42 R4,4")
+	c.Assert(err, IsNil)
+	c.Check(seeded.Seed, Equals, int64(-42))
+	c.Check(seeded.Layout, IsNil)
+	c.Check(Format(seeded), Equals, "9x9/10@-42 R4,4")
+
+	for _, bad := range []string{"", "9x9/10", "9x9@4", "9x9/10@x", "9x9/10@1 Z5,5", "9x9/10@1 R5", "5x5/1@0;0"} {
+		_, err := Parse(bad)
+		c.Check(err, Equals, ErrSyntax, Commentf("notation %q", bad))
+	}
+}
+
+func (s *NotationSuite) TestPlay(c *C) {
+	game, err := gominesweeper.NewGame(gominesweeper.Config{Width: 9, Height: 9, Mines: 10, Selector: gominesweeper.SeededSelector(7)})
+	c.Assert(err, IsNil)
+	for i := 0; i < 4 && game.State() == gominesweeper.Playing; i++ {
+		move := gominesweeper.NewSolverBot(1).NextMove(game.Snapshot())
+		_, err := game.Select(move.X, move.Y)
+		c.Assert(err, IsNil)
+	}
+
+	// a game shared in notation plays back the same
+	parsed, err := Parse(Format(FromReplay(game.Replay())))
+	c.Assert(err, IsNil)
+	played, err := parsed.Play()
+	c.Assert(err, IsNil)
+	c.Check(played.Display(), DeepEquals, game.Display())
+
+	// and so does a seeded one
+	parsed.Layout, parsed.Seed = nil, 7
+	played, err = parsed.Play()
+	c.Assert(err, IsNil)
+	c.Check(played.Display(), DeepEquals, game.Display())
+}
@@ -0,0 +1,173 @@
+package gominesweeper
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+
+	"github.com/smousa/go-minesweeper/minegen"
+)
+
+// openFirst clears the opening around the position before the game's first
+// selection, remembering the layout the game started with so that it can be
+// replayed.
+func (g *Game) openFirst(pos Position) error {
+	if g.config.OpeningSize == 0 || g.layout != nil {
+		return nil
+	} else if _, ok := g.minefield.peek(pos); !ok {
+		return nil
+	}
+	layout := g.minefield.mines()
+	src := SeededSource(openingSeed(layout, pos))
+	if err := g.minefield.clear(g.minefield.area(pos, g.config.OpeningSize), src); err != nil {
+		return err
+	}
+	g.layout = layout
+	return nil
+}
+
+// openingSeed returns the seed of the source that moves the mines out of the
+// opening.  It is drawn from the layout and the first selection, so that a
+// replay of the game moves them to the same blocks.
+func openingSeed(layout []Position, pos Position) int64 {
+	h := fnv.New64a()
+	for _, p := range append([]Position{pos}, layout...) {
+		binary.Write(h, binary.BigEndian, [2]int64{int64(p.X), int64(p.Y)})
+	}
+	return int64(h.Sum64())
+}
+
+// area returns the position along with every block within size steps of it
+// through the neighborhood.
+func (mf *Minefield) area(pos Position, size uint) map[Position]bool {
+	area := map[Position]bool{pos: true}
+	edge := []Position{pos}
+	for ; size > 0; size-- {
+		var next []Position
+		for _, p := range edge {
+			for _, neighbor := range mf.neighborhood.Neighbors(p) {
+				if _, ok := mf.peek(neighbor); ok && !area[neighbor] {
+					area[neighbor] = true
+					next = append(next, neighbor)
+				}
+			}
+		}
+		edge = next
+	}
+	return area
+}
+
+// clear moves the mines in the area to blocks outside it without a mine,
+// drawn from the source, keeping any flags and question marks where they are.
+// Mines that do not fit outside the area are left in place.  The minefield is
+// left as it was if the mines cannot be counted where they are moved to.
+func (mf *Minefield) clear(area map[Position]bool, src RandSource) error {
+	mines := mf.mines()
+	taken := make(map[Position]bool, len(mines))
+	var moving []int
+	for i, pos := range mines {
+		taken[pos] = true
+		if area[pos] {
+			moving = append(moving, i)
+		}
+	}
+	if len(moving) == 0 {
+		return nil
+	}
+	var free []Position
+	mf.each(func(pos Position, block *Block) {
+		if !area[pos] && !taken[pos] {
+			free = append(free, pos)
+		}
+	})
+	for i, j := range minegen.Perm(src, len(free)) {
+		if i == len(moving) {
+			break
+		}
+		mines[moving[i]] = free[j]
+	}
+
+	marked := make(map[Position]Block)
+	mf.stored(func(pos Position, block *Block) {
+		if block.flagged() || block.questioned() {
+			marked[pos] = block.status()
+		}
+	})
+	fresh := newMinefield(mf.neighborhood)
+	fresh.sparse = mf.sparse
+	if _, err := fresh.init(uint(mf.width), uint(mf.height), uint(len(mines)), func(width, height, max uint) ([]Position, error) {
+		return mines, nil
+	}); err != nil {
+		return err
+	}
+	mf.cells, mf.blocks = fresh.cells, fresh.blocks
+	mf.all = true
+	for pos, status := range marked {
+		block, _ := mf.block(pos)
+		block.setStatus(status)
+	}
+	return nil
+}
+
+// Opening is a connected group of 0s, which are all revealed along with the
+// numbers around them by selecting any one of them.
+type Opening struct {
+	// Zeros are the 0s of the opening, ordered by row.
+	Zeros []Position
+
+	// Size is the number of blocks selecting the opening reveals, counting
+	// the numbers around it.
+	Size int
+}
+
+// Openings returns the openings of the minefield, ordered by their first 0.
+func (mf *Minefield) Openings() []Opening {
+	zeros := mf.openings()
+	openings := make([]Opening, len(zeros))
+	for i, opening := range zeros {
+		openings[i] = Opening{Zeros: opening, Size: len(mf.border(opening)) + len(opening)}
+	}
+	return openings
+}
+
+// LargestOpening returns the opening that reveals the most blocks, the first
+// of them if there is a tie, and false if the minefield has no openings.
+func (mf *Minefield) LargestOpening() (Opening, bool) {
+	var largest Opening
+	for _, opening := range mf.Openings() {
+		if opening.Size > largest.Size {
+			largest = opening
+		}
+	}
+	return largest, largest.Size > 0
+}
+
+// OpeningAt returns the opening that selecting the block at the position
+// would reveal it with: the opening it is a 0 of or, for a number, the first
+// opening it borders.  It returns false if no opening reveals the block.
+func (mf *Minefield) OpeningAt(x, y int) (Opening, bool) {
+	pos := Position{X: x, Y: y}
+	for _, opening := range mf.Openings() {
+		for _, zero := range opening.Zeros {
+			if zero == pos {
+				return opening, true
+			}
+		}
+		if mf.border(opening.Zeros)[pos] {
+			return opening, true
+		}
+	}
+	return Opening{}, false
+}
+
+// border returns the numbers around the 0s.
+func (mf *Minefield) border(zeros []Position) map[Position]bool {
+	border := make(map[Position]bool)
+	for _, zero := range zeros {
+		for _, neighbor := range mf.neighborhood.Neighbors(zero) {
+			if block, ok := mf.peek(neighbor); ok && block.proximity() > 0 {
+				border[neighbor] = true
+			}
+		}
+	}
+	return border
+}
@@ -0,0 +1,36 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestMinefield_Openings(c *C) {
+	minefield, err := newMinefield(Surrounding).init(5, 5, 5, func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+	})
+	c.Assert(err, IsNil)
+
+	right := Opening{Zeros: []Position{{X: 4, Y: 2}}, Size: 6}
+	bottom := Opening{Zeros: []Position{{X: 0, Y: 4}, {X: 1, Y: 4}}, Size: 6}
+	c.Check(minefield.Openings(), DeepEquals, []Opening{right, bottom})
+
+	// the first of the largest
+	largest, ok := minefield.LargestOpening()
+	c.Check(ok, Equals, true)
+	c.Check(largest, DeepEquals, right)
+
+	opening, ok := minefield.OpeningAt(1, 4)
+	c.Check(ok, Equals, true)
+	c.Check(opening, DeepEquals, bottom)
+	opening, ok = minefield.OpeningAt(2, 3)
+	c.Check(ok, Equals, true)
+	c.Check(opening, DeepEquals, bottom)
+	_, ok = minefield.OpeningAt(0, 0)
+	c.Check(ok, Equals, false)
+
+	minefield, err = FromLayout(2, 1, []Position{{X: 0, Y: 0}})
+	c.Assert(err, IsNil)
+	c.Check(minefield.Openings(), HasLen, 0)
+	_, ok = minefield.LargestOpening()
+	c.Check(ok, Equals, false)
+}
@@ -0,0 +1,54 @@
+package gominesweeper
+
+import (
+	"time"
+)
+
+// Pause stops the game's clock until Resume is called or, unless
+// BlockPausedMoves is set, the next move is made.  Defusals and combos wait
+// for the game as well.  It returns ErrGameOver if the game is over.
+func (g *Game) Pause() error {
+	if g.expire(); g.state != Playing {
+		return ErrGameOver
+	} else if g.Paused() {
+		return nil
+	}
+	g.paused = g.clock()
+	g.publish(nil, g.Elapsed())
+	return nil
+}
+
+// Resume starts the game's clock again after Pause.  The time spent paused
+// is left out of its Elapsed time, its moves and its result.
+func (g *Game) Resume() {
+	if !g.Paused() {
+		return
+	}
+	pause := g.clock().Sub(g.paused)
+	g.paused = time.Time{}
+	if !g.start.IsZero() {
+		g.start = g.start.Add(pause)
+	}
+	if !g.lastReveal.IsZero() {
+		g.lastReveal = g.lastReveal.Add(pause)
+	}
+	for pos, deadline := range g.defusing {
+		g.defusing[pos] = deadline.Add(pause)
+	}
+	g.publish(nil, g.Elapsed())
+}
+
+// Paused returns true if the game is paused.
+func (g *Game) Paused() bool {
+	return !g.paused.IsZero()
+}
+
+// unpause resumes the game before a move, or returns ErrPaused if moves are
+// blocked while it is paused.
+func (g *Game) unpause() error {
+	if g.Paused() && g.config.BlockPausedMoves {
+		return ErrPaused
+	}
+	g.Resume()
+	return nil
+}
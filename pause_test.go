@@ -0,0 +1,62 @@
+package gominesweeper
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_Pause(c *C) {
+	now := time.Unix(0, 0)
+	game := newTestGame(c, Config{DefuseTime: 10 * time.Second})
+	game.clock = func() time.Time { return now }
+	var events []Event
+	game.Subscribe(func(event Event) {
+		events = append(events, event)
+	})
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	now = now.Add(5 * time.Second)
+	c.Assert(game.Pause(), IsNil)
+	c.Check(game.Paused(), Equals, true)
+	c.Check(events[len(events)-1].Paused, Equals, true)
+
+	// the clock and the defusal wait while paused
+	now = now.Add(time.Minute)
+	c.Check(game.Elapsed(), Equals, 5*time.Second)
+	c.Check(game.Tick(), Equals, Playing)
+	c.Check(game.Display()[Position{X: 0, Y: 0}], Equals, Flagged)
+
+	game.Resume()
+	c.Check(game.Paused(), Equals, false)
+	c.Check(events[len(events)-1].Paused, Equals, false)
+	now = now.Add(time.Second)
+	c.Check(game.Elapsed(), Equals, 6*time.Second)
+	c.Assert(game.Defuse(0, 0, true), IsNil)
+
+	// a move resumes the game
+	c.Assert(game.Pause(), IsNil)
+	now = now.Add(time.Hour)
+	_, err = game.Select(0, 4)
+	c.Assert(err, IsNil)
+	c.Check(game.Paused(), Equals, false)
+	moves := game.Replay().Moves
+	c.Check(moves[len(moves)-1].Elapsed, Equals, 6*time.Second)
+}
+
+func (s *MSSuite) TestGame_BlockPausedMoves(c *C) {
+	game := newTestGame(c, Config{BlockPausedMoves: true})
+	game.clock = fakeClock()
+	c.Assert(game.Pause(), IsNil)
+	_, err := game.Select(4, 2)
+	c.Check(err, Equals, ErrPaused)
+	c.Check(game.ToggleFlag(0, 0), Equals, ErrPaused)
+	c.Check(game.Replay().Moves, HasLen, 0)
+
+	game.Resume()
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(game.Pause(), Equals, ErrGameOver)
+}
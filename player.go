@@ -0,0 +1,140 @@
+package gominesweeper
+
+// Player decides the next move to make on a game.
+type Player interface {
+	NextMove(snapshot Snapshot) Move
+}
+
+// SolverBot is a Player that reveals the blocks the Solver knows to be safe
+// and, when there are none, guesses the block least likely to be a mine.
+type SolverBot struct {
+	rand RandSource
+}
+
+// NewSolverBot returns a bot that breaks ties between guesses using the seed.
+func NewSolverBot(seed int64) *SolverBot {
+	return NewSolverBotFrom(SeededSource(seed))
+}
+
+// NewSolverBotFrom returns a bot that breaks ties between guesses by drawing
+// from the source.
+func NewSolverBotFrom(src RandSource) *SolverBot {
+	return &SolverBot{src}
+}
+
+// NextMove reveals a safe block, or makes the best guess there is.
+func (b *SolverBot) NextMove(snapshot Snapshot) Move {
+	solver := NewSolver(snapshot)
+	if safe := solver.Safe(); len(safe) > 0 {
+		return Move{Reveal, safe[0]}
+	}
+
+	var best []Position
+	bestRisk := 2.0
+	for _, pos := range b.hidden(snapshot, solver) {
+		if risk := solver.Risk(pos); risk < bestRisk {
+			best, bestRisk = []Position{pos}, risk
+		} else if risk == bestRisk {
+			best = append(best, pos)
+		}
+	}
+	if len(best) == 0 {
+		return Move{Reveal, Position{X: snapshot.Width / 2, Y: snapshot.Height / 2}}
+	}
+	return Move{Reveal, best[b.rand.Intn(len(best))]}
+}
+
+// hidden returns the blocks that can be guessed, ordered by row.
+func (b *SolverBot) hidden(snapshot Snapshot, solver *Solver) []Position {
+	var hidden []Position
+	for pos := range snapshot.Blocks {
+		if solver.hidden(pos) {
+			hidden = append(hidden, pos)
+		}
+	}
+	sortPositions(hidden)
+	return hidden
+}
+
+// Risk estimates how likely the hidden block at the position is to be a mine:
+// 0 or 1 if it has been deduced, otherwise the worst odds of any number
+// bordering it, or the odds of the hidden blocks overall if no number does.
+func (s *Solver) Risk(pos Position) float64 {
+	if s.safe[pos] {
+		return 0
+	} else if s.mines[pos] {
+		return 1
+	}
+
+	risk, bordered := 0.0, false
+	for _, c := range s.constraints {
+		if c.positions[pos] {
+			bordered = true
+			if odds := float64(c.mines) / float64(len(c.positions)); odds > risk {
+				risk = odds
+			}
+		}
+	}
+	if bordered {
+		return risk
+	}
+
+	hidden, mines := 0, s.snapshot.Mines
+	for pos := range s.snapshot.Blocks {
+		if s.hidden(pos) {
+			hidden++
+		} else if s.known(pos) {
+			mines--
+		}
+	}
+	if hidden == 0 {
+		return 1
+	}
+	return float64(mines) / float64(hidden)
+}
+
+// RunResult is the outcome of running a Player against several games.
+type RunResult struct {
+	Games, Wins int
+}
+
+// WinRate returns the fraction of games that were won.
+func (r RunResult) WinRate() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.Wins) / float64(r.Games)
+}
+
+// RunGames has the player play a game for each seed, with the mines placed by the
+// SeededSelector, and reports how many it won.  A game where the player stops
+// making progress is counted as a loss.  The sim package plays many such
+// games at once.
+func RunGames(player Player, cfg Config, seeds ...int64) (RunResult, error) {
+	var result RunResult
+	for _, seed := range seeds {
+		cfg.Selector = SeededSelector(seed)
+		game, err := NewGame(cfg)
+		if err != nil {
+			return result, err
+		}
+
+		result.Games++
+		if Play(player, game) == Won {
+			result.Wins++
+		}
+	}
+	return result, nil
+}
+
+// Play has the player make moves on the game until it is over, or until the
+// player makes more moves than there are blocks twice over, and returns the
+// state of the game.
+func Play(player Player, game *Game) State {
+	width, height := game.minefield.size()
+	limit := 2 * width * height
+	for i := 0; i < limit && game.State() == Playing; i++ {
+		game.Apply(player.NextMove(game.Snapshot()))
+	}
+	return game.State()
+}
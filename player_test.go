@@ -0,0 +1,40 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestSolverBot(c *C) {
+	game := newTestGame(c, Config{})
+	bot := NewSolverBot(1)
+
+	// the first move is a guess
+	move := bot.NextMove(game.Snapshot())
+	c.Check(move.Action, Equals, Reveal)
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(bot.NextMove(game.Snapshot()), Equals, Move{Reveal, Position{X: 2, Y: 0}})
+}
+
+func (s *MSSuite) TestRunGames(c *C) {
+	seeds := make([]int64, 20)
+	for i := range seeds {
+		seeds[i] = int64(i)
+	}
+	cfg := Config{Width: 9, Height: 9, Mines: 10}
+
+	result, err := RunGames(NewSolverBot(1), cfg, seeds...)
+	c.Assert(err, IsNil)
+	c.Check(result.Games, Equals, 20)
+	c.Check(result.WinRate() > 0.5, Equals, true, Commentf("won %d of %d", result.Wins, result.Games))
+
+	// the same seeds give the same results
+	again, err := RunGames(NewSolverBot(1), cfg, seeds...)
+	c.Assert(err, IsNil)
+	c.Check(again, Equals, result)
+
+	_, err = RunGames(NewSolverBot(1), Config{Width: 2, Height: 2, Mines: 5}, 1)
+	c.Check(err, Equals, ErrExceedDimensions)
+	c.Check(RunResult{}.WinRate(), Equals, 0.0)
+}
@@ -0,0 +1,48 @@
+package gominesweeper
+
+import (
+	"github.com/smousa/go-minesweeper/minesolve"
+)
+
+// Probabilities returns the odds of every hidden block being a mine, counting
+// every placement of the remaining mines that agrees with the revealed numbers
+// as equally likely.  Blocks that have been deduced are 0 or 1.  If the hidden
+// blocks bordering numbers are too entangled to count, or no placement agrees
+// with the numbers, the odds are the solver's Risk instead.
+func (s *Solver) Probabilities() map[Position]float64 {
+	probabilities := make(map[Position]float64)
+	problem := minesolve.Problem{Mines: s.snapshot.Mines}
+	for pos, value := range s.snapshot.Blocks {
+		if s.hidden(pos) {
+			problem.Unknown = append(problem.Unknown, cell(pos))
+		} else if s.known(pos) {
+			problem.Mines--
+		}
+		if (value == Unknown || value == Questioned) && (s.safe[pos] || s.mines[pos]) {
+			probabilities[pos] = s.Risk(pos)
+		}
+	}
+	for _, c := range s.constraints {
+		constraint := minesolve.Constraint{Mines: c.mines}
+		for pos := range c.positions {
+			constraint.Cells = append(constraint.Cells, cell(pos))
+		}
+		problem.Constraints = append(problem.Constraints, constraint)
+	}
+
+	solution, err := minesolve.Solve(problem)
+	for _, c := range problem.Unknown {
+		pos := Position{X: c.X, Y: c.Y}
+		if err != nil || !solution.Exact {
+			probabilities[pos] = s.Risk(pos)
+		} else {
+			probabilities[pos] = solution.Probabilities[c]
+		}
+	}
+	return probabilities
+}
+
+// cell returns the position as a cell for the minesolve package.
+func cell(pos Position) minesolve.Cell {
+	return minesolve.Cell{X: pos.X, Y: pos.Y}
+}
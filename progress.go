@@ -0,0 +1,35 @@
+package gominesweeper
+
+// Progress measures how much of a game has been cleared.
+type Progress struct {
+	// Revealed is the number of blocks without mines that have been
+	// revealed, out of the Safe blocks there are.
+	Revealed, Safe int
+
+	// BBBV is the 3BV of the minefield, of which Solved has been cleared.
+	BBBV, Solved int
+}
+
+// Fraction returns the fraction of safe blocks that have been revealed.
+func (p Progress) Fraction() float64 {
+	if p.Safe == 0 {
+		return 1
+	}
+	return float64(p.Revealed) / float64(p.Safe)
+}
+
+// Remaining returns the 3BV that is left to clear.
+func (p Progress) Remaining() int {
+	return p.BBBV - p.Solved
+}
+
+// Progress returns how much of the game has been cleared.
+func (g *Game) Progress() Progress {
+	bbbv, solved := g.minefield.bbbv()
+	return Progress{
+		Revealed: g.minefield.revealed(),
+		Safe:     g.minefield.safe(),
+		BBBV:     bbbv,
+		Solved:   solved,
+	}
+}
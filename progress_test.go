@@ -0,0 +1,24 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_Progress(c *C) {
+	game := newTestGame(c, Config{})
+	progress := game.Progress()
+	c.Check(progress, Equals, Progress{Revealed: 0, Safe: 20, BBBV: 10, Solved: 0})
+	c.Check(progress.Fraction(), Equals, 0.0)
+	c.Check(progress.Remaining(), Equals, 10)
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	_, err = game.Select(1, 1)
+	c.Assert(err, IsNil)
+	progress = game.Progress()
+	c.Check(progress, Equals, Progress{Revealed: 7, Safe: 20, BBBV: 10, Solved: 2})
+	c.Check(progress.Fraction(), Equals, 0.35)
+	c.Check(progress.Remaining(), Equals, 8)
+
+	c.Check(Progress{}.Fraction(), Equals, 1.0)
+}
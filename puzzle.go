@@ -0,0 +1,106 @@
+package gominesweeper
+
+import (
+	"github.com/smousa/go-minesweeper/minegen"
+	"github.com/smousa/go-minesweeper/minesolve"
+)
+
+// Puzzle is a minefield with some of its blocks revealed from the start, so
+// that where the rest of its mines are can be worked out from the revealed
+// numbers alone.
+type Puzzle struct {
+	Minefield *Minefield
+
+	// Revealed are the blocks revealed from the start, ordered by row.
+	Revealed []Position
+}
+
+// NewPuzzle returns a puzzle of the minefield that reveals as few blocks as
+// it can while the mines keep a unique solution.  Starting with every block
+// without a mine revealed, it hides them one at a time in an order drawn from
+// the source, keeping each one hidden unless that would allow a second
+// solution.
+func NewPuzzle(mf *Minefield, src RandSource) Puzzle {
+	var safe []Position
+	mf.each(func(pos Position, block *Block) {
+		if block.proximity() != Mine {
+			safe = append(safe, pos)
+		}
+	})
+
+	revealed := make([]bool, len(safe))
+	for i := range revealed {
+		revealed[i] = true
+	}
+	positions := func() []Position {
+		var positions []Position
+		for i, pos := range safe {
+			if revealed[i] {
+				positions = append(positions, pos)
+			}
+		}
+		return positions
+	}
+	for _, i := range minegen.Perm(src, len(safe)) {
+		revealed[i] = false
+		if VerifyUniqueSolution(mf, positions()) != nil {
+			revealed[i] = true
+		}
+	}
+	return Puzzle{Minefield: mf, Revealed: positions()}
+}
+
+// VerifyUniqueSolution checks that exactly one placement of the minefield's
+// mines among its hidden blocks agrees with the numbers of the revealed
+// blocks, so that a puzzle can be solved without guessing.  It returns
+// ErrRevealedMine if a mine is revealed, an OutOfBoundsError for a position
+// off the minefield, and ErrNotUnique if there is more than one solution or
+// there are too many placements to count.
+func VerifyUniqueSolution(mf *Minefield, revealed []Position) error {
+	shown := make(map[Position]bool, len(revealed))
+	for _, pos := range revealed {
+		block, ok := mf.peek(pos)
+		if !ok {
+			return mf.outOfBounds(pos)
+		} else if block.proximity() == Mine {
+			return ErrRevealedMine
+		}
+		shown[pos] = true
+	}
+
+	problem := minesolve.Problem{Mines: len(mf.mines())}
+	mf.each(func(pos Position, block *Block) {
+		if !shown[pos] {
+			problem.Unknown = append(problem.Unknown, cell(pos))
+			return
+		}
+		constraint := minesolve.Constraint{Mines: block.proximity()}
+		for _, neighbor := range mf.neighborhood.Neighbors(pos) {
+			if mf.contains(neighbor) && !shown[neighbor] {
+				constraint.Cells = append(constraint.Cells, cell(neighbor))
+			}
+		}
+		problem.Constraints = append(problem.Constraints, constraint)
+	})
+
+	solution, err := minesolve.Solve(problem)
+	if err != nil || !solution.Exact || len(solution.Safe)+len(solution.Mines) != len(problem.Unknown) {
+		return ErrNotUnique
+	}
+	return nil
+}
+
+// Game starts a game of the puzzle with its blocks revealed, configured by
+// the rest of the config.
+func (p Puzzle) Game(cfg Config) *Game {
+	minefield := p.Minefield.Clone()
+	minefield.Reset()
+	for _, pos := range p.Revealed {
+		if block, ok := minefield.block(pos); ok {
+			block.Select()
+		}
+	}
+	cfg.Width, cfg.Height = uint(minefield.width), uint(minefield.height)
+	cfg.Mines = uint(len(minefield.mines()))
+	return newGame(minefield, cfg)
+}
@@ -0,0 +1,67 @@
+package gominesweeper
+
+import (
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestVerifyUniqueSolution(c *C) {
+	mf, err := FromLayout(5, 5, []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}})
+	c.Assert(err, IsNil)
+
+	var safe []Position
+	mf.each(func(pos Position, block *Block) {
+		if block.proximity() != Mine {
+			safe = append(safe, pos)
+		}
+	})
+	c.Check(VerifyUniqueSolution(mf, safe), IsNil)
+	c.Check(VerifyUniqueSolution(mf, nil), Equals, ErrNotUnique)
+
+	// a single number cannot place all the mines
+	c.Check(VerifyUniqueSolution(mf, []Position{{X: 0, Y: 2}}), Equals, ErrNotUnique)
+
+	c.Check(VerifyUniqueSolution(mf, []Position{{X: 0, Y: 0}}), Equals, ErrRevealedMine)
+	err = VerifyUniqueSolution(mf, []Position{{X: 5, Y: 0}})
+	c.Check(errors.Is(err, ErrOutOfBounds), Equals, true)
+}
+
+func (s *MSSuite) TestNewPuzzle(c *C) {
+	mf, err := NewMinefieldConfig(Config{Width: 9, Height: 9, Mines: 10, Selector: SeededSelector(7)})
+	c.Assert(err, IsNil)
+
+	puzzle := NewPuzzle(mf, SeededSource(1))
+	c.Check(VerifyUniqueSolution(mf, puzzle.Revealed), IsNil)
+	c.Check(len(puzzle.Revealed) < 71, Equals, true)
+	c.Check(NewPuzzle(mf, SeededSource(1)).Revealed, DeepEquals, puzzle.Revealed)
+
+	// every revealed block is needed
+	for i := range puzzle.Revealed {
+		fewer := append(append([]Position(nil), puzzle.Revealed[:i]...), puzzle.Revealed[i+1:]...)
+		c.Check(VerifyUniqueSolution(mf, fewer), Equals, ErrNotUnique, Commentf("%v", puzzle.Revealed[i]))
+	}
+
+	// the game starts with only the revealed blocks showing
+	game := puzzle.Game(Config{})
+	snapshot := game.Snapshot()
+	c.Check(snapshot.Mines, Equals, 10)
+	hidden := 0
+	for _, value := range snapshot.Blocks {
+		if value == Unknown {
+			hidden++
+		}
+	}
+	c.Check(hidden, Equals, 81-len(puzzle.Revealed))
+
+	for _, pos := range mf.mines() {
+		c.Assert(game.ToggleFlag(pos.X, pos.Y), IsNil)
+	}
+	mf.each(func(pos Position, block *Block) {
+		if block.proximity() != Mine {
+			game.Select(pos.X, pos.Y)
+		}
+	})
+	c.Check(game.State(), Equals, Won)
+	c.Check(mf.revealed(), Equals, 0)
+}
@@ -0,0 +1,28 @@
+package gominesweeper
+
+import (
+	"github.com/smousa/go-minesweeper/minegen"
+)
+
+// RandSource is a source of random numbers for placing mines and breaking
+// ties.  A *rand.Rand from math/rand is a RandSource.
+type RandSource = minegen.RandSource
+
+// SeededSource returns a source that always gives the same numbers for the
+// same seed, as used by SeededSelector and the other seeded generators.
+func SeededSource(seed int64) RandSource {
+	return minegen.SeededSource(seed)
+}
+
+// CryptoSource returns a source that draws from crypto/rand, so that boards
+// cannot be predicted from the ones before them, e.g. for tournaments.
+func CryptoSource() RandSource {
+	return minegen.CryptoSource()
+}
+
+// FixedSource returns a source that gives the numbers in turn, starting over
+// once they run out, so that tests and fuzzers can decide exactly where the
+// mines go; see minegen.FixedSource.
+func FixedSource(numbers ...int64) RandSource {
+	return minegen.FixedSource(numbers...)
+}
@@ -0,0 +1,63 @@
+package gominesweeper
+
+import (
+	"time"
+)
+
+// RateOfPlay is how fast a game is being played, reported periodically when
+// Config.OnRateOfPlay is set.
+type RateOfPlay struct {
+	State    State
+	Elapsed  time.Duration
+	Progress Progress
+
+	// Moves is the number of moves made so far, and Recent the number made
+	// since the last report.
+	Moves, Recent int
+
+	// APM is the moves per minute over the whole game, and RecentAPM over
+	// the time since the last report.
+	APM, RecentAPM float64
+
+	// Hesitation is the longest wait before a move made since the last
+	// report, and Idle is the time since the last move.
+	Hesitation, Idle time.Duration
+}
+
+// reportRate reports the rate of play if an interval has passed since the
+// last report, or the game is over and has not been reported as such.
+func (g *Game) reportRate(elapsed time.Duration) {
+	if g.config.OnRateOfPlay == nil || g.start.IsZero() || g.rateDone {
+		return
+	} else if g.state == Playing && elapsed-g.rateElapsed < g.config.RateOfPlayInterval {
+		return
+	}
+
+	rate := RateOfPlay{
+		State:    g.state,
+		Elapsed:  elapsed,
+		Progress: g.Progress(),
+		Moves:    len(g.moves),
+		Recent:   len(g.moves) - g.rateMoves,
+	}
+	if elapsed > 0 {
+		rate.APM = float64(rate.Moves) / elapsed.Minutes()
+	}
+	if since := elapsed - g.rateElapsed; since > 0 {
+		rate.RecentAPM = float64(rate.Recent) / since.Minutes()
+	}
+	for i := g.rateMoves; i < len(g.moves); i++ {
+		wait := g.moves[i].Elapsed
+		if i > 0 {
+			wait -= g.moves[i-1].Elapsed
+		}
+		rate.Hesitation = max(rate.Hesitation, wait)
+	}
+	if len(g.moves) > 0 {
+		rate.Idle = elapsed - g.moves[len(g.moves)-1].Elapsed
+	}
+
+	g.rateElapsed, g.rateMoves = elapsed, len(g.moves)
+	g.rateDone = g.state != Playing
+	g.config.OnRateOfPlay(g, rate)
+}
@@ -0,0 +1,65 @@
+package gominesweeper
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_RateOfPlay(c *C) {
+	var rates []RateOfPlay
+	game := newTestGame(c, Config{
+		Lives:              2,
+		RateOfPlayInterval: 3 * time.Second,
+		OnRateOfPlay: func(g *Game, rate RateOfPlay) {
+			rates = append(rates, rate)
+		},
+	})
+	game.clock = fakeClock()
+
+	// moves at 0s, 1s and 2s are not reported until 3s have passed
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	_, err = game.Select(4, 4)
+	c.Assert(err, IsNil)
+	c.Check(rates, HasLen, 0)
+	c.Check(game.Tick(), Equals, Playing)
+	c.Assert(rates, HasLen, 1)
+	c.Check(rates[0], DeepEquals, RateOfPlay{
+		State:     Playing,
+		Elapsed:   3 * time.Second,
+		Progress:  game.Progress(),
+		Moves:     3,
+		Recent:    3,
+		APM:       60,
+		RecentAPM: 60,
+		// the first move starts the clock, so it takes no time
+		Hesitation: time.Second,
+		Idle:       time.Second,
+	})
+
+	// waiting counts towards the hesitation before the next move, which is
+	// reported after another 3s
+	c.Check(game.Tick(), Equals, Playing)
+	c.Check(game.Tick(), Equals, Playing)
+	_, err = game.Select(2, 1)
+	c.Assert(err, IsNil)
+	c.Assert(rates, HasLen, 2)
+	c.Check(rates[1].Elapsed, Equals, 6*time.Second)
+	c.Check(rates[1].Recent, Equals, 1)
+	c.Check(rates[1].RecentAPM, Equals, 20.0)
+	c.Check(rates[1].Hesitation, Equals, 4*time.Second)
+	c.Check(rates[1].Idle, Equals, time.Duration(0))
+
+	// the end of the game is reported straight away, and only once
+	_, err = game.Select(1, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.State(), Equals, Lost)
+	game.Tick()
+	c.Assert(rates, HasLen, 3)
+	c.Check(rates[2].State, Equals, Lost)
+	c.Check(rates[2].Elapsed, Equals, 7*time.Second)
+	c.Check(rates[2].Moves, Equals, 5)
+	c.Check(rates[2].Recent, Equals, 1)
+}
@@ -0,0 +1,161 @@
+package gominesweeper
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Renderer draws what a player can see of a game, e.g. as text or an image.
+type Renderer func(w io.Writer, s Snapshot) error
+
+// registry holds everything registered by name, so that it can be chosen in
+// configuration such as the server's requests.
+var registry = struct {
+	sync.RWMutex
+	selectors map[string]Selector
+	rules     map[string]Rules
+	renderers map[string]Renderer
+}{
+	selectors: make(map[string]Selector),
+	rules:     make(map[string]Rules),
+	renderers: make(map[string]Renderer),
+}
+
+func init() {
+	RegisterSelector("random", RandomSelector)
+	RegisterRules(string(Beginner), Rules{Width: 9, Height: 9, Mines: 10, Win: "clear", Lives: 1})
+	RegisterRules(string(Intermediate), Rules{Width: 16, Height: 16, Mines: 40, Win: "clear", Lives: 1})
+	RegisterRules(string(Expert), Rules{Width: 30, Height: 16, Mines: 99, Win: "clear", Lives: 1})
+	RegisterRenderer("text", RenderText)
+}
+
+// RegisterSelector makes the selector available by name.  It panics if the
+// name is already taken or the selector is nil, and is meant to be called
+// from a package's init function.
+func RegisterSelector(name string, selector Selector) {
+	if selector == nil {
+		panic("gominesweeper: RegisterSelector selector is nil")
+	}
+	register(registry.selectors, name, selector)
+}
+
+// LookupSelector returns the selector registered with the name, or
+// ErrUnknownName.
+func LookupSelector(name string) (Selector, error) {
+	return lookup(registry.selectors, name)
+}
+
+// Selectors returns the names of the registered selectors, in order.
+func Selectors() []string {
+	return names(registry.selectors)
+}
+
+// RegisterRules makes the rules available by name, e.g. as a difficulty.  It
+// panics if the name is already taken.
+func RegisterRules(name string, rules Rules) {
+	register(registry.rules, name, rules)
+}
+
+// LookupRules returns the rules registered with the name, or ErrUnknownName.
+func LookupRules(name string) (Rules, error) {
+	return lookup(registry.rules, name)
+}
+
+// RulesNames returns the names of the registered rules, in order.
+func RulesNames() []string {
+	return names(registry.rules)
+}
+
+// RegisterRenderer makes the renderer available by name.  It panics if the
+// name is already taken or the renderer is nil.
+func RegisterRenderer(name string, renderer Renderer) {
+	if renderer == nil {
+		panic("gominesweeper: RegisterRenderer renderer is nil")
+	}
+	register(registry.renderers, name, renderer)
+}
+
+// LookupRenderer returns the renderer registered with the name, or
+// ErrUnknownName.
+func LookupRenderer(name string) (Renderer, error) {
+	return lookup(registry.renderers, name)
+}
+
+// Renderers returns the names of the registered renderers, in order.
+func Renderers() []string {
+	return names(registry.renderers)
+}
+
+// register adds the value to the registry under the name.
+func register[T any](m map[string]T, name string, value T) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := m[name]; ok {
+		panic("gominesweeper: " + name + " is already registered")
+	}
+	m[name] = value
+}
+
+// lookup returns the value in the registry under the name.
+func lookup[T any](m map[string]T, name string) (T, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	value, ok := m[name]
+	if !ok {
+		return value, ErrUnknownName
+	}
+	return value, nil
+}
+
+// names returns the names in the registry, in order.
+func names[T any](m map[string]T) []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RenderText draws the snapshot as a grid of text, one row per line: '#' is a
+// hidden block, 'F' a flag, 'Q' a question mark, '*' a mine, 'X' the mine that
+// went off, 'x' a wrong flag, 'D' a defused mine, '?' a revealed block in
+// blind mode, '.' a block without any mines in its proximity and '+' one with
+// more than 9.
+func RenderText(w io.Writer, s Snapshot) error {
+	buf := bufio.NewWriter(w)
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			switch value := s.Blocks[Position{X: x, Y: y}]; {
+			case value == Unknown:
+				buf.WriteByte('#')
+			case value == Flagged:
+				buf.WriteByte('F')
+			case value == Questioned:
+				buf.WriteByte('Q')
+			case value == Mine:
+				buf.WriteByte('*')
+			case value == Exploded:
+				buf.WriteByte('X')
+			case value == WrongFlag:
+				buf.WriteByte('x')
+			case value == Defused:
+				buf.WriteByte('D')
+			case value == Revealed:
+				buf.WriteByte('?')
+			case value == 0:
+				buf.WriteByte('.')
+			case value > 9:
+				buf.WriteByte('+')
+			default:
+				buf.WriteByte('0' + byte(value))
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Flush()
+}
@@ -0,0 +1,48 @@
+package gominesweeper
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestRegistry(c *C) {
+	RegisterSelector("test-corner", func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 0, Y: 0}}, nil
+	})
+	selector, err := LookupSelector("test-corner")
+	c.Assert(err, IsNil)
+	points, err := selector(3, 3, 1)
+	c.Assert(err, IsNil)
+	c.Check(points, DeepEquals, []Position{{X: 0, Y: 0}})
+	c.Check(Selectors(), DeepEquals, []string{"random", "test-corner"})
+	c.Check(func() { RegisterSelector("test-corner", RandomSelector) }, PanicMatches, ".*already registered")
+
+	_, err = LookupSelector("bogus")
+	c.Check(err, Equals, ErrUnknownName)
+
+	rules, err := LookupRules("expert")
+	c.Assert(err, IsNil)
+	cfg, err := rules.Config()
+	c.Assert(err, IsNil)
+	c.Check([]uint{cfg.Width, cfg.Height, cfg.Mines}, DeepEquals, []uint{30, 16, 99})
+	c.Check(RulesNames(), DeepEquals, []string{"beginner", "expert", "intermediate"})
+
+	_, err = LookupRenderer("text")
+	c.Check(err, IsNil)
+	_, err = LookupRenderer("bogus")
+	c.Check(err, Equals, ErrUnknownName)
+}
+
+func (s *MSSuite) TestRenderText(c *C) {
+	game := newTestGame(c, Config{})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(1, 1), IsNil)
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+
+	var buf strings.Builder
+	c.Assert(RenderText(&buf, game.Snapshot()), IsNil)
+	c.Check(buf.String(), Equals, "X###*\n#x*21\n#*#1.\n###11\n###*#\n")
+}
@@ -0,0 +1,258 @@
+package gominesweeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+// ReplayVersion is the version of the rules that replays are recorded with.
+// Replays recorded before versioning are version 0.
+const ReplayVersion = 1
+
+// Action is something a player can do to a block.
+type Action int
+
+const (
+	Reveal Action = iota
+	Flag
+	Defuse
+	Detonate
+	Chord
+	Question
+)
+
+// MarshalText encodes the action as its name.
+func (a Action) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes the action from its name.
+func (a *Action) UnmarshalText(text []byte) error {
+	for _, action := range []Action{Reveal, Flag, Defuse, Detonate, Chord, Question} {
+		if action.String() == string(text) {
+			*a = action
+			return nil
+		}
+	}
+	return ErrUnknownName
+}
+
+// String returns the name of the action.
+func (a Action) String() string {
+	switch a {
+	case Reveal:
+		return "reveal"
+	case Flag:
+		return "flag"
+	case Defuse:
+		return "defuse"
+	case Detonate:
+		return "detonate"
+	case Chord:
+		return "chord"
+	case Question:
+		return "question"
+	}
+	return "unknown"
+}
+
+// Move is an action taken on the block at a position.
+type Move struct {
+	Action Action
+	Position
+}
+
+// Record is a move along with the time it was made, relative to the first
+// move of the game.
+type Record struct {
+	Move
+	Elapsed time.Duration
+}
+
+// Replay holds everything needed to play a game back on the same minefield.
+type Replay struct {
+	Version int
+	Rules   Rules
+
+	// Layout is the position of every mine, ordered by row.
+	Layout []Position
+
+	Moves []Record
+}
+
+// Replay returns the replay of the game so far.  Its Layout shows where every
+// mine is, even while the game is still being played.
+func (g *Game) Replay() Replay {
+	moves := make([]Record, len(g.moves))
+	copy(moves, g.moves)
+	layout := g.layout
+	if layout == nil {
+		layout = g.minefield.mines()
+	}
+	return Replay{
+		Version: ReplayVersion,
+		Rules:   rulesOf(g.config),
+		Layout:  layout,
+		Moves:   moves,
+	}
+}
+
+// ReadReplay reads a replay that was written with Write, adapting it to the
+// current version of the rules.
+func ReadReplay(r io.Reader) (Replay, error) {
+	var replay Replay
+	if err := json.NewDecoder(r).Decode(&replay); err != nil {
+		return Replay{}, err
+	}
+	return replay.Upgrade()
+}
+
+// Write writes the replay as JSON.
+func (r Replay) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r)
+}
+
+// Upgrade adapts a replay recorded with an older version of the rules to the
+// current version.  It returns ErrReplayVersion for replays recorded with a
+// newer version, which cannot be played back faithfully.
+func (r Replay) Upgrade() (Replay, error) {
+	if r.Version > ReplayVersion {
+		return Replay{}, ErrReplayVersion
+	}
+
+	// version 0 predates the win condition and lives being recorded, so
+	// they can only have been the defaults
+	if r.Version < 1 {
+		if r.Rules.Win == "" {
+			r.Rules.Win = ClearAll.(fmt.Stringer).String()
+		}
+		if r.Rules.Lives == 0 {
+			r.Rules.Lives = 1
+		}
+		r.Version = 1
+	}
+	return r, nil
+}
+
+// NewGame starts a new game on the replay's minefield, without any of its
+// moves played.  It returns ErrReplayVersion if the replay needs to be
+// upgraded first, or ErrUnknownName if it was played with a custom win
+// condition.
+func (r Replay) NewGame() (*Game, error) {
+	if r.Version != ReplayVersion {
+		return nil, ErrReplayVersion
+	}
+	cfg, err := r.Rules.Config()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Selector = func(width, height, max uint) ([]Position, error) {
+		return r.Layout, nil
+	}
+	return NewGame(cfg)
+}
+
+// Play starts a new game on the replay's minefield and plays every move back
+// at the time it was recorded.
+func (r Replay) Play() (*Game, error) {
+	game, err := r.NewGame()
+	if err != nil {
+		return nil, err
+	}
+	if err := game.playback(r.Moves); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// Resume plays the replay back like Play, then carries the game on from its
+// last move on the real clock, as if that move had just been made.  It is how
+// a game that was saved as a replay is picked up again, e.g. by another
+// server.  The hooks of the config that are not part of the rules, such as
+// its Metrics, MoveLimit, ValidateMove and OnEnd, are used from then on.
+func (r Replay) Resume(hooks Config) (*Game, error) {
+	game, err := r.Play()
+	if err != nil {
+		return nil, err
+	}
+
+	last := time.Unix(0, 0)
+	if len(r.Moves) > 0 {
+		last = last.Add(r.Moves[len(r.Moves)-1].Elapsed)
+	}
+	game.shiftClock(time.Since(last))
+	game.clock = time.Now
+
+	game.config.OnDefuse = hooks.OnDefuse
+	game.config.OnEnd = hooks.OnEnd
+	game.config.OnRateOfPlay = hooks.OnRateOfPlay
+	game.config.RateOfPlayInterval = hooks.RateOfPlayInterval
+	game.config.BlockPausedMoves = hooks.BlockPausedMoves
+	game.config.MoveLimit = hooks.MoveLimit
+	game.config.ValidateMove = hooks.ValidateMove
+	game.config.Metrics = hooks.Metrics
+	return game, nil
+}
+
+// shiftClock moves every time the game has kept on by the duration.
+func (g *Game) shiftClock(d time.Duration) {
+	for _, t := range []*time.Time{&g.start, &g.end, &g.paused, &g.lastReveal} {
+		if !t.IsZero() {
+			*t = t.Add(d)
+		}
+	}
+	for i := range g.recent {
+		g.recent[i] = g.recent[i].Add(d)
+	}
+	for pos, deadline := range g.defusing {
+		g.defusing[pos] = deadline.Add(d)
+	}
+}
+
+// Verify plays the replay back and returns the game as it was left.  It
+// returns ErrReplayMismatch unless the replay was played by the config's rules
+// on the minefield its Selector places, which must always place the mines in
+// the same positions.
+func (r Replay) Verify(cfg Config) (*Game, error) {
+	game, err := NewGame(cfg)
+	if err != nil {
+		return nil, err
+	} else if !r.Matches(game) || !reflect.DeepEqual(r.Rules, game.Replay().Rules) {
+		return nil, ErrReplayMismatch
+	}
+	return r.Play()
+}
+
+// Matches returns true if the game is being played on the replay's minefield.
+func (r Replay) Matches(g *Game) bool {
+	if r.Rules.Width != g.config.Width || r.Rules.Height != g.config.Height {
+		return false
+	}
+	layout := g.minefield.mines()
+	if len(r.Layout) != len(layout) {
+		return false
+	}
+	for i := range layout {
+		if r.Layout[i] != layout[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// playback makes the moves on the game, on a clock stopped at the time of each
+// move.
+func (g *Game) playback(moves []Record) error {
+	var now time.Time
+	g.clock = func() time.Time { return now }
+	for _, record := range moves {
+		now = time.Unix(0, 0).Add(record.Elapsed)
+		if _, err := g.Apply(record.Move); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package gominesweeper
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+// fakeClock returns a clock that moves forward a second each time it is read.
+func fakeClock() func() time.Time {
+	now := time.Unix(0, 0)
+	return func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+}
+
+func (s *MSSuite) TestGame_Replay(c *C) {
+	game := newTestGame(c, Config{Lives: 2})
+	game.clock = fakeClock()
+	c.Check(game.Elapsed(), Equals, time.Duration(0))
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(game.ToggleFlag(7, 7), DeepEquals, &OutOfBoundsError{Position{X: 7, Y: 7}, 5, 5})
+
+	replay := game.Replay()
+	c.Check(replay.Version, Equals, ReplayVersion)
+	c.Check(replay.Rules.Width, Equals, uint(5))
+	c.Check(replay.Rules.Lives, Equals, uint(2))
+	c.Check(replay.Rules.Win, Equals, "clear")
+	c.Check(replay.Layout, DeepEquals, []Position{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 4}})
+	c.Check(replay.Moves, DeepEquals, []Record{
+		{Move{Reveal, Position{X: 4, Y: 2}}, 0},
+		{Move{Flag, Position{X: 4, Y: 0}}, time.Second},
+		{Move{Reveal, Position{X: 0, Y: 0}}, 2 * time.Second},
+	})
+	c.Check(replay.Matches(game), Equals, true)
+
+	// playing the moves back reproduces the game
+	played, err := replay.NewGame()
+	c.Assert(err, IsNil)
+	c.Check(played.Replay().Moves, HasLen, 0)
+	for _, record := range replay.Moves {
+		_, err := played.Apply(record.Move)
+		c.Assert(err, IsNil)
+	}
+	c.Check(played.Display(), DeepEquals, game.Display())
+	c.Check(played.Lives(), Equals, uint(1))
+
+	// a different minefield does not match
+	other, err := NewGame(Config{Width: 5, Height: 5, Mines: 5, Selector: func(width, height, max uint) ([]Position, error) {
+		return []Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 1}}, nil
+	}})
+	c.Assert(err, IsNil)
+	c.Check(replay.Matches(other), Equals, false)
+}
+
+func (s *MSSuite) TestGame_Elapsed(c *C) {
+	game := newTestGame(c, Config{})
+	game.clock = fakeClock()
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(game.Elapsed(), Equals, time.Second)
+	c.Check(game.Elapsed(), Equals, 2*time.Second)
+
+	// the clock stops once the game is over
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+	c.Check(game.Elapsed(), Equals, 3*time.Second)
+	c.Check(game.Elapsed(), Equals, 3*time.Second)
+}
+
+func (s *MSSuite) TestReplay_ReadWrite(c *C) {
+	game := newTestGame(c, Config{Neighborhood: Knight, Win: RevealTarget(Position{X: 1, Y: 1})})
+	game.clock = fakeClock()
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+
+	var buf bytes.Buffer
+	c.Assert(game.Replay().Write(&buf), IsNil)
+	replay, err := ReadReplay(&buf)
+	c.Assert(err, IsNil)
+	c.Check(replay, DeepEquals, game.Replay())
+
+	played, err := replay.NewGame()
+	c.Assert(err, IsNil)
+	_, err = played.Apply(replay.Moves[0].Move)
+	c.Assert(err, IsNil)
+	c.Check(played.Display(), DeepEquals, game.Display())
+}
+
+func (s *MSSuite) TestReplay_Upgrade(c *C) {
+	// unversioned replays were played by the default rules
+	replay, err := ReadReplay(strings.NewReader(`{"Rules": {"Width": 5, "Height": 5, "Mines": 1}, "Layout": [{"X": 0, "Y": 0}]}`))
+	c.Assert(err, IsNil)
+	c.Check(replay.Version, Equals, ReplayVersion)
+	c.Check(replay.Rules.Win, Equals, "clear")
+	c.Check(replay.Rules.Lives, Equals, uint(1))
+	_, err = replay.NewGame()
+	c.Check(err, IsNil)
+
+	// replays from newer versions are refused
+	_, err = ReadReplay(strings.NewReader(`{"Version": 2}`))
+	c.Check(err, Equals, ErrReplayVersion)
+	_, err = Replay{Version: 0}.NewGame()
+	c.Check(err, Equals, ErrReplayVersion)
+
+	// custom win conditions cannot be played back
+	game := newTestGame(c, Config{Win: WinFunc(func(g *Game) bool { return false })})
+	_, err = game.Replay().NewGame()
+	c.Check(err, Equals, ErrUnknownName)
+}
+
+func (s *MSSuite) TestReplay_Resume(c *C) {
+	game := newTestGame(c, Config{Lives: 2})
+	game.clock = fakeClock()
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+
+	var buf bytes.Buffer
+	c.Assert(game.Replay().Write(&buf), IsNil)
+	replay, err := ReadReplay(&buf)
+	c.Assert(err, IsNil)
+
+	ended := false
+	resumed, err := replay.Resume(Config{OnEnd: func(*Game, GameResult) { ended = true }})
+	c.Assert(err, IsNil)
+	c.Check(resumed.Display(), DeepEquals, game.Display())
+	c.Check(resumed.Lives(), Equals, uint(1))
+
+	// the clock carries on from the last move
+	elapsed := resumed.Elapsed()
+	c.Check(elapsed >= 2*time.Second && elapsed < 3*time.Second, Equals, true, Commentf("%s", elapsed))
+
+	_, err = resumed.Select(2, 1)
+	c.Assert(err, IsNil)
+	c.Check(resumed.State(), Equals, Lost)
+	c.Check(ended, Equals, true)
+	c.Check(resumed.Replay().Moves, HasLen, 4)
+}
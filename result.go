@@ -0,0 +1,56 @@
+package gominesweeper
+
+import (
+	"time"
+)
+
+// GameResult is how a finished game went, for keeping statistics.
+type GameResult struct {
+	Width, Height, Mines uint
+
+	State   State
+	Elapsed time.Duration
+
+	// BBBV is the 3BV of the minefield and Solved is how much of it was
+	// solved.
+	BBBV, Solved int
+
+	// End is when the game ended.
+	End time.Time
+}
+
+// BBBVPerSecond returns the 3BV solved per second, or 0 if no time passed.
+func (r GameResult) BBBVPerSecond() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Solved) / r.Elapsed.Seconds()
+}
+
+// Result returns the result of the game, and false if it is still being
+// played.
+func (g *Game) Result() (GameResult, bool) {
+	if g.end.IsZero() {
+		return GameResult{}, false
+	}
+	bbbv, solved := g.minefield.bbbv()
+	return GameResult{
+		Width:   g.config.Width,
+		Height:  g.config.Height,
+		Mines:   g.config.Mines,
+		State:   g.state,
+		Elapsed: g.Elapsed(),
+		BBBV:    bbbv,
+		Solved:  solved,
+		End:     g.end,
+	}, true
+}
+
+// finish stops the clock now that the game is over and reports its result.
+func (g *Game) finish(now time.Time) {
+	g.end = now
+	if g.config.OnEnd != nil {
+		result, _ := g.Result()
+		g.config.OnEnd(g, result)
+	}
+}
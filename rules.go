@@ -0,0 +1,129 @@
+package gominesweeper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rules are the rules a game is played by, in a form that can be saved along
+// with a replay.
+type Rules struct {
+	Width, Height, Mines uint
+
+	// Neighborhood holds the neighbors of the origin.  Every neighborhood is
+	// assumed to be the same for each position.
+	Neighborhood Deltas
+
+	// Win is the name of a built in win condition, or empty if the game was
+	// played with a custom one.
+	Win string
+
+	Lives        uint
+	DefuseTime   time.Duration
+	ScoreAttack  bool
+	ComboTimeout time.Duration
+	Actions      uint
+	Mode         Mode
+	TimeLimit    time.Duration
+	Clicks       uint
+	Blind        bool
+	Splits       []float64
+	Strict       bool
+	AutoFlag     bool
+	OpeningSize  uint
+	FlagLimit    bool
+}
+
+// rulesOf returns the rules of the config.
+func rulesOf(cfg Config) Rules {
+	rules := Rules{
+		Width:        cfg.Width,
+		Height:       cfg.Height,
+		Mines:        cfg.Mines,
+		Lives:        cfg.Lives,
+		DefuseTime:   cfg.DefuseTime,
+		ScoreAttack:  cfg.ScoreAttack,
+		ComboTimeout: cfg.ComboTimeout,
+		Actions:      cfg.Actions,
+		Mode:         cfg.Mode,
+		TimeLimit:    cfg.TimeLimit,
+		Clicks:       cfg.Clicks,
+		Blind:        cfg.Blind,
+		Splits:       cfg.Splits,
+		Strict:       cfg.Strict,
+		AutoFlag:     cfg.AutoFlag,
+		OpeningSize:  cfg.OpeningSize,
+		FlagLimit:    cfg.FlagLimit,
+	}
+	if cfg.Neighborhood != nil {
+		rules.Neighborhood = Deltas(cfg.Neighborhood.Neighbors(Position{}))
+	}
+	if win, ok := cfg.Win.(fmt.Stringer); ok {
+		rules.Win = win.String()
+	}
+	return rules
+}
+
+// Config returns a config that plays by the rules, without a Selector.  It
+// returns ErrUnknownName if the win condition is not built in.
+func (r Rules) Config() (Config, error) {
+	win, err := parseWin(r.Win)
+	if err != nil {
+		return Config{}, err
+	}
+
+	cfg := Config{
+		Width:        r.Width,
+		Height:       r.Height,
+		Mines:        r.Mines,
+		Win:          win,
+		Lives:        r.Lives,
+		DefuseTime:   r.DefuseTime,
+		ScoreAttack:  r.ScoreAttack,
+		ComboTimeout: r.ComboTimeout,
+		Actions:      r.Actions,
+		Mode:         r.Mode,
+		TimeLimit:    r.TimeLimit,
+		Clicks:       r.Clicks,
+		Blind:        r.Blind,
+		Splits:       r.Splits,
+		Strict:       r.Strict,
+		AutoFlag:     r.AutoFlag,
+		OpeningSize:  r.OpeningSize,
+		FlagLimit:    r.FlagLimit,
+	}
+	if r.Neighborhood != nil {
+		cfg.Neighborhood = r.Neighborhood
+	}
+	return cfg, nil
+}
+
+// parseWin returns the built in win condition with the name, which may be
+// followed by its arguments in parentheses.
+func parseWin(name string) (WinCondition, error) {
+	kind, args, _ := strings.Cut(strings.TrimSuffix(name, ")"), "(")
+	switch kind {
+	case "clear":
+		return ClearAll, nil
+	case "flag":
+		return FlagAll, nil
+	case "defuse":
+		return DefuseAll, nil
+	case "target":
+		var target Position
+		if _, err := fmt.Sscanf(args, "%d,%d", &target.X, &target.Y); err == nil {
+			return RevealTarget(target), nil
+		}
+	case "survive":
+		if d, err := time.ParseDuration(args); err == nil {
+			return Survive(d), nil
+		}
+	case "fraction":
+		if fraction, err := strconv.ParseFloat(args, 64); err == nil {
+			return RevealFraction(fraction), nil
+		}
+	}
+	return nil, ErrUnknownName
+}
@@ -0,0 +1,28 @@
+package gominesweeper
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestRules_Win(c *C) {
+	for _, win := range []WinCondition{
+		ClearAll,
+		FlagAll,
+		DefuseAll,
+		RevealTarget(Position{X: 2, Y: 3}),
+		RevealFraction(0.75),
+		Survive(90 * time.Second),
+	} {
+		rules := rulesOf(Config{Win: win})
+		cfg, err := rules.Config()
+		c.Assert(err, IsNil, Commentf("win %s", rules.Win))
+		c.Check(rulesOf(cfg).Win, Equals, rules.Win)
+	}
+
+	_, err := Rules{Win: "target(2)"}.Config()
+	c.Check(err, Equals, ErrUnknownName)
+	_, err = Rules{Win: "bogus"}.Config()
+	c.Check(err, Equals, ErrUnknownName)
+}
@@ -0,0 +1,25 @@
+package gominesweeper
+
+// Score returns the points scored in score attack and the current combo.
+func (g *Game) Score() (score, combo int) {
+	return g.score, g.combo
+}
+
+// scoreReveal scores the number of blocks revealed by selecting a block with
+// the given proximity.
+func (g *Game) scoreReveal(proximity, revealed int) {
+	now := g.clock()
+	if proximity == Mine {
+		g.combo = 0
+		return
+	} else if revealed == 0 {
+		return
+	}
+
+	if timeout := g.config.ComboTimeout; timeout > 0 && !g.lastReveal.IsZero() && now.Sub(g.lastReveal) > timeout {
+		g.combo = 0
+	}
+	g.combo++
+	g.score += revealed * g.combo
+	g.lastReveal = now
+}
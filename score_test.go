@@ -0,0 +1,75 @@
+package gominesweeper
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_Score(c *C) {
+	now := time.Unix(0, 0)
+	game := newTestGame(c, Config{Lives: 2, ScoreAttack: true, ComboTimeout: 5 * time.Second})
+	game.clock = func() time.Time { return now }
+
+	var events []Event
+	game.Subscribe(func(event Event) {
+		events = append(events, event)
+	})
+
+	// the combo builds with each reveal
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	_, err = game.Select(0, 1)
+	c.Assert(err, IsNil)
+	_, err = game.Select(0, 4)
+	c.Assert(err, IsNil)
+	score, combo := game.Score()
+	c.Check(score, Equals, 6+1*2+6*3)
+	c.Check(combo, Equals, 3)
+
+	// selecting a revealed block does nothing
+	_, err = game.Select(0, 4)
+	c.Assert(err, IsNil)
+	score, combo = game.Score()
+	c.Check(score, Equals, 26)
+	c.Check(combo, Equals, 3)
+
+	// a wrong flag resets the combo
+	c.Assert(game.ToggleFlag(1, 1), IsNil)
+	c.Assert(game.ToggleFlag(1, 1), IsNil)
+	_, err = game.Select(1, 1)
+	c.Assert(err, IsNil)
+	score, combo = game.Score()
+	c.Check(score, Equals, 27)
+	c.Check(combo, Equals, 1)
+
+	// so does pausing for too long
+	now = now.Add(6 * time.Second)
+	_, err = game.Select(1, 0)
+	c.Assert(err, IsNil)
+	score, combo = game.Score()
+	c.Check(score, Equals, 28)
+	c.Check(combo, Equals, 1)
+
+	// and setting off a mine
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+	score, combo = game.Score()
+	c.Check(score, Equals, 28)
+	c.Check(combo, Equals, 0)
+
+	c.Assert(events, HasLen, 9)
+	c.Check(events[2].Score, Equals, 26)
+	c.Check(events[2].Combo, Equals, 3)
+	c.Check(events[8].Score, Equals, 28)
+	c.Check(events[8].Combo, Equals, 0)
+}
+
+func (s *MSSuite) TestGame_NoScore(c *C) {
+	game := newTestGame(c, Config{})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	score, combo := game.Score()
+	c.Check(score, Equals, 0)
+	c.Check(combo, Equals, 0)
+}
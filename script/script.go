@@ -0,0 +1,244 @@
+// Package script runs Starlark scripts against minesweeper games, so that
+// bots, win conditions, scoring rules and tutorials can be written without
+// compiling Go.
+//
+// A script sees a game through a board, which has the fields width, height,
+// mines, lives and state, and the methods:
+//
+//	cell(x, y)       the value from Display of the block at the position
+//	neighbors(x, y)  the positions in bounds that neighbor the position
+//
+// The values of blocks are predeclared as UNKNOWN, FLAGGED, MINE, EXPLODED,
+// WRONG_FLAG, DEFUSED and REVEALED.  A script may define any of:
+//
+//	next_move(board)      returns ("reveal" or "flag", x, y); see Bot
+//	won(board)            returns True once the game is won; see Win
+//	score(board)          returns the score of the game; see Score
+//	on_move(board, move)  returns a message for the player, or None; see Tutor
+//
+// Scripts are sandboxed: they cannot load modules or reach anything outside
+// of the board, and every call is limited to MaxSteps steps.
+package script
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// MaxSteps is the most steps a script may take on a single call.
+const MaxSteps = 1000000
+
+var (
+	ErrUndefined = errors.New("function is not defined by the script")
+	ErrBadResult = errors.New("function returned an unexpected value")
+)
+
+// predeclared are the names every script can use.
+var predeclared = starlark.StringDict{
+	"UNKNOWN":    starlark.MakeInt(gominesweeper.Unknown),
+	"FLAGGED":    starlark.MakeInt(gominesweeper.Flagged),
+	"MINE":       starlark.MakeInt(gominesweeper.Mine),
+	"EXPLODED":   starlark.MakeInt(gominesweeper.Exploded),
+	"WRONG_FLAG": starlark.MakeInt(gominesweeper.WrongFlag),
+	"DEFUSED":    starlark.MakeInt(gominesweeper.Defused),
+	"REVEALED":   starlark.MakeInt(gominesweeper.Revealed),
+}
+
+// Script is a loaded script.  It is safe for concurrent use.
+type Script struct {
+	name    string
+	globals starlark.StringDict
+
+	// Print receives anything the script prints; it is discarded when nil.
+	Print func(msg string)
+
+	mu  sync.Mutex
+	err error
+}
+
+// Load runs the source of the script, named for its error messages, and
+// returns the functions and values it defines.
+func Load(name string, src []byte) (*Script, error) {
+	s := &Script{name: name}
+	globals, err := starlark.ExecFile(s.thread(), name, src, predeclared)
+	if err != nil {
+		return nil, err
+	}
+	globals.Freeze()
+	s.globals = globals
+	return s, nil
+}
+
+// Err returns the first error from a call that had no way to return it, such
+// as a Bot's moves or a Win condition.
+func (s *Script) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// fail keeps the error for Err.
+func (s *Script) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// thread returns a new thread to call the script on.
+func (s *Script) thread() *starlark.Thread {
+	thread := &starlark.Thread{
+		Name: s.name,
+		Print: func(_ *starlark.Thread, msg string) {
+			if s.Print != nil {
+				s.Print(msg)
+			}
+		},
+	}
+	thread.SetMaxExecutionSteps(MaxSteps)
+	return thread
+}
+
+// call calls the function the script defines with the name.
+func (s *Script) call(name string, args ...starlark.Value) (starlark.Value, error) {
+	fn, ok := s.globals[name].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, ErrUndefined)
+	}
+	return starlark.Call(s.thread(), fn, args, nil)
+}
+
+// Bot is a Player that asks the script's next_move for every move.  If the
+// script fails, the bot reveals the first block and the error is kept by Err.
+func (s *Script) Bot() gominesweeper.Player {
+	return bot{s}
+}
+
+// bot is the Player returned by Script.Bot.
+type bot struct {
+	script *Script
+}
+
+// NextMove calls next_move with the snapshot.
+func (b bot) NextMove(snapshot gominesweeper.Snapshot) gominesweeper.Move {
+	move, err := b.script.nextMove(snapshot)
+	if err != nil {
+		b.script.fail(err)
+	}
+	return move
+}
+
+// nextMove calls next_move with the snapshot and decodes the move it returns.
+func (s *Script) nextMove(snapshot gominesweeper.Snapshot) (gominesweeper.Move, error) {
+	var move gominesweeper.Move
+	result, err := s.call("next_move", board(snapshot))
+	if err != nil {
+		return move, err
+	}
+
+	var action string
+	tuple, ok := result.(starlark.Tuple)
+	if !ok {
+		return move, ErrBadResult
+	} else if err := starlark.UnpackPositionalArgs("next_move", tuple, nil, 3, &action, &move.X, &move.Y); err != nil {
+		return move, ErrBadResult
+	} else if err := move.Action.UnmarshalText([]byte(action)); err != nil {
+		return move, ErrBadResult
+	}
+	return move, nil
+}
+
+// Win returns a win condition that calls the script's won with the game's
+// snapshot.  If the script fails, the game is not won and the error is kept by
+// Err.
+func (s *Script) Win() gominesweeper.WinCondition {
+	return gominesweeper.WinFunc(func(g *gominesweeper.Game) bool {
+		result, err := s.call("won", board(g.Snapshot()))
+		if err != nil {
+			s.fail(err)
+			return false
+		}
+		return bool(result.Truth())
+	})
+}
+
+// Score calls the script's score with the snapshot.
+func (s *Script) Score(snapshot gominesweeper.Snapshot) (int, error) {
+	result, err := s.call("score", board(snapshot))
+	if err != nil {
+		return 0, err
+	}
+	score, err := starlark.AsInt32(result)
+	if err != nil {
+		return 0, ErrBadResult
+	}
+	return score, nil
+}
+
+// Tutor calls the script's on_move after every move on the game, with the
+// game's snapshot and the move as ("reveal", x, y), and sends any message it
+// returns to fn.  Errors are kept by Err.  It returns a function that stops
+// tutoring.
+func (s *Script) Tutor(g *gominesweeper.Game, fn func(msg string)) (stop func()) {
+	return g.Subscribe(func(event gominesweeper.Event) {
+		if event.Move == nil {
+			return
+		}
+		move := starlark.Tuple{
+			starlark.String(event.Move.Action.String()),
+			starlark.MakeInt(event.Move.X),
+			starlark.MakeInt(event.Move.Y),
+		}
+		result, err := s.call("on_move", board(g.Snapshot()), move)
+		if err != nil {
+			s.fail(err)
+			return
+		}
+		if msg, ok := starlark.AsString(result); ok {
+			fn(msg)
+		} else if result != starlark.None {
+			s.fail(ErrBadResult)
+		}
+	})
+}
+
+// board returns the script's view of the snapshot.
+func board(snapshot gominesweeper.Snapshot) starlark.Value {
+	cell := starlark.NewBuiltin("cell", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var x, y int
+		if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 2, &x, &y); err != nil {
+			return nil, err
+		}
+		value, ok := snapshot.Blocks[gominesweeper.Position{X: x, Y: y}]
+		if !ok {
+			return nil, &gominesweeper.OutOfBoundsError{Pos: gominesweeper.Position{X: x, Y: y}, Width: snapshot.Width, Height: snapshot.Height}
+		}
+		return starlark.MakeInt(value), nil
+	})
+	neighbors := starlark.NewBuiltin("neighbors", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var x, y int
+		if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 2, &x, &y); err != nil {
+			return nil, err
+		}
+		var positions []starlark.Value
+		for _, pos := range snapshot.Neighbors(gominesweeper.Position{X: x, Y: y}) {
+			positions = append(positions, starlark.Tuple{starlark.MakeInt(pos.X), starlark.MakeInt(pos.Y)})
+		}
+		return starlark.NewList(positions), nil
+	})
+	return starlarkstruct.FromStringDict(starlark.String("board"), starlark.StringDict{
+		"width":     starlark.MakeInt(snapshot.Width),
+		"height":    starlark.MakeInt(snapshot.Height),
+		"mines":     starlark.MakeInt(snapshot.Mines),
+		"lives":     starlark.MakeUint(snapshot.Lives),
+		"state":     starlark.String(snapshot.State.String()),
+		"cell":      cell,
+		"neighbors": neighbors,
+	})
+}
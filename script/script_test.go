@@ -0,0 +1,115 @@
+package script
+
+import (
+	"errors"
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type ScriptSuite struct{}
+
+var _ = Suite(&ScriptSuite{})
+
+// newGame returns a game on the 5x5 example minefield.
+func newGame(c *C, cfg gominesweeper.Config) *gominesweeper.Game {
+	cfg.Width, cfg.Height, cfg.Mines = 5, 5, 5
+	cfg.Selector = func(width, height, max uint) ([]gominesweeper.Position, error) {
+		return []gominesweeper.Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+	}
+	game, err := gominesweeper.NewGame(cfg)
+	c.Assert(err, IsNil)
+	return game
+}
+
+const example = `
+def next_move(board):
+    # reveal the last hidden block
+    for y in reversed(range(board.height)):
+        for x in reversed(range(board.width)):
+            if board.cell(x, y) == UNKNOWN:
+                return ("reveal", x, y)
+    return ("flag", 0, 0)
+
+def won(board):
+    return board.cell(0, 4) >= 0
+
+def score(board):
+    return len([1 for y in range(board.height) for x in range(board.width) if board.cell(x, y) >= 0])
+
+def on_move(board, move):
+    action, x, y = move
+    if action == "flag":
+        return "flagged %d,%d" % (x, y)
+    print("moved")
+    return None
+`
+
+func (s *ScriptSuite) TestScript(c *C) {
+	script, err := Load("example.star", []byte(example))
+	c.Assert(err, IsNil)
+	var printed []string
+	script.Print = func(msg string) { printed = append(printed, msg) }
+
+	game := newGame(c, gominesweeper.Config{Win: script.Win()})
+	var messages []string
+	stop := script.Tutor(game, func(msg string) { messages = append(messages, msg) })
+	defer stop()
+
+	bot := script.Bot()
+	c.Check(bot.NextMove(game.Snapshot()), Equals, gominesweeper.Move{Action: gominesweeper.Reveal, Position: gominesweeper.Position{X: 4, Y: 4}})
+	_, err = game.Select(4, 2)
+	c.Assert(err, IsNil)
+	_, err = game.Select(4, 4)
+	c.Assert(err, IsNil)
+	c.Check(bot.NextMove(game.Snapshot()), Equals, gominesweeper.Move{Action: gominesweeper.Reveal, Position: gominesweeper.Position{X: 3, Y: 4}})
+
+	score, err := script.Score(game.Snapshot())
+	c.Assert(err, IsNil)
+	c.Check(score, Equals, 7)
+
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	c.Check(game.State(), Equals, gominesweeper.Playing)
+	_, err = game.Select(0, 4)
+	c.Assert(err, IsNil)
+	c.Check(game.State(), Equals, gominesweeper.Won)
+
+	c.Check(messages, DeepEquals, []string{"flagged 0,0"})
+	c.Check(printed, DeepEquals, []string{"moved", "moved", "moved"})
+	c.Check(script.Err(), IsNil)
+}
+
+func (s *ScriptSuite) TestErrors(c *C) {
+	_, err := Load("bad.star", []byte("def broken(:"))
+	c.Check(err, NotNil)
+	_, err = Load("load.star", []byte(`load("os.star", "system")`))
+	c.Check(err, NotNil)
+
+	script, err := Load("loop.star", []byte(`
+def score(board):
+    n = 0
+    for i in range(100000000):
+        n += i
+    return n
+
+def next_move(board):
+    return ("jump", 0, 0)
+`))
+	c.Assert(err, IsNil)
+	game := newGame(c, gominesweeper.Config{})
+
+	// runaway scripts are stopped
+	_, err = script.Score(game.Snapshot())
+	c.Check(err, ErrorMatches, ".*too many steps.*")
+
+	script.Bot().NextMove(game.Snapshot())
+	c.Check(script.Err(), Equals, ErrBadResult)
+
+	script.Win().Won(game)
+	c.Check(errors.Is(script.Err(), ErrBadResult), Equals, true)
+	_, err = (&Script{}).call("won")
+	c.Check(errors.Is(err, ErrUndefined), Equals, true)
+}
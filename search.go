@@ -0,0 +1,40 @@
+package gominesweeper
+
+import (
+	"github.com/smousa/go-minesweeper/minegen"
+)
+
+// NoGuessSelector returns a mine selector that places the mines so that the
+// board can be cleared from the start position by deduction alone; see
+// minegen.NoGuess.
+func NoGuessSelector(seed int64, start Position, rules ...PlacementRule) Selector {
+	return minegen.NoGuess(seed, start, rules...)
+}
+
+// NoGuessSymmetricSelector returns a mine selector like NoGuessSelector that
+// mirrors the mines with the symmetry; see minegen.NoGuessSymmetric.
+func NoGuessSymmetricSelector(seed int64, start Position, symmetry Symmetry, rules ...PlacementRule) Selector {
+	return minegen.NoGuessSymmetric(seed, start, symmetry, rules...)
+}
+
+// NoGuessSelectorFrom returns a mine selector like NoGuessSelector, with the
+// seeds of the boards it tries drawn from the source; see minegen.NoGuessFrom.
+func NoGuessSelectorFrom(src RandSource, start Position, rules ...PlacementRule) Selector {
+	return minegen.NoGuessFrom(src, start, rules...)
+}
+
+// Band is a range of difficulty scores, including both ends.
+type Band = minegen.Band
+
+// TargetedSelector returns a mine selector that tries boards until one's
+// difficulty is within the band; see minegen.Targeted.
+func TargetedSelector(seed int64, band Band, source func(seed int64) Selector) Selector {
+	return minegen.Targeted(seed, band, source)
+}
+
+// TargetedSelectorFrom returns a mine selector like TargetedSelector, with
+// the seeds of the boards it tries drawn from the source; see
+// minegen.TargetedFrom.
+func TargetedSelectorFrom(src RandSource, band Band, source func(seed int64) Selector) Selector {
+	return minegen.TargetedFrom(src, band, source)
+}
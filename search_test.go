@@ -0,0 +1,57 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestNoGuessSelector(c *C) {
+	start := Position{X: 4, Y: 4}
+	for seed := int64(0); seed < 5; seed++ {
+		mf, err := NewMinefieldConfig(Config{Width: 9, Height: 9, Mines: 10, Selector: NoGuessSelector(seed, start)})
+		c.Assert(err, IsNil)
+		c.Check(RateBoardFrom(mf, start).Guesses, Equals, 0, Commentf("seed %d", seed))
+
+		// the start opens an opening
+		block, _ := mf.peek(start)
+		c.Check(block.proximity(), Equals, 0)
+	}
+
+	// a board too full to open cannot be found
+	_, err := NoGuessSelector(0, Position{X: 1, Y: 1})(3, 3, 1)
+	c.Check(err, Equals, ErrUnplaceable)
+
+	// a crypto source still finds a board that needs no guesses
+	mf, err := NewMinefieldConfig(Config{Width: 9, Height: 9, Mines: 10, Selector: NoGuessSelectorFrom(CryptoSource(), start)})
+	c.Assert(err, IsNil)
+	c.Check(RateBoardFrom(mf, start).Guesses, Equals, 0)
+}
+
+func (s *MSSuite) TestNoGuessSymmetricSelector(c *C) {
+	start := Position{X: 4, Y: 4}
+	mf, err := NewMinefieldConfig(Config{Width: 9, Height: 9, Mines: 10, Selector: NoGuessSymmetricSelector(1, start, Rotational)})
+	c.Assert(err, IsNil)
+	c.Check(RateBoardFrom(mf, start).Guesses, Equals, 0)
+
+	// the mines are the same turned upside down
+	for _, mine := range mf.mines() {
+		block, _ := mf.peek(Position{X: 8 - mine.X, Y: 8 - mine.Y})
+		c.Check(block.proximity(), Equals, Mine)
+	}
+}
+
+func (s *MSSuite) TestTargetedSelector(c *C) {
+	band := Band{Min: 20, Max: 30}
+	mf, err := NewMinefieldConfig(Config{Width: 9, Height: 9, Mines: 10, Selector: TargetedSelector(3, band, SeededSelector)})
+	c.Assert(err, IsNil)
+	c.Check(band.Contains(RateBoard(mf)), Equals, true)
+
+	_, err = TargetedSelector(3, Band{Min: 1000, Max: 2000}, SeededSelector)(3, 3, 1)
+	c.Check(err, Equals, ErrUnplaceable)
+
+	// the seeded source tries the same boards as the seed
+	want, err := TargetedSelector(3, band, SeededSelector)(9, 9, 10)
+	c.Assert(err, IsNil)
+	got, err := TargetedSelectorFrom(SeededSource(3), band, SeededSelector)(9, 9, 10)
+	c.Assert(err, IsNil)
+	c.Check(got, DeepEquals, want)
+}
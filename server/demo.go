@@ -0,0 +1,21 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed demo
+var demo embed.FS
+
+// Demo returns a handler that serves a minimal browser client for the server,
+// which plays games through its routes and follows them over the websocket.
+// It is meant to be served from the root of the same host as the server.
+func Demo() http.Handler {
+	root, err := fs.Sub(demo, "demo")
+	if err != nil {
+		panic(err)
+	}
+	return http.FileServer(http.FS(root))
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Profile holds a player's settings, kept by the server so that every device
+// the player uses can share them.
+type Profile struct {
+	// Input is the name of the player's input profile, such as "mouse",
+	// "touch" or "keyboard".
+	Input string `json:"input,omitempty"`
+
+	// Theme is the name of the player's theme or renderer.
+	Theme string `json:"theme,omitempty"`
+
+	// Rules is the name of the registered rules the player prefers.
+	Rules string `json:"rules,omitempty"`
+
+	Accessibility Accessibility `json:"accessibility"`
+
+	// Updated is when the profile was last saved.  A device saving a
+	// profile sends the Updated it last got, and is refused with
+	// ErrStaleProfile if another device has saved the profile since.
+	Updated time.Time `json:"updated"`
+}
+
+// Accessibility are the player's accessibility settings.
+type Accessibility struct {
+	HighContrast  bool `json:"high_contrast,omitempty"`
+	ColorBlind    bool `json:"color_blind,omitempty"`
+	ReducedMotion bool `json:"reduced_motion,omitempty"`
+	ScreenReader  bool `json:"screen_reader,omitempty"`
+}
+
+var ErrStaleProfile = errors.New("profile was updated by another device")
+
+// ProfileStore keeps the profiles of players.
+type ProfileStore interface {
+	// LoadProfile returns the player's profile, or an empty profile if the
+	// player has none.
+	LoadProfile(player string) (Profile, error)
+	SaveProfile(player string, profile Profile) error
+}
+
+// MemoryProfiles keeps profiles in memory only.  It is safe for concurrent
+// use.
+type MemoryProfiles struct {
+	mu       sync.RWMutex
+	profiles map[string]Profile
+}
+
+// LoadProfile returns the player's profile.
+func (m *MemoryProfiles) LoadProfile(player string) (Profile, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.profiles[player], nil
+}
+
+// SaveProfile keeps the player's profile.
+func (m *MemoryProfiles) SaveProfile(player string, profile Profile) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.profiles == nil {
+		m.profiles = make(map[string]Profile)
+	}
+	m.profiles[player] = profile
+	return nil
+}
+
+// DirProfiles keeps each profile as JSON in its own file in the directory at
+// the path.
+type DirProfiles string
+
+// path returns the path of the player's profile.
+func (d DirProfiles) path(player string) string {
+	return filepath.Join(string(d), url.PathEscape(player)+".json")
+}
+
+// LoadProfile reads the player's profile from its file.
+func (d DirProfiles) LoadProfile(player string) (Profile, error) {
+	var profile Profile
+	data, err := os.ReadFile(d.path(player))
+	if errors.Is(err, os.ErrNotExist) {
+		return profile, nil
+	} else if err != nil {
+		return profile, err
+	}
+	err = json.Unmarshal(data, &profile)
+	return profile, err
+}
+
+// SaveProfile writes the player's profile to its file, replacing it only once
+// it has all been written.
+func (d DirProfiles) SaveProfile(player string, profile Profile) error {
+	data, err := json.MarshalIndent(profile, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(string(d), "profile.*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	} else if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), d.path(player))
+}
+
+// getProfile returns the profile of the player in the path.
+func (s *Server) getProfile(w http.ResponseWriter, r *http.Request) {
+	profile, err := s.Profiles.LoadProfile(r.PathValue("player"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, profile)
+}
+
+// putProfile saves the profile of the player in the path, unless it was saved
+// by another device since the profile in the request was loaded.
+func (s *Server) putProfile(w http.ResponseWriter, r *http.Request) {
+	var profile Profile
+	if err := s.decode(w, r, &profile); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	player := r.PathValue("player")
+	s.profilesMu.Lock()
+	defer s.profilesMu.Unlock()
+	saved, err := s.Profiles.LoadProfile(player)
+	if err != nil {
+		writeError(w, err)
+		return
+	} else if !saved.Updated.Equal(profile.Updated) {
+		writeError(w, ErrStaleProfile)
+		return
+	}
+	profile.Updated = s.clock().UTC()
+	if !profile.Updated.After(saved.Updated) {
+		profile.Updated = saved.Updated.Add(time.Nanosecond)
+	}
+	if err := s.Profiles.SaveProfile(player, profile); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, profile)
+}
@@ -0,0 +1,89 @@
+// Package redisgames keeps the games of a server in Redis, so that several
+// servers can share them:
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	srv := server.New()
+//	srv.Games = redisgames.New(client)
+//
+// Each game is a hash holding its data and version, and is saved by a script
+// that checks the version, so that a save is refused if any server has saved
+// the game since it was loaded.
+package redisgames
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/smousa/go-minesweeper/server"
+)
+
+// put saves the data of a game as the version after ARGV[2], if that is still
+// its version, and returns the new version or -1.  A missing game has a
+// version of 0.
+var put = redis.NewScript(`
+local version = tonumber(redis.call("HGET", KEYS[1], "version") or "0")
+if version ~= tonumber(ARGV[2]) then
+	return -1
+end
+redis.call("HSET", KEYS[1], "data", ARGV[1], "version", version + 1)
+if tonumber(ARGV[3]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[3])
+end
+return version + 1
+`)
+
+// Store is a server.GameStore that keeps games in Redis.
+type Store struct {
+	client redis.UniversalClient
+
+	// Prefix is put before the id of each game to make its key.
+	Prefix string
+
+	// TTL is how long a game is kept after it was last saved; if zero, a
+	// game is kept until it is deleted.
+	TTL time.Duration
+}
+
+var _ server.GameStore = (*Store)(nil)
+
+// New returns a store that keeps games in Redis with the client, under keys
+// prefixed by "minesweeper:game:".
+func New(client redis.UniversalClient) *Store {
+	return &Store{client: client, Prefix: "minesweeper:game:"}
+}
+
+// Get returns the saved game and its version.
+func (s *Store) Get(ctx context.Context, id string) ([]byte, int64, error) {
+	values, err := s.client.HMGet(ctx, s.Prefix+id, "data", "version").Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	data, ok := values[0].(string)
+	if !ok {
+		return nil, 0, server.ErrNotFound
+	}
+	saved, _ := values[1].(string)
+	version, err := strconv.ParseInt(saved, 10, 64)
+	if err != nil {
+		return nil, 0, err
+	}
+	return []byte(data), version, nil
+}
+
+// Put saves the game if it has not been saved since the version.
+func (s *Store) Put(ctx context.Context, id string, data []byte, version int64) (int64, error) {
+	saved, err := put.Run(ctx, s.client, []string{s.Prefix + id}, data, version, s.TTL.Milliseconds()).Int64()
+	if err != nil {
+		return 0, err
+	} else if saved < 0 {
+		return 0, server.ErrVersionConflict
+	}
+	return saved, nil
+}
+
+// Delete removes the game.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	return s.client.Del(ctx, s.Prefix+id).Err()
+}
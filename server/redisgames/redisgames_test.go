@@ -0,0 +1,72 @@
+package redisgames
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/smousa/go-minesweeper/server"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type RedisSuite struct {
+	redis *miniredis.Miniredis
+	store *Store
+}
+
+var _ = Suite(&RedisSuite{})
+
+func (s *RedisSuite) SetUpTest(c *C) {
+	var err error
+	s.redis, err = miniredis.Run()
+	c.Assert(err, IsNil)
+	s.store = New(redis.NewClient(&redis.Options{Addr: s.redis.Addr()}))
+}
+
+func (s *RedisSuite) TearDownTest(c *C) {
+	s.redis.Close()
+}
+
+func (s *RedisSuite) TestStore(c *C) {
+	ctx := context.Background()
+	_, _, err := s.store.Get(ctx, "game")
+	c.Check(err, Equals, server.ErrNotFound)
+
+	_, err = s.store.Put(ctx, "game", []byte("first"), 1)
+	c.Check(err, Equals, server.ErrVersionConflict)
+	version, err := s.store.Put(ctx, "game", []byte("first"), 0)
+	c.Assert(err, IsNil)
+	c.Check(version, Equals, int64(1))
+	c.Check(s.redis.HGet("minesweeper:game:game", "data"), Equals, "first")
+
+	version, err = s.store.Put(ctx, "game", []byte("second"), version)
+	c.Assert(err, IsNil)
+	c.Check(version, Equals, int64(2))
+	_, err = s.store.Put(ctx, "game", []byte("stale"), 1)
+	c.Check(err, Equals, server.ErrVersionConflict)
+
+	data, version, err := s.store.Get(ctx, "game")
+	c.Assert(err, IsNil)
+	c.Check(string(data), Equals, "second")
+	c.Check(version, Equals, int64(2))
+
+	c.Assert(s.store.Delete(ctx, "game"), IsNil)
+	_, _, err = s.store.Get(ctx, "game")
+	c.Check(err, Equals, server.ErrNotFound)
+}
+
+func (s *RedisSuite) TestTTL(c *C) {
+	ctx := context.Background()
+	s.store.TTL = time.Minute
+	_, err := s.store.Put(ctx, "game", []byte("data"), 0)
+	c.Assert(err, IsNil)
+	c.Check(s.redis.TTL("minesweeper:game:game"), Equals, time.Minute)
+
+	s.redis.FastForward(2 * time.Minute)
+	_, _, err = s.store.Get(ctx, "game")
+	c.Check(err, Equals, server.ErrNotFound)
+}
@@ -0,0 +1,644 @@
+// Package server exposes minesweeper games over HTTP using JSON.
+//
+// Routes:
+//
+//	POST   /games               create a game from a GameRequest
+//	GET    /games/{id}          get the Snapshot of a game
+//	DELETE /games/{id}          delete a game
+//	POST /games/{id}/select     select the block at a MoveRequest
+//	POST /games/{id}/flag       toggle the flag at a MoveRequest
+//	POST /games/{id}/moves      make the move of a MoveRequest
+//	GET  /games/{id}/spectate   get the Snapshot of a game as a spectator
+//	                            sees it, without the player's flags or any
+//	                            mine they have not found
+//	GET  /games/{id}/events     stream the game's events over a websocket
+//	GET  /games/{id}/render/{renderer}
+//	                            draw the game with a registered renderer
+//	GET  /profiles/{player}     get the player's Profile
+//	PUT  /profiles/{player}     save the player's Profile
+//
+// The other game routes respond with the Snapshot of the game and the profile
+// routes with the Profile, or else with an ErrorResponse.  The websocket sends each gominesweeper.Event as a wire.Event
+// in a JSON text message; a client that falls too far behind is disconnected,
+// and should reconnect and get the Snapshot again.
+//
+// Moves, snapshots, events and errors are the messages of the wire package.
+// Every response has the version of the protocol in the wire.Header, and a
+// request with a version in it that is not supported is refused with 400 Bad
+// Request.
+//
+// A client showing part of a large board can get a game or its events with
+// the query ?x=&y=&width=&height=, which keeps only the blocks in that
+// rectangle of the board.  The blocks of the Snapshot then start at its X and
+// Y, and the changes of each event are relative to them.
+//
+// Games are saved to the server's GameStore after every move, so that servers
+// sharing a store, such as the Redis store of the redisgames package, can
+// each serve any game.  A move made on a game that another server has moved
+// on since is refused with 409 Conflict, and should be sent again.  The
+// websocket only sends the moves made through the server it is connected to.
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	gominesweeper "github.com/smousa/go-minesweeper"
+	"github.com/smousa/go-minesweeper/wire"
+)
+
+// eventBuffer is the number of events a websocket client may fall behind by.
+const eventBuffer = 64
+
+var (
+	ErrNotFound     = errors.New("game not found")
+	ErrBadRequest   = errors.New("bad request")
+	ErrTooLarge     = errors.New("game is larger than the server allows")
+	ErrBodyTooLarge = errors.New("request body is larger than the server allows")
+)
+
+// The default limits on the size of the games a server creates.
+const (
+	DefaultMaxWidth  = 1000
+	DefaultMaxHeight = 1000
+	DefaultMaxMines  = 100000
+
+	// DefaultMaxBodySize is the default limit on the bytes of a request
+	// body.
+	DefaultMaxBodySize = 1 << 20
+)
+
+// GameRequest describes the game to create.  Rules and Selector are the names
+// of registered rules and selectors; when Rules is given the size and mines
+// come from the rules instead.  Strict games refuse moves that would do
+// nothing, such as selecting a revealed block, and AutoFlag games flag the
+// mines that the revealed numbers give away.
+type GameRequest struct {
+	Width    uint   `json:"width"`
+	Height   uint   `json:"height"`
+	Mines    uint   `json:"mines"`
+	Lives    uint   `json:"lives,omitempty"`
+	Rules    string `json:"rules,omitempty"`
+	Selector string `json:"selector,omitempty"`
+	Strict   bool   `json:"strict,omitempty"`
+	AutoFlag bool   `json:"autoFlag,omitempty"`
+}
+
+// MoveRequest is the position of a block to move on, and the action to take
+// on it when posted to the moves of a game, which reveals it by default.
+type MoveRequest = wire.Move
+
+// Snapshot is the state of a game as seen by the player.
+type Snapshot = wire.Snapshot
+
+// ErrorResponse is returned when a request fails.
+type ErrorResponse = wire.Error
+
+// session is a game being played on the server, as of the version of it in
+// the store.
+type session struct {
+	sync.Mutex
+	game          *gominesweeper.Game
+	version       int64
+	width, height uint
+}
+
+// Server is an http.Handler that keeps games in its GameStore, keyed by their
+// id, along with the games it has loaded.
+type Server struct {
+	mu       sync.RWMutex
+	sessions map[string]*session
+	mux      *http.ServeMux
+	upgrader websocket.Upgrader
+
+	// selector places the mines of new games, leaving it to the package
+	// default when nil
+	selector gominesweeper.Selector
+
+	// MoveLimit and ValidateMove guard the games created after they are set
+	// against cheating; see gominesweeper.Config.  Rejected moves are
+	// answered with 429 Too Many Requests when over the limit, and 403
+	// Forbidden when they fail the validator.
+	MoveLimit    gominesweeper.MoveLimit
+	ValidateMove gominesweeper.MoveValidator
+
+	// Metrics is told how the games created after it is set are played, e.g.
+	// by the telemetry package's OpenTelemetry adapter.
+	Metrics gominesweeper.Metrics
+
+	// MaxWidth, MaxHeight and MaxMines limit the size of the games created,
+	// which are refused with 400 Bad Request when larger.  New sets them to
+	// the defaults, and 0 leaves them unlimited.
+	MaxWidth, MaxHeight, MaxMines uint
+
+	// MaxBodySize limits the bytes of the bodies of requests, which are
+	// refused with 413 Request Entity Too Large when larger.  New sets it to
+	// the default, and 0 leaves it unlimited.
+	MaxBodySize int64
+
+	// Games keeps the games; New keeps them in memory.
+	Games GameStore
+
+	// Profiles keeps the profiles of players; New keeps them in memory.
+	Profiles   ProfileStore
+	profilesMu sync.Mutex
+	clock      func() time.Time
+}
+
+// New returns a server without any games.
+func New() *Server {
+	s := &Server{
+		sessions: make(map[string]*session),
+		Games:    &MemoryGames{},
+		Profiles: &MemoryProfiles{},
+		clock:    time.Now,
+
+		MaxWidth:  DefaultMaxWidth,
+		MaxHeight: DefaultMaxHeight,
+		MaxMines:  DefaultMaxMines,
+
+		MaxBodySize: DefaultMaxBodySize,
+	}
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("POST /games", s.create)
+	s.mux.HandleFunc("GET /games/{id}", s.get)
+	s.mux.HandleFunc("DELETE /games/{id}", s.delete)
+	s.mux.HandleFunc("GET /games/{id}/spectate", s.spectate)
+	s.mux.HandleFunc("POST /games/{id}/select", s.move(func(game *gominesweeper.Game, req MoveRequest) error {
+		_, err := game.Select(req.X, req.Y)
+		return err
+	}))
+	s.mux.HandleFunc("POST /games/{id}/flag", s.move(func(game *gominesweeper.Game, req MoveRequest) error {
+		return game.ToggleFlag(req.X, req.Y)
+	}))
+	s.mux.HandleFunc("POST /games/{id}/moves", s.move(func(game *gominesweeper.Game, req MoveRequest) error {
+		_, err := game.Apply(gominesweeper.Move{Action: req.Action, Position: gominesweeper.Position{X: req.X, Y: req.Y}})
+		return err
+	}))
+	s.mux.HandleFunc("GET /games/{id}/events", s.events)
+	s.mux.HandleFunc("GET /games/{id}/render/{renderer}", s.render)
+	s.mux.HandleFunc("GET /profiles/{player}", s.getProfile)
+	s.mux.HandleFunc("PUT /profiles/{player}", s.putProfile)
+	return s
+}
+
+// ServeHTTP routes the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(wire.Header, strconv.Itoa(wire.Version))
+	if _, err := wire.ParseVersion(r.Header.Get(wire.Header)); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// create starts a new game.
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	var req GameRequest
+	if err := s.decode(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	cfg, err := s.config(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	game, err := gominesweeper.NewGame(cfg)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	id, err := newID()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	sess := &session{game: game, width: cfg.Width, height: cfg.Height}
+	if err := s.save(r.Context(), id, sess); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.mu.Lock()
+	s.sessions[id] = sess
+	s.mu.Unlock()
+
+	w.Header().Set("Location", "/games/"+id)
+	writeJSON(w, http.StatusCreated, sess.snapshot(id))
+}
+
+// config returns the config of the game described by the request, or
+// ErrTooLarge if it is over the server's limits.
+func (s *Server) config(req GameRequest) (gominesweeper.Config, error) {
+	cfg := gominesweeper.Config{
+		Width:  req.Width,
+		Height: req.Height,
+		Mines:  req.Mines,
+	}
+	if req.Rules != "" {
+		rules, err := gominesweeper.LookupRules(req.Rules)
+		if err != nil {
+			return cfg, err
+		}
+		if cfg, err = rules.Config(); err != nil {
+			return cfg, err
+		}
+	}
+	if req.Lives > 0 {
+		cfg.Lives = req.Lives
+	}
+	cfg.Strict = req.Strict
+	cfg.AutoFlag = req.AutoFlag
+	cfg.MoveLimit = s.MoveLimit
+	cfg.ValidateMove = s.ValidateMove
+	cfg.Metrics = s.Metrics
+
+	cfg.Selector = s.selector
+	if req.Selector != "" {
+		selector, err := gominesweeper.LookupSelector(req.Selector)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Selector = selector
+	}
+
+	if over(cfg.Width, s.MaxWidth) || over(cfg.Height, s.MaxHeight) || over(cfg.Mines, s.MaxMines) {
+		return cfg, ErrTooLarge
+	}
+	return cfg, nil
+}
+
+// decode decodes the JSON body of the request into v.  It returns
+// ErrBodyTooLarge if the body is over the server's limit, and ErrBadRequest if
+// it cannot be decoded.
+func (s *Server) decode(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	body := r.Body
+	if s.MaxBodySize > 0 {
+		body = http.MaxBytesReader(w, r.Body, s.MaxBodySize)
+	}
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return ErrBodyTooLarge
+		}
+		return ErrBadRequest
+	}
+	return nil
+}
+
+// over returns true if the value is over the limit, where 0 is no limit.
+func over(value, limit uint) bool {
+	return limit > 0 && value > limit
+}
+
+// get returns the snapshot of a game.
+func (s *Server) get(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	sess, err := s.session(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	sess.Lock()
+	defer sess.Unlock()
+	rect, err := sess.viewport(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	} else if rect != nil {
+		writeJSON(w, http.StatusOK, sess.viewRect(id, *rect, sess.game.SnapshotRect(*rect).Blocks))
+		return
+	}
+	writeJSON(w, http.StatusOK, sess.snapshot(id))
+}
+
+// delete removes a game.
+func (s *Server) delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, _, err := s.Games.Get(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	} else if err := s.Games.Delete(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.mu.Lock()
+	delete(s.sessions, id)
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// spectate responds with the game as a spectator sees it.
+func (s *Server) spectate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	sess, err := s.session(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	sess.Lock()
+	defer sess.Unlock()
+	writeJSON(w, http.StatusOK, sess.view(id, sess.game.SpectatorView().Blocks))
+}
+
+// move returns a handler that makes a move on a game.
+func (s *Server) move(fn func(*gominesweeper.Game, MoveRequest) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		sess, err := s.session(r.Context(), id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		var req MoveRequest
+		if err := s.decode(w, r, &req); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		sess.Lock()
+		defer sess.Unlock()
+		if err := fn(sess.game, req); err != nil {
+			writeError(w, err)
+			return
+		} else if err := s.save(r.Context(), id, sess); err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, sess.snapshot(id))
+	}
+}
+
+// events streams the events of a game to a websocket until either side closes
+// it.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	sess, err := s.session(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// subscribe before upgrading so that no move is missed once the client
+	// is connected
+	events := make(chan gominesweeper.Event, eventBuffer)
+	overflow := make(chan struct{})
+	var once sync.Once
+	send := func(event gominesweeper.Event) {
+		select {
+		case events <- event:
+		default:
+			once.Do(func() { close(overflow) })
+		}
+	}
+	sess.Lock()
+	rect, err := sess.viewport(r)
+	if err != nil {
+		sess.Unlock()
+		writeError(w, err)
+		return
+	}
+	var unsubscribe func()
+	if rect != nil {
+		unsubscribe = sess.game.SubscribeRect(*rect, send)
+	} else {
+		unsubscribe = sess.game.Subscribe(send)
+	}
+	sess.Unlock()
+	defer func() {
+		sess.Lock()
+		unsubscribe()
+		sess.Unlock()
+	}()
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// the client only sends control messages, which are handled while reading
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case event := <-events:
+			if err := conn.WriteJSON(wire.NewEvent(event)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		case <-overflow:
+			return
+		}
+	}
+}
+
+// render draws a game with the renderer named in the path.
+func (s *Server) render(w http.ResponseWriter, r *http.Request) {
+	sess, err := s.session(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	renderer, err := gominesweeper.LookupRenderer(r.PathValue("renderer"))
+	if err != nil {
+		writeError(w, ErrNotFound)
+		return
+	}
+
+	var buf bytes.Buffer
+	sess.Lock()
+	err = renderer(&buf, sess.game.Snapshot())
+	sess.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(buf.Bytes()))
+	buf.WriteTo(w)
+}
+
+// session looks up a game by its id, loading it from the store unless the
+// game already loaded is of the latest version.
+func (s *Server) session(ctx context.Context, id string) (*session, error) {
+	data, version, err := s.Games.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	sess, ok := s.sessions[id]
+	s.mu.RUnlock()
+	if ok {
+		sess.Lock()
+		current := sess.version >= version
+		sess.Unlock()
+		if current {
+			return sess, nil
+		}
+	}
+
+	replay, err := gominesweeper.ReadReplay(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	game, err := replay.Resume(gominesweeper.Config{
+		MoveLimit:    s.MoveLimit,
+		ValidateMove: s.ValidateMove,
+		Metrics:      s.Metrics,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sess = &session{game: game, version: version, width: replay.Rules.Width, height: replay.Rules.Height}
+	s.mu.Lock()
+	s.sessions[id] = sess
+	s.mu.Unlock()
+	return sess, nil
+}
+
+// save puts the session's game in the store, as the version after the one
+// it was loaded as.  If the store has a newer version, the session is
+// forgotten so that the next request loads the game again.
+func (s *Server) save(ctx context.Context, id string, sess *session) error {
+	var buf bytes.Buffer
+	if err := sess.game.Replay().Write(&buf); err != nil {
+		return err
+	}
+	version, err := s.Games.Put(ctx, id, buf.Bytes(), sess.version)
+	if err != nil {
+		s.mu.Lock()
+		if s.sessions[id] == sess {
+			delete(s.sessions, id)
+		}
+		s.mu.Unlock()
+		return err
+	}
+	sess.version = version
+	return nil
+}
+
+// snapshot returns the state of the session's game.
+func (sess *session) snapshot(id string) Snapshot {
+	return sess.view(id, sess.game.Display())
+}
+
+// view returns the state of the session's game with the blocks.
+func (sess *session) view(id string, display map[gominesweeper.Position]int) Snapshot {
+	return sess.viewRect(id, gominesweeper.Rect{Width: int(sess.width), Height: int(sess.height)}, display)
+}
+
+// viewRect returns the state of the session's game with the blocks in the
+// rectangle, whose positions in the display are relative to its origin.
+func (sess *session) viewRect(id string, rect gominesweeper.Rect, display map[gominesweeper.Position]int) Snapshot {
+	blocks := make([][]int, rect.Height)
+	for y := range blocks {
+		blocks[y] = make([]int, rect.Width)
+		for x := range blocks[y] {
+			blocks[y][x] = display[gominesweeper.Position{X: x, Y: y}]
+		}
+	}
+	return Snapshot{
+		ID:     id,
+		State:  sess.game.State().String(),
+		Lives:  sess.game.Lives(),
+		X:      rect.Origin.X,
+		Y:      rect.Origin.Y,
+		Blocks: blocks,
+	}
+}
+
+// viewport returns the rectangle of the board asked for by the query of the
+// request, cut down to the board, or nil if the whole board was asked for.
+func (sess *session) viewport(r *http.Request) (*gominesweeper.Rect, error) {
+	query := r.URL.Query()
+	if !query.Has("x") && !query.Has("y") && !query.Has("width") && !query.Has("height") {
+		return nil, nil
+	}
+	var values [4]int
+	for i, key := range []string{"x", "y", "width", "height"} {
+		value, err := strconv.Atoi(query.Get(key))
+		if err != nil || value < 0 {
+			return nil, ErrBadRequest
+		}
+		values[i] = value
+	}
+	// the origin is clamped to the board before the size, so that a huge
+	// size cannot overflow
+	x, y := min(values[0], int(sess.width)), min(values[1], int(sess.height))
+	return &gominesweeper.Rect{
+		Origin: gominesweeper.Position{X: x, Y: y},
+		Width:  min(values[2], int(sess.width)-x),
+		Height: min(values[3], int(sess.height)-y),
+	}, nil
+}
+
+// newID returns a random game id.
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// writeError responds with the error and a status code that matches it.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, gominesweeper.ErrRateLimited):
+		status = http.StatusTooManyRequests
+	case errors.Is(err, gominesweeper.ErrMoveRejected):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrBodyTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case isAny(err, gominesweeper.ErrGameOver, gominesweeper.ErrAlreadyRevealed, gominesweeper.ErrFlagged, ErrStaleProfile,
+		ErrVersionConflict, gominesweeper.ErrPaused, gominesweeper.ErrFlagLimit, gominesweeper.ErrNotDefusing):
+		status = http.StatusConflict
+	case isAny(err, ErrBadRequest, wire.ErrUnsupportedVersion, gominesweeper.ErrOutOfBounds, gominesweeper.ErrExceedDimensions,
+		gominesweeper.ErrBadCount, gominesweeper.ErrDupPoint, gominesweeper.ErrUnknownName,
+		gominesweeper.ErrUnknownAction, gominesweeper.ErrUnplaceable, gominesweeper.ErrProximityFull, ErrTooLarge):
+		status = http.StatusBadRequest
+	}
+	writeJSON(w, status, wire.NewError(err))
+}
+
+// isAny returns true if the error matches any of the targets.
+func isAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSON responds with the value encoded as JSON.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,453 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	gominesweeper "github.com/smousa/go-minesweeper"
+	"github.com/smousa/go-minesweeper/wire"
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+type ServerSuite struct {
+	server *httptest.Server
+}
+
+var _ = Suite(&ServerSuite{})
+
+func (s *ServerSuite) SetUpTest(c *C) {
+	srv := New()
+	srv.selector = func(width, height, max uint) ([]gominesweeper.Position, error) {
+		return []gominesweeper.Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+	}
+	s.server = httptest.NewServer(srv)
+}
+
+func (s *ServerSuite) TearDownTest(c *C) {
+	s.server.Close()
+}
+
+// do sends the request body as JSON and decodes the response into v.
+func (s *ServerSuite) do(c *C, method, path string, body, v interface{}) int {
+	var buf bytes.Buffer
+	if body != nil {
+		c.Assert(json.NewEncoder(&buf).Encode(body), IsNil)
+	}
+	req, err := http.NewRequest(method, s.server.URL+path, &buf)
+	c.Assert(err, IsNil)
+	resp, err := http.DefaultClient.Do(req)
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	c.Assert(json.NewDecoder(resp.Body).Decode(v), IsNil)
+	return resp.StatusCode
+}
+
+func (s *ServerSuite) TestGame(c *C) {
+	var snapshot Snapshot
+	status := s.do(c, "POST", "/games", GameRequest{Width: 5, Height: 5, Mines: 5}, &snapshot)
+	c.Assert(status, Equals, http.StatusCreated)
+	c.Check(snapshot.ID, Not(Equals), "")
+	c.Check(snapshot.State, Equals, "playing")
+	c.Check(snapshot.Lives, Equals, uint(1))
+	c.Assert(snapshot.Blocks, HasLen, 5)
+	c.Check(snapshot.Blocks[0], DeepEquals, []int{
+		gominesweeper.Unknown, gominesweeper.Unknown, gominesweeper.Unknown, gominesweeper.Unknown, gominesweeper.Unknown,
+	})
+	id := snapshot.ID
+
+	status = s.do(c, "POST", "/games/"+id+"/select", MoveRequest{X: 4, Y: 2}, &snapshot)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(snapshot.Blocks[2][4], Equals, 0)
+	c.Check(snapshot.Blocks[1][3], Equals, 2)
+
+	status = s.do(c, "POST", "/games/"+id+"/flag", MoveRequest{X: 0, Y: 0}, &snapshot)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(snapshot.Blocks[0][0], Equals, gominesweeper.Flagged)
+
+	status = s.do(c, "POST", "/games/"+id+"/moves", MoveRequest{Action: gominesweeper.Question, X: 1, Y: 1}, &snapshot)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(snapshot.Blocks[1][1], Equals, gominesweeper.Questioned)
+
+	var got Snapshot
+	status = s.do(c, "GET", "/games/"+id, nil, &got)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(got, DeepEquals, snapshot)
+
+	status = s.do(c, "POST", "/games/"+id+"/select", MoveRequest{X: 4, Y: 0}, &snapshot)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(snapshot.State, Equals, "lost")
+	c.Check(snapshot.Blocks[0][4], Equals, gominesweeper.Exploded)
+}
+
+func (s *ServerSuite) TestErrors(c *C) {
+	var resp ErrorResponse
+	status := s.do(c, "GET", "/games/missing", nil, &resp)
+	c.Check(status, Equals, http.StatusNotFound)
+	c.Check(resp.Error, Equals, ErrNotFound.Error())
+
+	status = s.do(c, "POST", "/games", GameRequest{Width: 5, Height: 5, Mines: 3}, &resp)
+	c.Check(status, Equals, http.StatusBadRequest)
+	c.Check(resp.Error, Equals, gominesweeper.ErrBadCount.Error())
+
+	// games over the size limits are refused before they are made
+	for _, req := range []GameRequest{
+		{Width: DefaultMaxWidth + 1, Height: 5, Mines: 5},
+		{Width: 5, Height: 1 << 40, Mines: 5},
+		{Width: 1000, Height: 1000, Mines: DefaultMaxMines + 1},
+	} {
+		status = s.do(c, "POST", "/games", req, &resp)
+		c.Check(status, Equals, http.StatusBadRequest)
+		c.Check(resp.Error, Equals, ErrTooLarge.Error())
+	}
+
+	// as are bodies over the size limit
+	status = s.do(c, "POST", "/games", strings.Repeat(" ", DefaultMaxBodySize), &resp)
+	c.Check(status, Equals, http.StatusRequestEntityTooLarge)
+	c.Check(resp.Error, Equals, ErrBodyTooLarge.Error())
+
+	var snapshot Snapshot
+	status = s.do(c, "POST", "/games", GameRequest{Width: 5, Height: 5, Mines: 5}, &snapshot)
+	c.Assert(status, Equals, http.StatusCreated)
+
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/select", MoveRequest{X: 9, Y: 9}, &resp)
+	c.Check(status, Equals, http.StatusBadRequest)
+	c.Check(resp.Error, Equals, "point (9,9) is out of bounds of 5x5")
+	c.Check(resp.Position, DeepEquals, &wire.Position{X: 9, Y: 9})
+
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/select", strings.Repeat(" ", DefaultMaxBodySize), &resp)
+	c.Check(status, Equals, http.StatusRequestEntityTooLarge)
+	c.Check(resp.Error, Equals, ErrBodyTooLarge.Error())
+
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/select", MoveRequest{X: 0, Y: 0}, &snapshot)
+	c.Check(status, Equals, http.StatusOK)
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/flag", MoveRequest{X: 1, Y: 1}, &resp)
+	c.Check(status, Equals, http.StatusConflict)
+	c.Check(resp.Error, Equals, gominesweeper.ErrGameOver.Error())
+
+	// strict games refuse redundant moves
+	status = s.do(c, "POST", "/games", GameRequest{Width: 5, Height: 5, Mines: 5, Strict: true}, &snapshot)
+	c.Assert(status, Equals, http.StatusCreated)
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/select", MoveRequest{X: 0, Y: 1}, &snapshot)
+	c.Check(status, Equals, http.StatusOK)
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/select", MoveRequest{X: 0, Y: 1}, &resp)
+	c.Check(status, Equals, http.StatusConflict)
+	c.Check(resp.Error, Equals, gominesweeper.ErrAlreadyRevealed.Error())
+}
+
+func (s *ServerSuite) TestWriteError(c *C) {
+	for err, status := range map[error]int{
+		gominesweeper.ErrPaused:        http.StatusConflict,
+		gominesweeper.ErrFlagLimit:     http.StatusConflict,
+		gominesweeper.ErrNotDefusing:   http.StatusConflict,
+		gominesweeper.ErrUnknownAction: http.StatusBadRequest,
+		gominesweeper.ErrUnplaceable:   http.StatusBadRequest,
+		errors.New("broken"):           http.StatusInternalServerError,
+	} {
+		w := httptest.NewRecorder()
+		writeError(w, err)
+		c.Check(w.Code, Equals, status, Commentf("%v", err))
+	}
+}
+
+func (s *ServerSuite) TestRejectedMoves(c *C) {
+	srv := New()
+	srv.MoveLimit = gominesweeper.MoveLimit{Moves: 2, Per: time.Hour}
+	srv.ValidateMove = func(g *gominesweeper.Game, move gominesweeper.Move) error {
+		if move.Action == gominesweeper.Flag {
+			return errors.New("no flags")
+		}
+		return nil
+	}
+	srv.selector = func(width, height, max uint) ([]gominesweeper.Position, error) {
+		return []gominesweeper.Position{{X: 0, Y: 0}}, nil
+	}
+	s.server.Close()
+	s.server = httptest.NewServer(srv)
+
+	var snapshot Snapshot
+	status := s.do(c, "POST", "/games", GameRequest{Width: 5, Height: 5, Mines: 1}, &snapshot)
+	c.Assert(status, Equals, http.StatusCreated)
+
+	var resp ErrorResponse
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/flag", MoveRequest{X: 0, Y: 0}, &resp)
+	c.Check(status, Equals, http.StatusForbidden)
+	c.Check(resp.Error, Equals, "move was rejected: no flags")
+
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/select", MoveRequest{X: 1, Y: 0}, &snapshot)
+	c.Check(status, Equals, http.StatusOK)
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/select", MoveRequest{X: 0, Y: 1}, &snapshot)
+	c.Check(status, Equals, http.StatusOK)
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/select", MoveRequest{X: 1, Y: 1}, &resp)
+	c.Check(status, Equals, http.StatusTooManyRequests)
+	c.Check(resp.Error, Equals, gominesweeper.ErrRateLimited.Error())
+}
+
+func (s *ServerSuite) TestRegistry(c *C) {
+	var snapshot Snapshot
+	status := s.do(c, "POST", "/games", GameRequest{Rules: "beginner", Selector: "random", Lives: 3}, &snapshot)
+	c.Assert(status, Equals, http.StatusCreated)
+	c.Check(snapshot.Lives, Equals, uint(3))
+	c.Check(snapshot.Blocks, HasLen, 9)
+
+	resp, err := http.Get(s.server.URL + "/games/" + snapshot.ID + "/render/text")
+	c.Assert(err, IsNil)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	c.Assert(err, IsNil)
+	c.Check(resp.StatusCode, Equals, http.StatusOK)
+	c.Check(string(body), Equals, strings.Repeat("#########\n", 9))
+
+	var errResp ErrorResponse
+	status = s.do(c, "GET", "/games/"+snapshot.ID+"/render/bogus", nil, &errResp)
+	c.Check(status, Equals, http.StatusNotFound)
+	status = s.do(c, "POST", "/games", GameRequest{Rules: "bogus"}, &errResp)
+	c.Check(status, Equals, http.StatusBadRequest)
+	c.Check(errResp.Error, Equals, gominesweeper.ErrUnknownName.Error())
+}
+
+func (s *ServerSuite) TestEvents(c *C) {
+	var snapshot Snapshot
+	status := s.do(c, "POST", "/games", GameRequest{Width: 5, Height: 5, Mines: 5}, &snapshot)
+	c.Assert(status, Equals, http.StatusCreated)
+
+	url := "ws" + strings.TrimPrefix(s.server.URL, "http") + "/games/" + snapshot.ID + "/events"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	c.Assert(err, IsNil)
+	defer conn.Close()
+
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/flag", MoveRequest{X: 0, Y: 0}, &snapshot)
+	c.Assert(status, Equals, http.StatusOK)
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/select", MoveRequest{X: 4, Y: 0}, &snapshot)
+	c.Assert(status, Equals, http.StatusOK)
+
+	var event wire.Event
+	c.Assert(conn.ReadJSON(&event), IsNil)
+	c.Check(*event.Move, Equals, wire.Move{Action: gominesweeper.Flag, X: 0, Y: 0})
+	c.Check(event.Changes, DeepEquals, []wire.Change{{X: 0, Y: 0, Value: gominesweeper.Flagged}})
+	c.Check(event.State, Equals, "playing")
+
+	event = wire.Event{}
+	c.Assert(conn.ReadJSON(&event), IsNil)
+	c.Check(event.Move.Action, Equals, gominesweeper.Reveal)
+	c.Check(event.State, Equals, "lost")
+	c.Check(event.Changes, HasLen, 4)
+
+	var resp ErrorResponse
+	status = s.do(c, "GET", "/games/missing/events", nil, &resp)
+	c.Check(status, Equals, http.StatusNotFound)
+}
+
+func (s *ServerSuite) TestProfiles(c *C) {
+	var profile Profile
+	status := s.do(c, "GET", "/profiles/ada", nil, &profile)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(profile, DeepEquals, Profile{})
+
+	// one device saves the profile
+	phone := profile
+	phone.Input = "touch"
+	phone.Accessibility.ReducedMotion = true
+	status = s.do(c, "PUT", "/profiles/ada", phone, &phone)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(phone.Updated.IsZero(), Equals, false)
+
+	// another device that loaded it before cannot overwrite it
+	laptop := profile
+	laptop.Input = "mouse"
+	var errResp ErrorResponse
+	status = s.do(c, "PUT", "/profiles/ada", laptop, &errResp)
+	c.Check(status, Equals, http.StatusConflict)
+	c.Check(errResp.Error, Equals, ErrStaleProfile.Error())
+
+	// until it gets the profile again
+	status = s.do(c, "GET", "/profiles/ada", nil, &laptop)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(laptop.Input, Equals, "touch")
+	c.Check(laptop.Accessibility.ReducedMotion, Equals, true)
+	laptop.Theme = "svg"
+	status = s.do(c, "PUT", "/profiles/ada", laptop, &laptop)
+	c.Check(status, Equals, http.StatusOK)
+	c.Check(laptop.Updated.After(phone.Updated), Equals, true)
+}
+
+func (s *ServerSuite) TestDirProfiles(c *C) {
+	store := DirProfiles(c.MkDir())
+	profile, err := store.LoadProfile("../ada")
+	c.Assert(err, IsNil)
+	c.Check(profile, DeepEquals, Profile{})
+
+	profile = Profile{Rules: "expert", Accessibility: Accessibility{HighContrast: true}}
+	c.Assert(store.SaveProfile("../ada", profile), IsNil)
+	loaded, err := store.LoadProfile("../ada")
+	c.Assert(err, IsNil)
+	c.Check(loaded, DeepEquals, profile)
+	other, err := store.LoadProfile("ada")
+	c.Assert(err, IsNil)
+	c.Check(other, DeepEquals, Profile{})
+}
+
+func (s *ServerSuite) TestSpectate(c *C) {
+	var snapshot Snapshot
+	status := s.do(c, "POST", "/games", GameRequest{Width: 5, Height: 5, Mines: 5}, &snapshot)
+	c.Assert(status, Equals, http.StatusCreated)
+	id := snapshot.ID
+
+	s.do(c, "POST", "/games/"+id+"/flag", MoveRequest{X: 0, Y: 0}, &snapshot)
+	s.do(c, "POST", "/games/"+id+"/select", MoveRequest{X: 4, Y: 0}, &snapshot)
+	c.Check(snapshot.Blocks[2][1], Equals, gominesweeper.Mine)
+
+	// the spectator sees neither the flag nor the mines shown by losing
+	var view Snapshot
+	status = s.do(c, "GET", "/games/"+id+"/spectate", nil, &view)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(view.State, Equals, "lost")
+	c.Check(view.Blocks[0][4], Equals, gominesweeper.Exploded)
+	c.Check(view.Blocks[0][0], Equals, gominesweeper.Unknown)
+	c.Check(view.Blocks[2][1], Equals, gominesweeper.Unknown)
+
+	var resp ErrorResponse
+	status = s.do(c, "GET", "/games/missing/spectate", nil, &resp)
+	c.Check(status, Equals, http.StatusNotFound)
+}
+
+func (s *ServerSuite) TestStore(c *C) {
+	var snapshot Snapshot
+	status := s.do(c, "POST", "/games", GameRequest{Width: 5, Height: 5, Mines: 5}, &snapshot)
+	c.Assert(status, Equals, http.StatusCreated)
+	id := snapshot.ID
+	status = s.do(c, "POST", "/games/"+id+"/select", MoveRequest{X: 4, Y: 2}, &snapshot)
+	c.Assert(status, Equals, http.StatusOK)
+
+	// another server sharing the store picks up the game where it was left
+	games := s.server.Config.Handler.(*Server).Games
+	other := New()
+	other.Games = games
+	otherServer := httptest.NewServer(other)
+	defer otherServer.Close()
+
+	var got Snapshot
+	resp, err := http.Get(otherServer.URL + "/games/" + id)
+	c.Assert(err, IsNil)
+	c.Assert(json.NewDecoder(resp.Body).Decode(&got), IsNil)
+	resp.Body.Close()
+	c.Check(got.Blocks, DeepEquals, snapshot.Blocks)
+
+	resp, err = http.Post(otherServer.URL+"/games/"+id+"/flag", "application/json", strings.NewReader(`{"x":0,"y":0}`))
+	c.Assert(err, IsNil)
+	c.Assert(json.NewDecoder(resp.Body).Decode(&got), IsNil)
+	resp.Body.Close()
+	c.Check(got.Blocks[0][0], Equals, gominesweeper.Flagged)
+
+	// the first server loads the newer version before the next move
+	status = s.do(c, "POST", "/games/"+id+"/select", MoveRequest{X: 4, Y: 4}, &snapshot)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(snapshot.Blocks[0][0], Equals, gominesweeper.Flagged)
+
+	var errResp ErrorResponse
+	req, err := http.NewRequest("DELETE", s.server.URL+"/games/"+id, nil)
+	c.Assert(err, IsNil)
+	resp, err = http.DefaultClient.Do(req)
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	c.Check(resp.StatusCode, Equals, http.StatusNoContent)
+	status = s.do(c, "GET", "/games/"+id, nil, &errResp)
+	c.Check(status, Equals, http.StatusNotFound)
+}
+
+func (s *ServerSuite) TestVersionConflict(c *C) {
+	var snapshot Snapshot
+	status := s.do(c, "POST", "/games", GameRequest{Width: 5, Height: 5, Mines: 5}, &snapshot)
+	c.Assert(status, Equals, http.StatusCreated)
+
+	// a store that is saved to between the load and the save of a move
+	srv := s.server.Config.Handler.(*Server)
+	srv.Games = &racingGames{GameStore: srv.Games}
+
+	var errResp ErrorResponse
+	status = s.do(c, "POST", "/games/"+snapshot.ID+"/select", MoveRequest{X: 4, Y: 2}, &errResp)
+	c.Check(status, Equals, http.StatusConflict)
+	c.Check(errResp.Error, Equals, ErrVersionConflict.Error())
+}
+
+// racingGames is a GameStore where every game is saved by someone else just
+// before it is put.
+type racingGames struct {
+	GameStore
+}
+
+func (r *racingGames) Put(ctx context.Context, id string, data []byte, version int64) (int64, error) {
+	if _, err := r.GameStore.Put(ctx, id, data, version); err != nil {
+		return 0, err
+	}
+	return r.GameStore.Put(ctx, id, data, version)
+}
+
+func (s *ServerSuite) TestViewport(c *C) {
+	var snapshot Snapshot
+	status := s.do(c, "POST", "/games", GameRequest{Width: 5, Height: 5, Mines: 5}, &snapshot)
+	c.Assert(status, Equals, http.StatusCreated)
+	id := snapshot.ID
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(s.server.URL, "http")+"/games/"+id+"/events?x=3&y=2&width=2&height=3", nil)
+	c.Assert(err, IsNil)
+	defer conn.Close()
+
+	status = s.do(c, "POST", "/games/"+id+"/select", MoveRequest{X: 4, Y: 2}, &snapshot)
+	c.Assert(status, Equals, http.StatusOK)
+
+	var event wire.Event
+	c.Assert(conn.SetReadDeadline(time.Now().Add(time.Second)), IsNil)
+	c.Assert(conn.ReadJSON(&event), IsNil)
+	c.Check(event.Changes, DeepEquals, []wire.Change{
+		{X: 0, Y: 0, Value: 1}, {X: 1, Y: 0, Value: 0},
+		{X: 0, Y: 1, Value: 1}, {X: 1, Y: 1, Value: 1},
+	})
+
+	// the rectangle is cut down to the board
+	var view Snapshot
+	status = s.do(c, "GET", "/games/"+id+"?x=3&y=1&width=4&height=2", nil, &view)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(view.X, Equals, 3)
+	c.Check(view.Y, Equals, 1)
+	c.Check(view.Blocks, DeepEquals, [][]int{{2, 1}, {1, 0}})
+
+	// a size that would overflow is cut down too
+	status = s.do(c, "GET", "/games/"+id+"?x=1&y=1&width=1&height=9223372036854775807", nil, &view)
+	c.Assert(status, Equals, http.StatusOK)
+	c.Check(view.Blocks, HasLen, 4)
+	c.Check(view.Blocks[0], HasLen, 1)
+
+	var errResp ErrorResponse
+	status = s.do(c, "GET", "/games/"+id+"?x=3&y=1", nil, &errResp)
+	c.Check(status, Equals, http.StatusBadRequest)
+}
+
+func (s *ServerSuite) TestProtocolVersion(c *C) {
+	resp, err := http.Get(s.server.URL + "/games/missing")
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	c.Check(resp.Header.Get(wire.Header), Equals, "1")
+
+	req, err := http.NewRequest("GET", s.server.URL+"/games/missing", nil)
+	c.Assert(err, IsNil)
+	req.Header.Set(wire.Header, "99")
+	resp, err = http.DefaultClient.Do(req)
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	var errResp ErrorResponse
+	c.Assert(json.NewDecoder(resp.Body).Decode(&errResp), IsNil)
+	c.Check(resp.StatusCode, Equals, http.StatusBadRequest)
+	c.Check(errResp.Error, Equals, wire.ErrUnsupportedVersion.Error())
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrVersionConflict is returned when a game is saved over a newer version of it.
+var ErrVersionConflict = errors.New("game was changed by another request")
+
+// GameStore keeps the games of a server, saved as replays, so that they
+// survive restarts and can be shared by servers behind a load balancer.
+// Every save of a game gives it a new version, and a save is refused unless
+// it is of the version that was last loaded, so that two servers cannot both
+// make a move on the same game.  A GameStore must be safe for concurrent use.
+type GameStore interface {
+	// Get returns the saved game with the id and its version, or
+	// ErrNotFound.
+	Get(ctx context.Context, id string) (data []byte, version int64, err error)
+
+	// Put saves the game with the id if its version is still the one
+	// given, or if there is no such game and the version is 0, and returns
+	// its new version.  It returns ErrVersionConflict otherwise.
+	Put(ctx context.Context, id string, data []byte, version int64) (int64, error)
+
+	// Delete removes the game with the id, if there is one.
+	Delete(ctx context.Context, id string) error
+}
+
+// MemoryGames keeps games in memory only.  It is safe for concurrent use.
+type MemoryGames struct {
+	mu    sync.RWMutex
+	games map[string]savedGame
+}
+
+// savedGame is a game kept by MemoryGames.
+type savedGame struct {
+	data    []byte
+	version int64
+}
+
+// Get returns the saved game.
+func (m *MemoryGames) Get(ctx context.Context, id string) ([]byte, int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	saved, ok := m.games[id]
+	if !ok {
+		return nil, 0, ErrNotFound
+	}
+	return append([]byte(nil), saved.data...), saved.version, nil
+}
+
+// Put saves the game if it has not been saved since the version.
+func (m *MemoryGames) Put(ctx context.Context, id string, data []byte, version int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.games == nil {
+		m.games = make(map[string]savedGame)
+	}
+	if m.games[id].version != version {
+		return 0, ErrVersionConflict
+	}
+	m.games[id] = savedGame{append([]byte(nil), data...), version + 1}
+	return version + 1, nil
+}
+
+// Delete removes the game.
+func (m *MemoryGames) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.games, id)
+	return nil
+}
@@ -0,0 +1,188 @@
+// Package sim benchmarks minesweeper strategies by having them play many
+// seeded games at once.
+//
+// Every game is played on the minefield placed by the SeededSelector with its
+// own seed, by a player made for that game, so a run with the same options
+// gives the same wins and guesses every time:
+//
+//	result, err := sim.Simulate(ctx, sim.SolverBot, sim.Options{
+//		Config: gominesweeper.Config{Width: 9, Height: 9, Mines: 10},
+//		Games:  1000,
+//	})
+package sim
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+// Strategy makes the player of a game from its seed.  Each game gets its own
+// player, so players need not be safe for concurrent use.
+type Strategy func(seed int64) gominesweeper.Player
+
+// SolverBot is the strategy of the SolverBot.
+func SolverBot(seed int64) gominesweeper.Player {
+	return gominesweeper.NewSolverBot(seed)
+}
+
+// Options are what a run plays.
+type Options struct {
+	// Config is the config of every game; its Selector is replaced.
+	Config gominesweeper.Config
+
+	// Games is the number of games to play, seeded Seed, Seed+1 and so on.
+	Games int
+	Seed  int64
+
+	// Workers is the number of games played at once; defaults to
+	// GOMAXPROCS.
+	Workers int
+}
+
+// Summary adds up the games of a run.
+type Summary struct {
+	Games, Wins int
+
+	// Moves is the number of moves made across every game, of which Guesses
+	// revealed a block the Solver could not tell was safe.
+	Moves, Guesses int
+
+	// Time is the time spent playing every game, added up.
+	Time time.Duration
+}
+
+// WinRate returns the fraction of games that were won.
+func (s Summary) WinRate() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Games)
+}
+
+// AverageTime returns the average time spent playing a game.
+func (s Summary) AverageTime() time.Duration {
+	if s.Games == 0 {
+		return 0
+	}
+	return s.Time / time.Duration(s.Games)
+}
+
+// AverageGuesses returns the average number of guesses made in a game.
+func (s Summary) AverageGuesses() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Guesses) / float64(s.Games)
+}
+
+// add adds up the games of another summary.
+func (s *Summary) add(other Summary) {
+	s.Games += other.Games
+	s.Wins += other.Wins
+	s.Moves += other.Moves
+	s.Guesses += other.Guesses
+	s.Time += other.Time
+}
+
+// Simulate plays the games of the options with the strategy, spread across the
+// workers, and adds up their results.  It stops early with the context's error
+// once the context is done, or with the first error starting a game.
+func Simulate(ctx context.Context, strategy Strategy, opts Options) (Summary, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	seeds := make(chan int64)
+	go func() {
+		defer close(seeds)
+		for i := 0; i < opts.Games; i++ {
+			select {
+			case seeds <- opts.Seed + int64(i):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu     sync.Mutex
+		result Summary
+		first  error
+		wg     sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seed := range seeds {
+				game, err := play(strategy, opts.Config, seed)
+				mu.Lock()
+				if err != nil && first == nil {
+					first = err
+					cancel()
+				}
+				result.add(game)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if first != nil {
+		return result, first
+	}
+	return result, ctx.Err()
+}
+
+// play has the strategy play the game of the seed with RunGames.
+func play(strategy Strategy, cfg gominesweeper.Config, seed int64) (Summary, error) {
+	player := &counter{Player: strategy(seed)}
+	start := time.Now()
+	run, err := gominesweeper.RunGames(player, cfg, seed)
+	if err != nil {
+		return Summary{}, err
+	}
+	return Summary{
+		Games:   run.Games,
+		Wins:    run.Wins,
+		Moves:   player.moves,
+		Guesses: player.guesses,
+		Time:    time.Since(start),
+	}, nil
+}
+
+// counter counts the moves of a player, and the guesses among them.
+type counter struct {
+	gominesweeper.Player
+	moves, guesses int
+}
+
+func (c *counter) NextMove(snapshot gominesweeper.Snapshot) gominesweeper.Move {
+	move := c.Player.NextMove(snapshot)
+	c.moves++
+	if move.Action == gominesweeper.Reveal && !safe(snapshot, move.Position) {
+		c.guesses++
+	}
+	return move
+}
+
+// safe returns true if the block at the position is hidden and the Solver
+// knows it to be safe, or if it has already been revealed.
+func safe(snapshot gominesweeper.Snapshot, pos gominesweeper.Position) bool {
+	if value, ok := snapshot.Blocks[pos]; !ok || value != gominesweeper.Unknown {
+		return true
+	}
+	for _, known := range gominesweeper.NewSolver(snapshot).Safe() {
+		if known == pos {
+			return true
+		}
+	}
+	return false
+}
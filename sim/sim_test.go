@@ -0,0 +1,53 @@
+package sim
+
+import (
+	"context"
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type SimSuite struct{}
+
+var _ = Suite(&SimSuite{})
+
+var beginner = gominesweeper.Config{Width: 9, Height: 9, Mines: 10}
+
+func (s *SimSuite) TestSimulate(c *C) {
+	opts := Options{Config: beginner, Games: 40, Seed: 100, Workers: 4}
+	result, err := Simulate(context.Background(), SolverBot, opts)
+	c.Assert(err, IsNil)
+	c.Check(result.Games, Equals, 40)
+	c.Check(result.Wins > 0 && result.Wins < 40, Equals, true)
+	c.Check(result.Guesses >= result.Games, Equals, true)
+	c.Check(result.Moves > result.Guesses, Equals, true)
+	c.Check(result.AverageTime() > 0, Equals, true)
+
+	// the same seeds give the same results, however many workers play them
+	opts.Workers = 1
+	again, err := Simulate(context.Background(), SolverBot, opts)
+	c.Assert(err, IsNil)
+	c.Check([]int{again.Games, again.Wins, again.Moves, again.Guesses}, DeepEquals,
+		[]int{result.Games, result.Wins, result.Moves, result.Guesses})
+
+}
+
+func (s *SimSuite) TestSimulate_Errors(c *C) {
+	_, err := Simulate(context.Background(), SolverBot, Options{Config: gominesweeper.Config{Width: 2, Height: 2, Mines: 9}, Games: 3})
+	c.Check(err, Equals, gominesweeper.ErrExceedDimensions)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := Simulate(ctx, SolverBot, Options{Config: beginner, Games: 1000})
+	c.Check(err, Equals, context.Canceled)
+	c.Check(result.Games < 1000, Equals, true)
+}
+
+func (s *SimSuite) BenchmarkSimulate(c *C) {
+	for i := 0; i < c.N; i++ {
+		Simulate(context.Background(), SolverBot, Options{Config: beginner, Games: 100})
+	}
+}
@@ -0,0 +1,48 @@
+package gominesweeper
+
+// Snapshot is everything a player can see of a game.
+type Snapshot struct {
+	// Origin is where the snapshot starts when it is only of part of the
+	// minefield, such as the viewport of an InfiniteField.  The positions of
+	// Blocks are relative to it.
+	Origin Position
+
+	Width, Height int
+	Mines         int
+	Neighborhood  Neighborhood
+
+	State State
+	Lives uint
+
+	// Blocks holds the value from Display for every position.
+	Blocks map[Position]int
+}
+
+// Snapshot returns what the player can currently see of the game.
+func (g *Game) Snapshot() Snapshot {
+	return g.snapshot(g.Display())
+}
+
+// snapshot returns the game's snapshot with the blocks.
+func (g *Game) snapshot(blocks map[Position]int) Snapshot {
+	return Snapshot{
+		Width:        int(g.config.Width),
+		Height:       int(g.config.Height),
+		Mines:        int(g.config.Mines),
+		Neighborhood: g.config.Neighborhood,
+		State:        g.state,
+		Lives:        g.lives,
+		Blocks:       blocks,
+	}
+}
+
+// Neighbors returns the neighbors of the position that are within bounds.
+func (s Snapshot) Neighbors(pos Position) []Position {
+	var neighbors []Position
+	for _, neighbor := range s.Neighborhood.Neighbors(pos) {
+		if _, ok := s.Blocks[neighbor]; ok {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors
+}
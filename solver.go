@@ -0,0 +1,115 @@
+package gominesweeper
+
+import (
+	"github.com/smousa/go-minesweeper/minesolve"
+)
+
+// constraint is a set of hidden positions known to hold a number of mines.
+type constraint struct {
+	positions map[Position]bool
+	mines     int
+}
+
+// Solver deduces which hidden blocks of a snapshot are safe and which are
+// mines.  Flags are trusted to be on mines.
+type Solver struct {
+	snapshot    Snapshot
+	safe, mines map[Position]bool
+	constraints []constraint
+}
+
+// NewSolver solves as much of the snapshot as can be deduced with the
+// minesolve package.  Nothing is deduced from a snapshot that contradicts
+// itself, such as one with a flag that is not on a mine.
+func NewSolver(snapshot Snapshot) *Solver {
+	s := &Solver{
+		snapshot: snapshot,
+		safe:     make(map[Position]bool),
+		mines:    make(map[Position]bool),
+	}
+	problem := s.problem()
+	solution, err := minesolve.Deduce(problem)
+	if err != nil {
+		solution = minesolve.Solution{Constraints: problem.Constraints}
+	}
+	for _, c := range solution.Safe {
+		s.safe[Position{X: c.X, Y: c.Y}] = true
+	}
+	for _, c := range solution.Mines {
+		s.mines[Position{X: c.X, Y: c.Y}] = true
+	}
+	for _, c := range solution.Constraints {
+		positions := make(map[Position]bool, len(c.Cells))
+		for _, cell := range c.Cells {
+			positions[Position{X: cell.X, Y: cell.Y}] = true
+		}
+		s.constraints = append(s.constraints, constraint{positions, c.Mines})
+	}
+	return s
+}
+
+// problem returns the revealed numbers of the snapshot as constraints on the
+// hidden blocks around them, ordered by row so that they are always deduced
+// the same way.
+func (s *Solver) problem() minesolve.Problem {
+	problem := minesolve.Problem{Mines: -1}
+	var numbers []Position
+	for pos, value := range s.snapshot.Blocks {
+		if value >= 0 {
+			numbers = append(numbers, pos)
+		}
+	}
+	sortPositions(numbers)
+	for _, pos := range numbers {
+		c := minesolve.Constraint{Mines: s.snapshot.Blocks[pos]}
+		for _, neighbor := range s.snapshot.Neighbors(pos) {
+			if s.known(neighbor) {
+				c.Mines--
+			} else if s.hidden(neighbor) {
+				c.Cells = append(c.Cells, cell(neighbor))
+			}
+		}
+		if len(c.Cells) > 0 {
+			problem.Constraints = append(problem.Constraints, c)
+		}
+	}
+	return problem
+}
+
+// Safe returns the hidden blocks that are known not to be mines, ordered by
+// row.
+func (s *Solver) Safe() []Position {
+	return sortedKeys(s.safe)
+}
+
+// Mines returns the hidden, unflagged blocks that are known to be mines,
+// ordered by row.
+func (s *Solver) Mines() []Position {
+	return sortedKeys(s.mines)
+}
+
+// hidden returns true if the block at the position has not been revealed and
+// is not yet known to be safe or a mine.
+func (s *Solver) hidden(pos Position) bool {
+	value, ok := s.snapshot.Blocks[pos]
+	return ok && (value == Unknown || value == Questioned) && !s.safe[pos] && !s.mines[pos]
+}
+
+// known returns true if the block at the position is known to be a mine.
+func (s *Solver) known(pos Position) bool {
+	switch s.snapshot.Blocks[pos] {
+	case Mine, Exploded, Flagged, Defused:
+		return true
+	}
+	return s.mines[pos]
+}
+
+// sortedKeys returns the positions of the set, ordered by row.
+func sortedKeys(set map[Position]bool) []Position {
+	positions := make([]Position, 0, len(set))
+	for pos := range set {
+		positions = append(positions, pos)
+	}
+	sortPositions(positions)
+	return positions
+}
@@ -0,0 +1,121 @@
+package gominesweeper
+
+import (
+	"encoding/json"
+	"math"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestSolver(c *C) {
+	game := newTestGame(c, Config{})
+	solver := NewSolver(game.Snapshot())
+	c.Check(solver.Safe(), HasLen, 0)
+	c.Check(solver.Mines(), HasLen, 0)
+
+	// (4,3) leaves 1 mine between (3,4) and (4,4), so the rest of what
+	// borders (3,3) is safe, which places the mine of (3,2) on (2,1) and in
+	// turn clears (2,0) through (3,1)
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	solver = NewSolver(game.Snapshot())
+	c.Check(solver.Safe(), DeepEquals, []Position{{X: 2, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 3}, {X: 2, Y: 4}})
+	c.Check(solver.Mines(), DeepEquals, []Position{{X: 2, Y: 1}})
+
+	// flags are trusted
+	c.Assert(game.ToggleFlag(4, 4), IsNil)
+	solver = NewSolver(game.Snapshot())
+	c.Check(solver.Safe(), DeepEquals, []Position{{X: 2, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 3}, {X: 2, Y: 4}, {X: 3, Y: 4}})
+}
+
+func (s *MSSuite) TestSnapshot(c *C) {
+	game := newTestGame(c, Config{Lives: 3})
+	snapshot := game.Snapshot()
+	c.Check(snapshot.Width, Equals, 5)
+	c.Check(snapshot.Height, Equals, 5)
+	c.Check(snapshot.Mines, Equals, 5)
+	c.Check(snapshot.State, Equals, Playing)
+	c.Check(snapshot.Lives, Equals, uint(3))
+	c.Check(snapshot.Blocks, DeepEquals, game.Display())
+	c.Check(snapshot.Neighbors(Position{X: 0, Y: 0}), HasLen, 3)
+}
+
+func (s *MSSuite) TestSolver_Estimate(c *C) {
+	game := newTestGame(c, Config{})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	solver := NewSolver(game.Snapshot())
+
+	estimate := solver.Estimate(7, 2000)
+	c.Check(estimate.Seed, Equals, int64(7))
+	c.Check(estimate.Iterations, Equals, 2000)
+	c.Check(estimate.Accepted > 0, Equals, true)
+	c.Check(estimate.Hints, HasLen, 19)
+	c.Check(estimate.Hints[Position{X: 2, Y: 0}], Equals, Hint{Risk: 0, Confidence: Exact})
+	c.Check(estimate.Hints[Position{X: 2, Y: 1}], Equals, Hint{Risk: 1, Confidence: Exact})
+	for pos, hint := range estimate.Hints {
+		c.Check(hint.Risk >= 0 && hint.Risk <= 1, Equals, true, Commentf("block %v", pos))
+	}
+	c.Check(estimate.Hints[Position{X: 0, Y: 0}].Confidence, Equals, Simulated)
+	c.Check(estimate.Hints[Position{X: 0, Y: 0}].Samples, Equals, estimate.Accepted)
+
+	// the same seed and iterations give the same estimate
+	c.Check(solver.Estimate(7, 2000), DeepEquals, estimate)
+	c.Check(solver.Estimate(8, 2000), Not(DeepEquals), estimate)
+
+	// without any iterations the solver's risk is used
+	fallback := solver.Estimate(7, 0)
+	c.Check(fallback.Accepted, Equals, 0)
+	c.Check(fallback.Hints[Position{X: 0, Y: 0}], Equals, Hint{Risk: solver.Risk(Position{X: 0, Y: 0}), Confidence: Heuristic})
+
+	// the hints are encoded by row, and decode to the same estimate
+	data, err := json.Marshal(fallback)
+	c.Assert(err, IsNil)
+	var decoded Estimate
+	c.Assert(json.Unmarshal(data, &decoded), IsNil)
+	c.Check(decoded, DeepEquals, fallback)
+	var encoded struct {
+		Hints []struct {
+			X, Y       int
+			Risk       float64
+			Confidence string
+		}
+	}
+	c.Assert(json.Unmarshal(data, &encoded), IsNil)
+	c.Check(encoded.Hints, HasLen, 19)
+	c.Check(encoded.Hints[0].X, Equals, 0)
+	c.Check(encoded.Hints[0].Y, Equals, 0)
+	c.Check(encoded.Hints[0].Confidence, Equals, "heuristic")
+
+	text, err := Simulated.MarshalText()
+	c.Assert(err, IsNil)
+	var confidence Confidence
+	c.Assert(confidence.UnmarshalText(text), IsNil)
+	c.Check(confidence, Equals, Simulated)
+}
+
+func (s *MSSuite) TestSolver_Probabilities(c *C) {
+	game := newTestGame(c, Config{})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	probabilities := NewSolver(game.Snapshot()).Probabilities()
+	c.Check(probabilities, HasLen, 19)
+
+	// deduced blocks are certain
+	c.Check(probabilities[Position{X: 2, Y: 0}], Equals, 0.0)
+	c.Check(probabilities[Position{X: 2, Y: 1}], Equals, 1.0)
+
+	// the mine next to (4,3) is as likely to be on (3,4) as on (4,4), and so
+	// is the one next to (4,1) on (3,0) and (4,0), which leaves the other 2
+	// mines spread among the 10 blocks that border no number
+	c.Check(probabilities[Position{X: 3, Y: 4}], Equals, 0.5)
+	c.Check(probabilities[Position{X: 4, Y: 4}], Equals, 0.5)
+	c.Check(probabilities[Position{X: 3, Y: 0}], Equals, 0.5)
+	c.Check(math.Abs(probabilities[Position{X: 0, Y: 0}]-0.2) < 1e-9, Equals, true)
+
+	sum := 0.0
+	for _, p := range probabilities {
+		sum += p
+	}
+	c.Check(math.Abs(sum-5) < 1e-9, Equals, true)
+}
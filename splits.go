@@ -0,0 +1,64 @@
+package gominesweeper
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Split is the time at which a fraction of a game's 3BV was solved.
+type Split struct {
+	Fraction float64       `json:"fraction"`
+	Elapsed  time.Duration `json:"elapsed"`
+	Reached  bool          `json:"reached"`
+}
+
+// Splits are the splits of a single game, such as a personal best.
+type Splits []Split
+
+// ReadSplits reads splits that were previously written with Write.
+func ReadSplits(r io.Reader) (Splits, error) {
+	var splits Splits
+	if err := json.NewDecoder(r).Decode(&splits); err != nil {
+		return nil, err
+	}
+	return splits, nil
+}
+
+// Write writes the splits as JSON, e.g. to save a personal best.
+func (s Splits) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// Compare returns how far ahead (negative) or behind (positive) each split is
+// compared to the same split of the personal best.  Splits that have not been
+// reached by both are left out.
+func (s Splits) Compare(best Splits) map[float64]time.Duration {
+	deltas := make(map[float64]time.Duration)
+	for _, split := range s {
+		for _, other := range best {
+			if split.Fraction == other.Fraction && split.Reached && other.Reached {
+				deltas[split.Fraction] = split.Elapsed - other.Elapsed
+			}
+		}
+	}
+	return deltas
+}
+
+// Splits returns the splits of the game so far.
+func (g *Game) Splits() Splits {
+	splits := make(Splits, len(g.splits))
+	copy(splits, g.splits)
+	return splits
+}
+
+// split marks any splits that have been reached at the elapsed time.
+func (g *Game) split(elapsed time.Duration) {
+	total, solved := g.minefield.bbbv()
+	for i := range g.splits {
+		if !g.splits[i].Reached && float64(solved) >= g.splits[i].Fraction*float64(total) {
+			g.splits[i].Elapsed = elapsed
+			g.splits[i].Reached = true
+		}
+	}
+}
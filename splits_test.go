@@ -0,0 +1,44 @@
+package gominesweeper
+
+import (
+	"bytes"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_Splits(c *C) {
+	game := newTestGame(c, Config{Splits: []float64{0.25, 0.5, 1}})
+	game.clock = fakeClock()
+	c.Check(game.Splits(), DeepEquals, Splits{{Fraction: 0.25}, {Fraction: 0.5}, {Fraction: 1}})
+
+	// 3BV is 10: 2 openings and 8 numbers
+	for _, pos := range []Position{{X: 4, Y: 2}, {X: 0, Y: 4}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}} {
+		_, err := game.Select(pos.X, pos.Y)
+		c.Assert(err, IsNil)
+	}
+	splits := game.Splits()
+	c.Check(splits, DeepEquals, Splits{
+		{Fraction: 0.25, Elapsed: 2 * time.Second, Reached: true},
+		{Fraction: 0.5, Elapsed: 4 * time.Second, Reached: true},
+		{Fraction: 1},
+	})
+
+	// flags do not count towards the 3BV
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	c.Check(game.Splits(), DeepEquals, splits)
+
+	var buf bytes.Buffer
+	c.Assert(splits.Write(&buf), IsNil)
+	best, err := ReadSplits(&buf)
+	c.Assert(err, IsNil)
+	c.Check(best, DeepEquals, splits)
+
+	best[0].Elapsed = 3 * time.Second
+	best[1].Elapsed = 3 * time.Second
+	best[2] = Split{Fraction: 1, Elapsed: 10 * time.Second, Reached: true}
+	c.Check(splits.Compare(best), DeepEquals, map[float64]time.Duration{
+		0.25: -time.Second,
+		0.5:  time.Second,
+	})
+}
@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"math"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+// adaptiveGain is how far the density of mines moves for each point the recent
+// win rate is off the target.
+const adaptiveGain = 0.1
+
+// Adaptive recommends the next board for a player, making it harder while
+// they win more often than the Target and easier while they win less often,
+// by changing the density of mines.
+type Adaptive struct {
+	Width, Height uint
+
+	// Target is the win rate to hold, e.g. 0.5.
+	Target float64
+
+	// Density is the fraction of blocks that are mines on the next board,
+	// kept between MinDensity and MaxDensity.
+	Density, MinDensity, MaxDensity float64
+
+	// played is the number of games played when the density last changed
+	played int
+}
+
+// NewAdaptive returns an adaptive difficulty for boards of the size, starting
+// at the density of an intermediate board.
+func NewAdaptive(width, height uint, target float64) *Adaptive {
+	return &Adaptive{
+		Width:      width,
+		Height:     height,
+		Target:     target,
+		Density:    40.0 / 256,
+		MinDensity: 0.05,
+		MaxDensity: 0.25,
+	}
+}
+
+// Next returns the config of the next board, after moving the density by how
+// far the recent win rate of the stats is off the target.  The density only
+// moves once for each game played.
+func (a *Adaptive) Next(stats Stats) gominesweeper.Config {
+	if stats.Played > a.played && len(stats.Recent) > 0 {
+		a.Density += adaptiveGain * (stats.RecentWinRate() - a.Target)
+		a.Density = math.Max(a.MinDensity, math.Min(a.MaxDensity, a.Density))
+		a.played = stats.Played
+	}
+
+	size := a.Width * a.Height
+	mines := uint(math.Round(a.Density * float64(size)))
+	if mines < 1 {
+		mines = 1
+	} else if mines >= size {
+		mines = size - 1
+	}
+	return gominesweeper.Config{Width: a.Width, Height: a.Height, Mines: mines}
+}
@@ -0,0 +1,29 @@
+package stats
+
+import (
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func (s *StatsSuite) TestAdaptive(c *C) {
+	tracker, err := NewTracker(&MemoryStore{})
+	c.Assert(err, IsNil)
+	adaptive := NewAdaptive(16, 16, 0.5)
+	c.Check(adaptive.Next(tracker.Stats()).Mines, Equals, uint(40))
+
+	// winning makes the boards harder, but only once per game
+	c.Assert(tracker.Record(gominesweeper.GameResult{State: gominesweeper.Won}), IsNil)
+	c.Check(adaptive.Next(tracker.Stats()).Mines, Equals, uint(53))
+	c.Check(adaptive.Next(tracker.Stats()).Mines, Equals, uint(53))
+
+	// losing makes them easier, down to the least density
+	for i := 0; i < RecentGames; i++ {
+		c.Assert(tracker.Record(gominesweeper.GameResult{State: gominesweeper.Lost}), IsNil)
+		adaptive.Next(tracker.Stats())
+	}
+	cfg := adaptive.Next(tracker.Stats())
+	c.Check(cfg.Mines, Equals, uint(13))
+	c.Check([]uint{cfg.Width, cfg.Height}, DeepEquals, []uint{16, 16})
+	_, err = gominesweeper.NewGame(cfg)
+	c.Check(err, IsNil)
+}
@@ -0,0 +1,234 @@
+// Package stats keeps statistics of finished minesweeper games: games played,
+// win rate, streaks, best times and average 3BV/s.
+//
+// A Tracker can be hooked up to every new game so that results are recorded
+// as soon as a game is over:
+//
+//	tracker, err := stats.NewTracker(stats.FileStore("stats.json"))
+//	...
+//	cfg.OnEnd = tracker.OnEnd
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+// Stats are the statistics of every game recorded.
+type Stats struct {
+	Played int `json:"played"`
+	Won    int `json:"won"`
+
+	// Streak is the number of games won in a row so far, and BestStreak is
+	// the longest Streak there has been.
+	Streak     int `json:"streak"`
+	BestStreak int `json:"best_streak"`
+
+	// Best holds the fastest win for each preset, by the preset's name or,
+	// for games that were not played by a registered preset, by their size
+	// such as "10x8/12".
+	Best map[string]time.Duration `json:"best"`
+
+	// BBBVPerSecond is the sum of the 3BV/s of every win, to be averaged.
+	BBBVPerSecond float64 `json:"bbbv_per_second"`
+
+	// Recent holds whether each of the last RecentGames games was won,
+	// oldest first.
+	Recent []bool `json:"recent"`
+}
+
+// RecentGames is the number of games kept in Stats.Recent.
+const RecentGames = 20
+
+// WinRate returns the fraction of games that were won.
+func (s Stats) WinRate() float64 {
+	if s.Played == 0 {
+		return 0
+	}
+	return float64(s.Won) / float64(s.Played)
+}
+
+// RecentWinRate returns the fraction of the recent games that were won.
+func (s Stats) RecentWinRate() float64 {
+	if len(s.Recent) == 0 {
+		return 0
+	}
+	won := 0
+	for _, win := range s.Recent {
+		if win {
+			won++
+		}
+	}
+	return float64(won) / float64(len(s.Recent))
+}
+
+// AverageBBBVPerSecond returns the average 3BV/s of the games that were won.
+func (s Stats) AverageBBBVPerSecond() float64 {
+	if s.Won == 0 {
+		return 0
+	}
+	return s.BBBVPerSecond / float64(s.Won)
+}
+
+// add records the result.
+func (s *Stats) add(result gominesweeper.GameResult) {
+	s.Played++
+	s.Recent = append(s.Recent, result.State == gominesweeper.Won)
+	if len(s.Recent) > RecentGames {
+		s.Recent = s.Recent[len(s.Recent)-RecentGames:]
+	}
+	if result.State != gominesweeper.Won {
+		s.Streak = 0
+		return
+	}
+
+	s.Won++
+	if s.Streak++; s.Streak > s.BestStreak {
+		s.BestStreak = s.Streak
+	}
+	if s.Best == nil {
+		s.Best = make(map[string]time.Duration)
+	}
+	preset := Preset(result)
+	if best, ok := s.Best[preset]; !ok || result.Elapsed < best {
+		s.Best[preset] = result.Elapsed
+	}
+	s.BBBVPerSecond += result.BBBVPerSecond()
+}
+
+// Preset returns the name of the registered rules the result's game was
+// played by, judging by its size, or its size such as "10x8/12" if there are
+// none.
+func Preset(result gominesweeper.GameResult) string {
+	for _, name := range gominesweeper.RulesNames() {
+		rules, err := gominesweeper.LookupRules(name)
+		if err == nil && rules.Width == result.Width && rules.Height == result.Height && rules.Mines == result.Mines {
+			return name
+		}
+	}
+	return fmt.Sprintf("%dx%d/%d", result.Width, result.Height, result.Mines)
+}
+
+// Store keeps the stats between runs.
+type Store interface {
+	// Load returns the stats that were saved, or empty stats if there are
+	// none.
+	Load() (Stats, error)
+	Save(stats Stats) error
+}
+
+// MemoryStore keeps the stats in memory only.
+type MemoryStore struct {
+	stats Stats
+}
+
+// Load returns the stats that were saved.
+func (m *MemoryStore) Load() (Stats, error) {
+	return m.stats, nil
+}
+
+// Save keeps the stats.
+func (m *MemoryStore) Save(stats Stats) error {
+	m.stats = stats
+	return nil
+}
+
+// FileStore keeps the stats as JSON in the file at the path.
+type FileStore string
+
+// Load reads the stats from the file.
+func (f FileStore) Load() (Stats, error) {
+	var stats Stats
+	data, err := os.ReadFile(string(f))
+	if errors.Is(err, os.ErrNotExist) {
+		return stats, nil
+	} else if err != nil {
+		return stats, err
+	}
+	err = json.Unmarshal(data, &stats)
+	return stats, err
+}
+
+// Save writes the stats to the file, replacing it only once they have all been
+// written.
+func (f FileStore) Save(stats Stats) error {
+	data, err := json.MarshalIndent(stats, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(string(f)), filepath.Base(string(f))+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	} else if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), string(f))
+}
+
+// Tracker records results into stats, saving them to its store after every
+// result.  It is safe for concurrent use.
+type Tracker struct {
+	mu    sync.Mutex
+	store Store
+	stats Stats
+	err   error
+}
+
+// NewTracker returns a tracker that carries on from the stats in the store.
+func NewTracker(store Store) (*Tracker, error) {
+	stats, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Tracker{store: store, stats: stats}, nil
+}
+
+// Record adds the result to the stats and saves them.
+func (t *Tracker) Record(result gominesweeper.GameResult) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stats.add(result)
+	return t.store.Save(t.stats)
+}
+
+// OnEnd records the result of the game, so that it can be used as a Config's
+// OnEnd.  Errors saving the stats are kept by Err.
+func (t *Tracker) OnEnd(_ *gominesweeper.Game, result gominesweeper.GameResult) {
+	if err := t.Record(result); err != nil {
+		t.mu.Lock()
+		t.err = err
+		t.mu.Unlock()
+	}
+}
+
+// Err returns the last error from saving the stats in OnEnd.
+func (t *Tracker) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+// Stats returns the stats so far.
+func (t *Tracker) Stats() Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := t.stats
+	stats.Recent = append([]bool(nil), t.stats.Recent...)
+	stats.Best = make(map[string]time.Duration, len(t.stats.Best))
+	for preset, best := range t.stats.Best {
+		stats.Best[preset] = best
+	}
+	return stats
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type StatsSuite struct{}
+
+var _ = Suite(&StatsSuite{})
+
+func (s *StatsSuite) TestTracker(c *C) {
+	path := filepath.Join(c.MkDir(), "stats.json")
+	tracker, err := NewTracker(FileStore(path))
+	c.Assert(err, IsNil)
+
+	for _, result := range []gominesweeper.GameResult{
+		{Width: 9, Height: 9, Mines: 10, State: gominesweeper.Won, Elapsed: 20 * time.Second, BBBV: 30, Solved: 30},
+		{Width: 9, Height: 9, Mines: 10, State: gominesweeper.Won, Elapsed: 10 * time.Second, BBBV: 20, Solved: 20},
+		{Width: 9, Height: 9, Mines: 10, State: gominesweeper.Lost, Elapsed: 5 * time.Second, BBBV: 25, Solved: 3},
+		{Width: 10, Height: 8, Mines: 12, State: gominesweeper.Won, Elapsed: 25 * time.Second, BBBV: 25, Solved: 25},
+	} {
+		c.Assert(tracker.Record(result), IsNil)
+	}
+
+	stats := tracker.Stats()
+	c.Check(stats.Played, Equals, 4)
+	c.Check(stats.WinRate(), Equals, 0.75)
+	c.Check(stats.Streak, Equals, 1)
+	c.Check(stats.BestStreak, Equals, 2)
+	c.Check(stats.Best, DeepEquals, map[string]time.Duration{
+		"beginner": 10 * time.Second,
+		"10x8/12":  25 * time.Second,
+	})
+	c.Check(stats.AverageBBBVPerSecond(), Equals, (1.5+2+1)/3)
+	c.Check(stats.Recent, DeepEquals, []bool{true, true, false, true})
+	c.Check(stats.RecentWinRate(), Equals, 0.75)
+
+	// the stats carry on from the file
+	again, err := NewTracker(FileStore(path))
+	c.Assert(err, IsNil)
+	c.Check(again.Stats(), DeepEquals, stats)
+}
+
+func (s *StatsSuite) TestOnEnd(c *C) {
+	tracker, err := NewTracker(&MemoryStore{})
+	c.Assert(err, IsNil)
+
+	game, err := gominesweeper.NewGame(gominesweeper.Config{
+		Width: 5, Height: 5, Mines: 5,
+		Selector: func(width, height, max uint) ([]gominesweeper.Position, error) {
+			return []gominesweeper.Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+		},
+		OnEnd: tracker.OnEnd,
+	})
+	c.Assert(err, IsNil)
+	_, ok := game.Result()
+	c.Check(ok, Equals, false)
+
+	_, err = game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(tracker.Stats().Played, Equals, 0)
+	_, err = game.Select(0, 0)
+	c.Assert(err, IsNil)
+
+	stats := tracker.Stats()
+	c.Check(stats.Played, Equals, 1)
+	c.Check(stats.Won, Equals, 0)
+	c.Check(tracker.Err(), IsNil)
+
+	result, ok := game.Result()
+	c.Check(ok, Equals, true)
+	c.Check(result.State, Equals, gominesweeper.Lost)
+	c.Check(result.BBBV, Equals, 10)
+	c.Check(result.Solved, Equals, 1)
+	c.Check(Preset(result), Equals, "5x5/5")
+}
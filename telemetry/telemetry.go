@@ -0,0 +1,86 @@
+// Package telemetry reports how minesweeper games are played to OpenTelemetry,
+// so that a hosted server can be monitored without changing the games:
+//
+//	metrics, err := telemetry.New(otel.Meter("minesweeper"))
+//	...
+//	srv := server.New()
+//	srv.Metrics = metrics
+//
+// The instruments it records are:
+//
+//	minesweeper.games.created     counter of games started, by mode
+//	minesweeper.move.duration     histogram of seconds taken by each move,
+//	                              by action and whether it failed
+//	minesweeper.flood_fill.size   histogram of blocks revealed by a move
+//	minesweeper.solver.duration   histogram of seconds taken to solve a game
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Metrics records the metrics of games with the instruments of a meter.
+type Metrics struct {
+	games  metric.Int64Counter
+	moves  metric.Float64Histogram
+	fills  metric.Int64Histogram
+	solver metric.Float64Histogram
+}
+
+// New returns metrics that record with the instruments it creates on the
+// meter.
+func New(meter metric.Meter) (*Metrics, error) {
+	var m Metrics
+	var err error
+	if m.games, err = meter.Int64Counter("minesweeper.games.created",
+		metric.WithDescription("Games started."),
+		metric.WithUnit("{game}")); err != nil {
+		return nil, err
+	}
+	if m.moves, err = meter.Float64Histogram("minesweeper.move.duration",
+		metric.WithDescription("Time taken to make a move."),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if m.fills, err = meter.Int64Histogram("minesweeper.flood_fill.size",
+		metric.WithDescription("Blocks revealed by a move."),
+		metric.WithUnit("{block}")); err != nil {
+		return nil, err
+	}
+	if m.solver, err = meter.Float64Histogram("minesweeper.solver.duration",
+		metric.WithDescription("Time taken to solve what a player can see."),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GameCreated counts the game.
+func (m *Metrics) GameCreated(cfg gominesweeper.Config) {
+	m.games.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("mode", cfg.Mode.String()),
+	))
+}
+
+// MoveApplied records the time the move took.
+func (m *Metrics) MoveApplied(action gominesweeper.Action, latency time.Duration, err error) {
+	m.moves.Record(context.Background(), latency.Seconds(), metric.WithAttributes(
+		attribute.String("action", action.String()),
+		attribute.Bool("error", err != nil),
+	))
+}
+
+// FloodFilled records the number of blocks revealed.
+func (m *Metrics) FloodFilled(size int) {
+	m.fills.Record(context.Background(), int64(size))
+}
+
+// Solved records the time the solver took.
+func (m *Metrics) Solved(latency time.Duration) {
+	m.solver.Record(context.Background(), latency.Seconds())
+}
@@ -0,0 +1,60 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type TelemetrySuite struct{}
+
+var _ = Suite(&TelemetrySuite{})
+
+func (s *TelemetrySuite) TestMetrics(c *C) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	metrics, err := New(provider.Meter("minesweeper"))
+	c.Assert(err, IsNil)
+
+	game, err := gominesweeper.NewGame(gominesweeper.Config{
+		Width: 5, Height: 5, Mines: 5,
+		Selector: func(width, height, max uint) ([]gominesweeper.Position, error) {
+			return []gominesweeper.Position{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}}, nil
+		},
+		Metrics: metrics,
+	})
+	c.Assert(err, IsNil)
+	_, err = game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	game.Solver()
+
+	var data metricdata.ResourceMetrics
+	c.Assert(reader.Collect(context.Background(), &data), IsNil)
+	c.Assert(data.ScopeMetrics, HasLen, 1)
+	recorded := make(map[string]metricdata.Aggregation)
+	for _, m := range data.ScopeMetrics[0].Metrics {
+		recorded[m.Name] = m.Data
+	}
+
+	games := recorded["minesweeper.games.created"].(metricdata.Sum[int64])
+	c.Check(games.DataPoints, HasLen, 1)
+	c.Check(games.DataPoints[0].Value, Equals, int64(1))
+
+	moves := recorded["minesweeper.move.duration"].(metricdata.Histogram[float64])
+	c.Check(moves.DataPoints, HasLen, 2)
+
+	fills := recorded["minesweeper.flood_fill.size"].(metricdata.Histogram[int64])
+	c.Assert(fills.DataPoints, HasLen, 1)
+	c.Check(fills.DataPoints[0].Count, Equals, uint64(1))
+	c.Check(fills.DataPoints[0].Sum, Equals, int64(6))
+
+	solver := recorded["minesweeper.solver.duration"].(metricdata.Histogram[float64])
+	c.Check(solver.DataPoints[0].Count, Equals, uint64(1))
+}
@@ -0,0 +1,73 @@
+package gominesweeper
+
+import (
+	"time"
+)
+
+// MoveLimit limits a Game to making Moves moves within any span of Per.
+type MoveLimit struct {
+	Moves uint
+	Per   time.Duration
+}
+
+// MoveValidator decides whether a Game may make a move, e.g. to reject
+// clicks faster than a person can make or moves sent twice.  Any error it
+// returns rejects the move.
+type MoveValidator func(g *Game, move Move) error
+
+// RejectionError is returned for a move that a Game refused to make because
+// of its MoveLimit or MoveValidator.  It matches its Reason with errors.Is,
+// along with the validator's error.
+type RejectionError struct {
+	Move Move
+
+	// Reason is ErrRateLimited or ErrMoveRejected.
+	Reason error
+
+	// Err is the error returned by the MoveValidator.
+	Err error
+}
+
+// Error describes the rejection.
+func (e *RejectionError) Error() string {
+	if e.Err != nil {
+		return e.Reason.Error() + ": " + e.Err.Error()
+	}
+	return e.Reason.Error()
+}
+
+// Unwrap returns the reason along with the validator's error.
+func (e *RejectionError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Reason, e.Err}
+	}
+	return []error{e.Reason}
+}
+
+// admit returns a RejectionError if the move is over the game's limit or
+// fails its validator, and otherwise counts it towards the limit.
+func (g *Game) admit(move Move) error {
+	limit := g.config.MoveLimit
+	var now time.Time
+	if limit.Moves > 0 {
+		now = g.clock()
+		recent := g.recent[:0]
+		for _, t := range g.recent {
+			if now.Sub(t) < limit.Per {
+				recent = append(recent, t)
+			}
+		}
+		if g.recent = recent; uint(len(recent)) >= limit.Moves {
+			return &RejectionError{Move: move, Reason: ErrRateLimited}
+		}
+	}
+	if g.config.ValidateMove != nil {
+		if err := g.config.ValidateMove(g, move); err != nil {
+			return &RejectionError{Move: move, Reason: ErrMoveRejected, Err: err}
+		}
+	}
+	if limit.Moves > 0 {
+		g.recent = append(g.recent, now)
+	}
+	return nil
+}
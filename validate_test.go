@@ -0,0 +1,49 @@
+package gominesweeper
+
+import (
+	"errors"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_MoveLimit(c *C) {
+	now := time.Unix(0, 0)
+	game := newTestGame(c, Config{MoveLimit: MoveLimit{Moves: 2, Per: time.Second}})
+	game.clock = func() time.Time { return now }
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	_, err = game.Select(0, 4)
+	c.Check(errors.Is(err, ErrRateLimited), Equals, true)
+	c.Check(err, DeepEquals, &RejectionError{Move: Move{Reveal, Position{X: 0, Y: 4}}, Reason: ErrRateLimited})
+	c.Check(game.Replay().Moves, HasLen, 2)
+
+	// rejected moves do not count towards the limit
+	now = now.Add(time.Second)
+	_, err = game.Select(0, 4)
+	c.Check(err, IsNil)
+}
+
+func (s *MSSuite) TestGame_ValidateMove(c *C) {
+	errTooFar := errors.New("too far")
+	game := newTestGame(c, Config{
+		ValidateMove: func(g *Game, move Move) error {
+			if move.X > 3 {
+				return errTooFar
+			}
+			return nil
+		},
+	})
+	game.clock = fakeClock()
+
+	_, err := game.Select(0, 4)
+	c.Assert(err, IsNil)
+	c.Check(game.ToggleFlag(4, 0), DeepEquals, &RejectionError{Move: Move{Flag, Position{X: 4, Y: 0}}, Reason: ErrMoveRejected, Err: errTooFar})
+	_, err = game.Select(4, 2)
+	c.Check(errors.Is(err, ErrMoveRejected), Equals, true)
+	c.Check(errors.Is(err, errTooFar), Equals, true)
+	c.Check(err.Error(), Equals, "move was rejected: too far")
+	c.Check(game.Display()[Position{X: 4, Y: 2}], Equals, Unknown)
+}
@@ -0,0 +1,45 @@
+package gominesweeper
+
+// PlayerView returns what the player has found of the game, for sending to
+// them when the game is played over a server.  Unlike Snapshot, it holds
+// nothing about the mines beyond what the player's own moves have found, even
+// once the game is over: the mines shown when the game is lost and flagged
+// when it is won are left hidden, and flags that were not on a mine are shown
+// as flags rather than WrongFlag.  The mines that were set off or defused are
+// shown, as are the player's flags and question marks.
+func (g *Game) PlayerView() Snapshot {
+	return g.view(true)
+}
+
+// SpectatorView returns what someone watching the game may see of it, which
+// is PlayerView without the player's flags and question marks, as those would
+// give away what the player has worked out of the mines.
+func (g *Game) SpectatorView() Snapshot {
+	return g.view(false)
+}
+
+// view returns what the player has found of the game, with their marks if
+// they are to be shown.
+func (g *Game) view(marks bool) Snapshot {
+	blocks := make(map[Position]int)
+	g.minefield.each(func(pos Position, block *Block) {
+		value := Unknown
+		switch {
+		case block.exploded():
+			value = Exploded
+		case g.defused[pos]:
+			value = Defused
+		case block.checked() && block.proximity() != Mine:
+			value = block.proximity()
+			if g.config.Blind {
+				value = Revealed
+			}
+		case marks && block.flagged() && !g.endFlags[pos]:
+			value = Flagged
+		case marks && block.questioned():
+			value = Questioned
+		}
+		blocks[pos] = value
+	})
+	return g.snapshot(blocks)
+}
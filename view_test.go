@@ -0,0 +1,97 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_PlayerView(c *C) {
+	game := newTestGame(c, Config{})
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	c.Assert(game.ToggleFlag(1, 1), IsNil)
+	c.Assert(game.ToggleFlag(1, 1), IsNil)
+	_, err := game.Apply(Move{Question, Position{X: 2, Y: 2}})
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(4, 4), IsNil)
+	_, err = game.Select(4, 2)
+	c.Assert(err, IsNil)
+
+	player, spectator := game.PlayerView(), game.SpectatorView()
+	c.Check(player.Blocks[Position{X: 0, Y: 0}], Equals, Flagged)
+	c.Check(player.Blocks[Position{X: 2, Y: 2}], Equals, Questioned)
+	c.Check(player.Blocks[Position{X: 4, Y: 2}], Equals, 0)
+	c.Check(spectator.Blocks[Position{X: 0, Y: 0}], Equals, Unknown)
+	c.Check(spectator.Blocks[Position{X: 2, Y: 2}], Equals, Unknown)
+	c.Check(spectator.Blocks[Position{X: 4, Y: 2}], Equals, 0)
+	c.Check(spectator.Mines, Equals, 5)
+
+	// losing shows every mine in the display, but not in the views
+	_, err = game.Select(2, 1)
+	c.Assert(err, IsNil)
+	c.Assert(game.State(), Equals, Lost)
+	c.Check(game.Display()[Position{X: 1, Y: 2}], Equals, Mine)
+	c.Check(game.Display()[Position{X: 4, Y: 4}], Equals, WrongFlag)
+	player, spectator = game.PlayerView(), game.SpectatorView()
+	c.Check(player.State, Equals, Lost)
+	c.Check(player.Blocks[Position{X: 2, Y: 1}], Equals, Exploded)
+	c.Check(player.Blocks[Position{X: 1, Y: 2}], Equals, Unknown)
+	c.Check(player.Blocks[Position{X: 4, Y: 4}], Equals, Flagged)
+	c.Check(spectator.Blocks[Position{X: 2, Y: 1}], Equals, Exploded)
+	c.Check(spectator.Blocks[Position{X: 4, Y: 4}], Equals, Unknown)
+}
+
+func (s *MSSuite) TestGame_ViewAfterWin(c *C) {
+	// winning flags every mine in the display, but not in the views
+	game := newTestGame(c, Config{Win: RevealTarget(Position{X: 4, Y: 2})})
+	c.Assert(game.ToggleFlag(4, 0), IsNil)
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.State(), Equals, Won)
+	c.Check(game.Display()[Position{X: 0, Y: 0}], Equals, Flagged)
+
+	player := game.PlayerView()
+	c.Check(player.Blocks[Position{X: 4, Y: 0}], Equals, Flagged)
+	c.Check(player.Blocks[Position{X: 0, Y: 0}], Equals, Unknown)
+	c.Check(player.Blocks[Position{X: 3, Y: 4}], Equals, Unknown)
+}
+
+func (s *MSSuite) TestGame_ViewsHideMines(c *C) {
+	// whatever is played, the views only show the blocks the player revealed
+	// or marked, and the mines they found
+	for seed := int64(0); seed < 20; seed++ {
+		game, err := NewGame(Config{Width: 9, Height: 9, Mines: 10, Lives: 3, Selector: SeededSelector(seed), Win: RevealFraction(0.5)})
+		c.Assert(err, IsNil)
+		src := SeededSource(seed)
+		revealed, flagged, found := make(map[Position]bool), make(map[Position]bool), make(map[Position]bool)
+		for game.State() == Playing {
+			pos := Position{X: src.Intn(9), Y: src.Intn(9)}
+			if src.Intn(4) == 0 {
+				game.ToggleFlag(pos.X, pos.Y)
+				flagged[pos] = !flagged[pos] && game.Display()[pos] == Flagged
+				continue
+			}
+			if value, _ := game.Select(pos.X, pos.Y); value == Mine {
+				found[pos] = true
+			}
+			for p, value := range game.Display() {
+				if value >= 0 {
+					revealed[p] = true
+				}
+			}
+		}
+
+		for _, view := range []Snapshot{game.PlayerView(), game.SpectatorView()} {
+			for pos, value := range view.Blocks {
+				switch {
+				case value >= 0:
+					c.Check(revealed[pos], Equals, true, Commentf("seed %d: %v", seed, pos))
+				case value == Exploded:
+					c.Check(found[pos], Equals, true, Commentf("seed %d: %v", seed, pos))
+				case value == Flagged:
+					c.Check(flagged[pos], Equals, true, Commentf("seed %d: %v", seed, pos))
+				default:
+					c.Check(value, Equals, Unknown, Commentf("seed %d: %v", seed, pos))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package gominesweeper
+
+// Rect is a rectangle of blocks, such as the part of a large board that is
+// in view.
+type Rect struct {
+	Origin        Position
+	Width, Height int
+}
+
+// Contains returns true if the position is inside the rectangle.
+func (r Rect) Contains(pos Position) bool {
+	return pos.X >= r.Origin.X && pos.X < r.Origin.X+r.Width &&
+		pos.Y >= r.Origin.Y && pos.Y < r.Origin.Y+r.Height
+}
+
+// SnapshotRect returns what the player can currently see of the blocks in the
+// rectangle, with their positions relative to its origin like the Viewport of
+// an InfiniteField, so that a client showing part of a large board does not
+// have to get all of it.  Blocks outside of the board are left out, and only
+// the blocks in the rectangle are looked at.
+func (g *Game) SnapshotRect(rect Rect) Snapshot {
+	blocks := make(map[Position]int)
+	for y := max(rect.Origin.Y, 0); y < min(rect.Origin.Y+rect.Height, int(g.config.Height)); y++ {
+		for x := max(rect.Origin.X, 0); x < min(rect.Origin.X+rect.Width, int(g.config.Width)); x++ {
+			blocks[Position{X: x - rect.Origin.X, Y: y - rect.Origin.Y}] = g.value(Position{X: x, Y: y})
+		}
+	}
+	snapshot := g.snapshot(blocks)
+	snapshot.Origin = rect.Origin
+	return snapshot
+}
+
+// SubscribeRect calls fn with every Event that Subscribe would, keeping only
+// the changes and cues of the blocks in the rectangle, with their positions
+// relative to its origin like SnapshotRect.  Events are still sent when none
+// of their changes are in view, since the state of the game may have changed.
+func (g *Game) SubscribeRect(rect Rect, fn func(Event)) (unsubscribe func()) {
+	return g.Subscribe(func(event Event) {
+		changes := make([]Change, 0, len(event.Changes))
+		for _, change := range event.Changes {
+			if rect.Contains(change.Position) {
+				change.Position = Position{X: change.X - rect.Origin.X, Y: change.Y - rect.Origin.Y}
+				changes = append(changes, change)
+			}
+		}
+		event.Changes = changes
+
+		var cues []Cue
+		for _, cue := range event.Cues {
+			if rect.Contains(cue.Position) {
+				cue.Position = Position{X: cue.X - rect.Origin.X, Y: cue.Y - rect.Origin.Y}
+				cues = append(cues, cue)
+			}
+		}
+		event.Cues = cues
+		fn(event)
+	})
+}
@@ -0,0 +1,70 @@
+package gominesweeper
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestRect_Contains(c *C) {
+	rect := Rect{Position{X: 1, Y: 2}, 3, 2}
+	c.Check(rect.Contains(Position{X: 1, Y: 2}), Equals, true)
+	c.Check(rect.Contains(Position{X: 3, Y: 3}), Equals, true)
+	c.Check(rect.Contains(Position{X: 4, Y: 3}), Equals, false)
+	c.Check(rect.Contains(Position{X: 1, Y: 4}), Equals, false)
+	c.Check(rect.Contains(Position{X: 0, Y: 2}), Equals, false)
+}
+
+func (s *MSSuite) TestGame_SnapshotRect(c *C) {
+	game := newTestGame(c, Config{})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+
+	view := game.SnapshotRect(Rect{Position{X: 3, Y: 1}, 2, 2})
+	c.Check(view.Origin, Equals, Position{X: 3, Y: 1})
+	c.Check(view.Width, Equals, 5)
+	c.Check(view.State, Equals, Playing)
+	c.Check(view.Blocks, DeepEquals, map[Position]int{
+		{X: 0, Y: 0}: 2, {X: 1, Y: 0}: 1,
+		{X: 0, Y: 1}: 1, {X: 1, Y: 1}: 0,
+	})
+
+	// blocks off the board are left out
+	view = game.SnapshotRect(Rect{Position{X: -1, Y: -1}, 2, 2})
+	c.Check(view.Blocks, DeepEquals, map[Position]int{{X: 1, Y: 1}: Flagged})
+
+	// the whole board matches Display
+	view = game.SnapshotRect(Rect{Position{X: 0, Y: 0}, 5, 5})
+	c.Check(view.Blocks, DeepEquals, game.Display())
+}
+
+func (s *MSSuite) TestGame_SubscribeRect(c *C) {
+	game := newTestGame(c, Config{})
+	game.clock = func() time.Time { return time.Unix(0, 0) }
+
+	var events []Event
+	unsubscribe := game.SubscribeRect(Rect{Position{X: 3, Y: 2}, 2, 3}, func(event Event) {
+		events = append(events, event)
+	})
+	defer unsubscribe()
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+
+	c.Check(events, DeepEquals, []Event{{
+		Move: &Move{Reveal, Position{X: 4, Y: 2}},
+		Changes: []Change{
+			{Position{X: 0, Y: 0}, 1}, {Position{X: 1, Y: 0}, 0},
+			{Position{X: 0, Y: 1}, 1}, {Position{X: 1, Y: 1}, 1},
+		},
+		State: Playing,
+		Lives: 1,
+	}, {
+		Move:    &Move{Flag, Position{X: 0, Y: 0}},
+		Changes: []Change{},
+		State:   Playing,
+		Lives:   1,
+	}})
+}
@@ -0,0 +1,73 @@
+//go:build js && wasm
+
+package wasm
+
+import (
+	"syscall/js"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+// Register adds the minesweeper object to the global scope.  The program must
+// keep running for its functions to be called, such as by blocking forever
+// once it has registered.
+func Register() {
+	js.Global().Set("minesweeper", js.ValueOf(map[string]any{
+		"newGame": js.FuncOf(newGame),
+		"values":  values,
+	}))
+}
+
+// newGame starts a game of the options in the first argument, returning an
+// object with its moves and snapshot, and a dispose function that releases
+// them once the game is no longer needed.
+func newGame(this js.Value, args []js.Value) any {
+	opts := "{}"
+	if len(args) > 0 && args[0].Truthy() {
+		opts = js.Global().Get("JSON").Call("stringify", args[0]).String()
+	}
+	cfg, err := config([]byte(opts))
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+	game, err := gominesweeper.NewGame(cfg)
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+
+	funcs := map[string]js.Func{
+		"reveal":   move(game, gominesweeper.Reveal),
+		"flag":     move(game, gominesweeper.Flag),
+		"chord":    move(game, gominesweeper.Chord),
+		"question": move(game, gominesweeper.Question),
+		"snapshot": js.FuncOf(func(this js.Value, args []js.Value) any {
+			return snapshot(game)
+		}),
+	}
+
+	// the functions keep the game alive until they are released
+	var dispose js.Func
+	dispose = js.FuncOf(func(this js.Value, args []js.Value) any {
+		for _, f := range funcs {
+			f.Release()
+		}
+		dispose.Release()
+		return nil
+	})
+	obj := map[string]any{"dispose": dispose}
+	for name, f := range funcs {
+		obj[name] = f
+	}
+	return obj
+}
+
+// move returns a function that makes the action at the x and y in its
+// arguments.
+func move(game *gominesweeper.Game, action gominesweeper.Action) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) < 2 {
+			return map[string]any{"error": "expected x and y"}
+		}
+		return apply(game, action, args[0].Int(), args[1].Int())
+	})
+}
@@ -0,0 +1,111 @@
+// Package wasm exposes games to JavaScript when built for js/wasm, so that the
+// package can power a game in the browser.  Register adds a minesweeper
+// object to the global scope:
+//
+//	const game = minesweeper.newGame({rules: "beginner"});
+//	game.reveal(4, 4);
+//	game.flag(0, 1);
+//	game.chord(4, 4);
+//	const {state, blocks} = game.snapshot();
+//	game.dispose();
+//
+// newGame takes either the name of registered rules or a width, height and
+// mines, along with the lives and the seed of the mines.  Each move returns
+// the value it found, the blocks it revealed and the state of the game, or an
+// error.  blocks holds the value from Display of every block by row, and
+// minesweeper.values names the values that are not proximities.
+//
+// The functions of a game hold on to it until dispose is called, so callers
+// must call dispose once they are done with a game, such as before starting
+// the next one.  The game's functions cannot be called after that.
+package wasm
+
+import (
+	"encoding/json"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+// options describe a new game from JavaScript.
+type options struct {
+	Rules  string `json:"rules"`
+	Width  uint   `json:"width"`
+	Height uint   `json:"height"`
+	Mines  uint   `json:"mines"`
+	Lives  uint   `json:"lives"`
+	Seed   *int64 `json:"seed"`
+}
+
+// config returns the config of the options encoded as JSON.  Named rules
+// take precedence over a size.
+func config(data []byte) (gominesweeper.Config, error) {
+	var opts options
+	if err := json.Unmarshal(data, &opts); err != nil {
+		return gominesweeper.Config{}, err
+	}
+
+	cfg := gominesweeper.Config{Width: opts.Width, Height: opts.Height, Mines: opts.Mines}
+	if opts.Rules != "" {
+		var err error
+		if cfg, err = gominesweeper.Preset(opts.Rules).Config(); err != nil {
+			return gominesweeper.Config{}, err
+		}
+	}
+	if opts.Lives > 0 {
+		cfg.Lives = opts.Lives
+	}
+	if opts.Seed != nil {
+		cfg.Selector = gominesweeper.SeededSelector(*opts.Seed)
+	}
+	return cfg, nil
+}
+
+// values names the values of Display that are not proximities.
+var values = map[string]any{
+	"mine":       gominesweeper.Mine,
+	"flagged":    gominesweeper.Flagged,
+	"unknown":    gominesweeper.Unknown,
+	"exploded":   gominesweeper.Exploded,
+	"wrongFlag":  gominesweeper.WrongFlag,
+	"defused":    gominesweeper.Defused,
+	"revealed":   gominesweeper.Revealed,
+	"questioned": gominesweeper.Questioned,
+}
+
+// apply makes the move and returns its result, or its error, as the plain
+// values that js.ValueOf takes.
+func apply(g *gominesweeper.Game, action gominesweeper.Action, x, y int) map[string]any {
+	result, err := g.Apply(gominesweeper.Move{Action: action, Position: gominesweeper.Position{X: x, Y: y}})
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+	return map[string]any{
+		"value":    result.Value,
+		"revealed": result.Revealed,
+		"state":    result.State.String(),
+	}
+}
+
+// snapshot returns what the player can see of the game as the plain values
+// that js.ValueOf takes, with the elapsed time in milliseconds.
+func snapshot(g *gominesweeper.Game) map[string]any {
+	s := g.Snapshot()
+	blocks := make([]any, s.Height)
+	for y := range blocks {
+		row := make([]any, s.Width)
+		for x := range row {
+			row[x] = s.Blocks[gominesweeper.Position{X: x, Y: y}]
+		}
+		blocks[y] = row
+	}
+	return map[string]any{
+		"width":   s.Width,
+		"height":  s.Height,
+		"mines":   s.Mines,
+		"state":   s.State.String(),
+		"lives":   int(s.Lives),
+		"elapsed": int(g.Elapsed() / time.Millisecond),
+		"blocks":  blocks,
+	}
+}
@@ -0,0 +1,50 @@
+package wasm
+
+import (
+	"testing"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type WasmSuite struct{}
+
+var _ = Suite(&WasmSuite{})
+
+func (s *WasmSuite) TestConfig(c *C) {
+	cfg, err := config([]byte(`{"rules": "expert", "lives": 3}`))
+	c.Assert(err, IsNil)
+	c.Check([]uint{cfg.Width, cfg.Height, cfg.Mines, cfg.Lives}, DeepEquals, []uint{30, 16, 99, 3})
+
+	cfg, err = config([]byte(`{"width": 5, "height": 4, "mines": 3, "seed": 42}`))
+	c.Assert(err, IsNil)
+	c.Check([]uint{cfg.Width, cfg.Height, cfg.Mines}, DeepEquals, []uint{5, 4, 3})
+	c.Check(cfg.Selector, NotNil)
+
+	_, err = config([]byte(`{"rules": "missing"}`))
+	c.Check(err, Equals, gominesweeper.ErrUnknownName)
+}
+
+func (s *WasmSuite) TestMoves(c *C) {
+	cfg, err := config([]byte(`{"width": 5, "height": 5, "mines": 5, "seed": 42}`))
+	c.Assert(err, IsNil)
+	game, err := gominesweeper.NewGame(cfg)
+	c.Assert(err, IsNil)
+
+	snap := snapshot(game)
+	c.Check(snap["width"], Equals, 5)
+	c.Check(snap["state"], Equals, "playing")
+	c.Assert(snap["blocks"], HasLen, 5)
+	c.Check(snap["blocks"].([]any)[0], DeepEquals, []any{
+		gominesweeper.Unknown, gominesweeper.Unknown, gominesweeper.Unknown, gominesweeper.Unknown, gominesweeper.Unknown,
+	})
+
+	result := apply(game, gominesweeper.Flag, 0, 0)
+	c.Check(result, DeepEquals, map[string]any{"value": gominesweeper.Flagged, "revealed": 0, "state": "playing"})
+	c.Check(snapshot(game)["blocks"].([]any)[0].([]any)[0], Equals, gominesweeper.Flagged)
+
+	result = apply(game, gominesweeper.Reveal, 9, 9)
+	c.Check(result["error"], Equals, "point (9,9) is out of bounds of 5x5")
+}
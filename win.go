@@ -0,0 +1,64 @@
+package gominesweeper
+
+import (
+	"fmt"
+	"time"
+)
+
+// WinCondition decides when a game has been won.  It is checked after every
+// move, and on Tick, for as long as the game is being played.
+type WinCondition interface {
+	Won(g *Game) bool
+}
+
+// WinFunc is a WinCondition made from an ordinary function.
+type WinFunc func(g *Game) bool
+
+// Won calls f(g).
+func (f WinFunc) Won(g *Game) bool {
+	return f(g)
+}
+
+// namedWin is a built in WinCondition, named so that it can be recorded in
+// the Rules of a replay.
+type namedWin struct {
+	WinFunc
+	name string
+}
+
+// String returns the name of the win condition.
+func (w namedWin) String() string {
+	return w.name
+}
+
+var (
+	// ClearAll is won once every block without a mine is revealed.
+	ClearAll WinCondition = namedWin{func(g *Game) bool {
+		return g.minefield.cleared()
+	}, "clear"}
+
+	// FlagAll is won once every mine is flagged and no other block is.
+	FlagAll WinCondition = namedWin{func(g *Game) bool {
+		won := true
+		g.minefield.stored(func(pos Position, block *Block) {
+			won = won && block.flagged() == (block.proximity() == Mine)
+		})
+		return won
+	}, "flag"}
+)
+
+// RevealTarget is won once the block at the target position is revealed.
+func RevealTarget(target Position) WinCondition {
+	return namedWin{func(g *Game) bool {
+		block, ok := g.minefield.peek(target)
+		return ok && block.checked() && block.proximity() != Mine
+	}, fmt.Sprintf("target(%d,%d)", target.X, target.Y)}
+}
+
+// Survive is won once the game has been played for the duration without
+// losing.
+func Survive(d time.Duration) WinCondition {
+	return namedWin{func(g *Game) bool {
+		return g.Elapsed() >= d
+	}, fmt.Sprintf("survive(%s)", d)}
+}
@@ -0,0 +1,68 @@
+package gominesweeper
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestWin_FlagAll(c *C) {
+	game := newTestGame(c, Config{Win: FlagAll})
+
+	for _, pos := range []Position{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 1, Y: 1}} {
+		c.Assert(game.ToggleFlag(pos.X, pos.Y), IsNil)
+	}
+	c.Check(game.State(), Equals, Playing)
+
+	// a wrong flag has to be removed
+	c.Assert(game.ToggleFlag(3, 4), IsNil)
+	c.Check(game.State(), Equals, Playing)
+	c.Assert(game.ToggleFlag(1, 1), IsNil)
+	c.Check(game.State(), Equals, Won)
+}
+
+func (s *MSSuite) TestWin_RevealTarget(c *C) {
+	game := newTestGame(c, Config{Win: RevealTarget(Position{X: 1, Y: 1})})
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(game.State(), Equals, Playing)
+	_, err = game.Select(1, 1)
+	c.Assert(err, IsNil)
+	c.Check(game.State(), Equals, Won)
+
+	display := game.Display()
+	c.Check(display[Position{X: 0, Y: 0}], Equals, Flagged)
+	c.Check(display[Position{X: 0, Y: 1}], Equals, Unknown)
+}
+
+func (s *MSSuite) TestWin_Survive(c *C) {
+	now := time.Unix(0, 0)
+	game := newTestGame(c, Config{Win: Survive(time.Minute)})
+	game.clock = func() time.Time { return now }
+
+	// the clock only starts on the first move
+	now = now.Add(time.Hour)
+	c.Check(game.Tick(), Equals, Playing)
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	now = now.Add(59 * time.Second)
+	c.Check(game.Tick(), Equals, Playing)
+	now = now.Add(time.Second)
+	c.Check(game.Tick(), Equals, Won)
+	c.Check(game.Elapsed(), Equals, time.Minute)
+
+	_, err = game.Select(0, 4)
+	c.Check(err, Equals, ErrGameOver)
+}
+
+func (s *MSSuite) TestWin_Func(c *C) {
+	game := newTestGame(c, Config{Win: WinFunc(func(g *Game) bool {
+		return g.Display()[Position{X: 3, Y: 3}] == 1
+	})})
+
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Check(game.State(), Equals, Won)
+}
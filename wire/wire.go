@@ -0,0 +1,179 @@
+// Package wire defines the messages of the minesweeper protocol, which the
+// server package speaks over HTTP and websockets, so that clients in Go can
+// share them and clients in any other language have a fixed format to
+// follow.  Every message is a JSON object:
+//
+//	Move      a move on a game, posted by the client
+//	Snapshot  the state of a game as the player sees it
+//	Event     what changed in a game after a move, sent over a websocket
+//	Error     why a request failed
+//
+// The protocol is versioned.  Within a version, fields are only ever added,
+// never renamed, removed or given another meaning, so a client must ignore
+// any field it does not know.  The messages of each version are kept in
+// testdata, and the tests check that they are still read and written the
+// same.
+package wire
+
+import (
+	"errors"
+	"strconv"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+)
+
+const (
+	// Version is the version of the protocol of these messages.
+	Version = 1
+
+	// MinVersion is the oldest version of the protocol still spoken.
+	MinVersion = 1
+
+	// Header is the HTTP header that a server sends its Version in.  A
+	// client may send the version it speaks in it too, which the server
+	// refuses with ErrUnsupportedVersion unless it is Supported.
+	Header = "Minesweeper-Protocol"
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported protocol version")
+)
+
+// Supported returns true if the version of the protocol is spoken.
+func Supported(version int) bool {
+	return version >= MinVersion && version <= Version
+}
+
+// ParseVersion returns the version of the protocol sent in the Header, or
+// Version if none was sent.  It returns ErrUnsupportedVersion unless the
+// version is Supported.
+func ParseVersion(header string) (int, error) {
+	if header == "" {
+		return Version, nil
+	}
+	version, err := strconv.Atoi(header)
+	if err != nil || !Supported(version) {
+		return 0, ErrUnsupportedVersion
+	}
+	return version, nil
+}
+
+// Position is a block on the board, counted from the top left.
+type Position struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Move is the position of a block to move on, and the action to take on it,
+// which reveals it by default.
+type Move struct {
+	Action gominesweeper.Action `json:"action,omitempty"`
+	X      int                  `json:"x"`
+	Y      int                  `json:"y"`
+}
+
+// Snapshot is the state of a game as seen by the player.
+type Snapshot struct {
+	ID    string `json:"id"`
+	State string `json:"state"`
+	Lives uint   `json:"lives"`
+
+	// X and Y are the column and row of the first block, when only a
+	// rectangle of the board was asked for.
+	X int `json:"x,omitempty"`
+	Y int `json:"y,omitempty"`
+
+	// Blocks are the values from Display, indexed by row and then column.
+	Blocks [][]int `json:"blocks"`
+}
+
+// Change is a block whose displayed value has changed.
+type Change struct {
+	X     int `json:"x"`
+	Y     int `json:"y"`
+	Value int `json:"value"`
+}
+
+// Cue describes a block revealed in blind mode, to be played as a sound.
+type Cue struct {
+	X     int     `json:"x"`
+	Y     int     `json:"y"`
+	Count int     `json:"count"`
+	Pan   float64 `json:"pan"`
+	Pitch float64 `json:"pitch"`
+}
+
+// Event is what changed in a game after a move, or as time passed.
+type Event struct {
+	// Move is the move that caused the event, if any.
+	Move *Move `json:"move,omitempty"`
+
+	// Changes are the blocks whose value changed, ordered by row.
+	Changes []Change `json:"changes"`
+
+	State string `json:"state"`
+	Lives uint   `json:"lives"`
+
+	// Elapsed is the time played, in nanoseconds.
+	Elapsed int64 `json:"elapsed"`
+	Paused  bool  `json:"paused,omitempty"`
+
+	Cues  []Cue `json:"cues,omitempty"`
+	Score int   `json:"score,omitempty"`
+	Combo int   `json:"combo,omitempty"`
+}
+
+// Error is why a request failed.  Position is the block the error is about,
+// if any, such as one that is out of bounds.
+type Error struct {
+	Error    string    `json:"error"`
+	Position *Position `json:"position,omitempty"`
+}
+
+// NewMove returns the message of the move.
+func NewMove(move gominesweeper.Move) Move {
+	return Move{Action: move.Action, X: move.X, Y: move.Y}
+}
+
+// Move returns the move of the message.
+func (m Move) Move() gominesweeper.Move {
+	return gominesweeper.Move{Action: m.Action, Position: gominesweeper.Position{X: m.X, Y: m.Y}}
+}
+
+// NewEvent returns the message of the event.
+func NewEvent(event gominesweeper.Event) Event {
+	e := Event{
+		Changes: make([]Change, len(event.Changes)),
+		State:   event.State.String(),
+		Lives:   event.Lives,
+		Elapsed: int64(event.Elapsed),
+		Paused:  event.Paused,
+		Score:   event.Score,
+		Combo:   event.Combo,
+	}
+	if event.Move != nil {
+		move := NewMove(*event.Move)
+		e.Move = &move
+	}
+	for i, change := range event.Changes {
+		e.Changes[i] = Change{X: change.X, Y: change.Y, Value: change.Value}
+	}
+	for _, cue := range event.Cues {
+		e.Cues = append(e.Cues, Cue{X: cue.X, Y: cue.Y, Count: cue.Count, Pan: cue.Pan, Pitch: cue.Pitch})
+	}
+	return e
+}
+
+// NewError returns the message of the error, with the position of an
+// OutOfBoundsError or DuplicatePointError.
+func NewError(err error) Error {
+	e := Error{Error: err.Error()}
+	var outOfBounds *gominesweeper.OutOfBoundsError
+	var duplicate *gominesweeper.DuplicatePointError
+	if errors.As(err, &outOfBounds) {
+		e.Position = &Position{X: outOfBounds.Pos.X, Y: outOfBounds.Pos.Y}
+	} else if errors.As(err, &duplicate) {
+		e.Position = &Position{X: duplicate.Pos.X, Y: duplicate.Pos.Y}
+	}
+	return e
+}
@@ -0,0 +1,110 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	gominesweeper "github.com/smousa/go-minesweeper"
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+type WireSuite struct{}
+
+var _ = Suite(&WireSuite{})
+
+// messages returns a new value of each message, by the name of its file in
+// testdata.
+func messages() map[string]interface{} {
+	return map[string]interface{}{
+		"move.json":     &Move{},
+		"snapshot.json": &Snapshot{},
+		"event.json":    &Event{},
+		"error.json":    &Error{},
+	}
+}
+
+// The messages of every version still spoken are read and written exactly as
+// they were.
+func (s *WireSuite) TestCompatibility(c *C) {
+	for version := MinVersion; version <= Version; version++ {
+		dir := filepath.Join("testdata", "v"+strconv.Itoa(version))
+		for name, v := range messages() {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			c.Assert(err, IsNil)
+			c.Assert(json.Unmarshal(data, v), IsNil, Commentf("%s/%s", dir, name))
+
+			var buf bytes.Buffer
+			c.Assert(json.NewEncoder(&buf).Encode(v), IsNil)
+			c.Check(buf.String(), Equals, string(data), Commentf("%s/%s", dir, name))
+		}
+	}
+}
+
+// Fields added by later versions are ignored.
+func (s *WireSuite) TestUnknownFields(c *C) {
+	var move Move
+	c.Assert(json.Unmarshal([]byte(`{"action":"flag","x":1,"y":2,"player":"ada","at":{"t":1}}`), &move), IsNil)
+	c.Check(move, Equals, Move{Action: gominesweeper.Flag, X: 1, Y: 2})
+
+	var event Event
+	c.Assert(json.Unmarshal([]byte(`{"changes":[{"x":1,"y":0,"value":3,"owner":"ada"}],"state":"won","lives":1,"elapsed":5,"round":2}`), &event), IsNil)
+	c.Check(event, DeepEquals, Event{Changes: []Change{{X: 1, Y: 0, Value: 3}}, State: "won", Lives: 1, Elapsed: 5})
+}
+
+func (s *WireSuite) TestParseVersion(c *C) {
+	version, err := ParseVersion("")
+	c.Assert(err, IsNil)
+	c.Check(version, Equals, Version)
+	version, err = ParseVersion("1")
+	c.Assert(err, IsNil)
+	c.Check(version, Equals, 1)
+
+	for _, header := range []string{"0", "2", "v1", "1.0"} {
+		_, err = ParseVersion(header)
+		c.Check(err, Equals, ErrUnsupportedVersion, Commentf("%q", header))
+	}
+	c.Check(Supported(Version), Equals, true)
+	c.Check(Supported(Version+1), Equals, false)
+}
+
+func (s *WireSuite) TestNewEvent(c *C) {
+	event := NewEvent(gominesweeper.Event{
+		Move:    &gominesweeper.Move{Action: gominesweeper.Chord, Position: gominesweeper.Position{X: 4, Y: 2}},
+		Changes: []gominesweeper.Change{{Position: gominesweeper.Position{X: 3, Y: 1}, Value: 2}},
+		State:   gominesweeper.Lost,
+		Elapsed: 1500 * time.Millisecond,
+		Cues:    []gominesweeper.Cue{{Position: gominesweeper.Position{X: 4, Y: 1}, Count: 1, Pan: 1, Pitch: 0.75}},
+		Score:   120,
+	})
+	c.Check(event, DeepEquals, Event{
+		Move:    &Move{Action: gominesweeper.Chord, X: 4, Y: 2},
+		Changes: []Change{{X: 3, Y: 1, Value: 2}},
+		State:   "lost",
+		Elapsed: 1500000000,
+		Cues:    []Cue{{X: 4, Y: 1, Count: 1, Pan: 1, Pitch: 0.75}},
+		Score:   120,
+	})
+	c.Check(event.Move.Move(), Equals, gominesweeper.Move{Action: gominesweeper.Chord, Position: gominesweeper.Position{X: 4, Y: 2}})
+
+	// an event without changes still sends them as an empty list
+	data, err := json.Marshal(NewEvent(gominesweeper.Event{State: gominesweeper.Playing}))
+	c.Assert(err, IsNil)
+	c.Check(string(data), Equals, `{"changes":[],"state":"playing","lives":0,"elapsed":0}`)
+}
+
+func (s *WireSuite) TestNewError(c *C) {
+	c.Check(NewError(&gominesweeper.OutOfBoundsError{Pos: gominesweeper.Position{X: 9, Y: 9}, Width: 5, Height: 5}), DeepEquals, Error{
+		Error:    "point (9,9) is out of bounds of 5x5",
+		Position: &Position{X: 9, Y: 9},
+	})
+	c.Check(NewError(&gominesweeper.DuplicatePointError{Pos: gominesweeper.Position{X: 1, Y: 1}}).Position, DeepEquals, &Position{X: 1, Y: 1})
+	c.Check(NewError(gominesweeper.ErrGameOver), DeepEquals, Error{Error: "game is over"})
+}
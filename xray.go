@@ -0,0 +1,55 @@
+package gominesweeper
+
+// Overlay is what the player can see of a game, together with what every
+// block really is.
+type Overlay struct {
+	Snapshot
+
+	// Actual holds the proximity of every block, or Mine.
+	Actual map[Position]int
+}
+
+// XRay returns the snapshot of a debug game overlaid with where its mines
+// are.  Before the first selection of a game with an OpeningSize, the mines
+// may still be moved.  It returns ErrNotDebug unless the game's Config has
+// Debug set.
+func (g *Game) XRay() (Overlay, error) {
+	if !g.config.Debug {
+		return Overlay{}, ErrNotDebug
+	}
+
+	actual := make(map[Position]int)
+	g.minefield.each(func(pos Position, block *Block) {
+		actual[pos] = block.proximity()
+	})
+	return Overlay{Snapshot: g.Snapshot(), Actual: actual}, nil
+}
+
+// RevealAll reveals every block of a debug game that is not flagged, ending
+// it as lost with the reason RevealedAll if it was still being played.
+// Flags that are not on a mine are then shown as WrongFlag.  It is not a
+// move, so it is left out of the Replay, but it is published to the
+// subscribers of the game like a Tick.  It returns ErrNotDebug unless the
+// game's Config has Debug set.
+func (g *Game) RevealAll() error {
+	if !g.config.Debug {
+		return ErrNotDebug
+	}
+
+	for y := 0; y < int(g.config.Height); y++ {
+		for x := 0; x < int(g.config.Width); x++ {
+			block, _ := g.minefield.block(Position{X: x, Y: y})
+			block.Select()
+		}
+	}
+	if g.state == Playing {
+		now := g.clock()
+		if g.Paused() {
+			now = g.paused
+		}
+		g.state, g.reason = Lost, RevealedAll
+		g.finish(now)
+	}
+	g.publish(nil, g.Elapsed())
+	return nil
+}
@@ -0,0 +1,55 @@
+package gominesweeper
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MSSuite) TestGame_XRay(c *C) {
+	game := newTestGame(c, Config{})
+	_, err := game.XRay()
+	c.Check(err, Equals, ErrNotDebug)
+	c.Check(game.RevealAll(), Equals, ErrNotDebug)
+
+	game = newTestGame(c, Config{Debug: true})
+	_, err = game.Select(4, 2)
+	c.Assert(err, IsNil)
+	overlay, err := game.XRay()
+	c.Assert(err, IsNil)
+	c.Check(overlay.Blocks, DeepEquals, game.Display())
+	c.Check(overlay.State, Equals, Playing)
+	c.Check(overlay.Actual, HasLen, 25)
+	c.Check(overlay.Actual[Position{X: 0, Y: 0}], Equals, Mine)
+	c.Check(overlay.Actual[Position{X: 1, Y: 0}], Equals, 2)
+	c.Check(overlay.Actual[Position{X: 0, Y: 4}], Equals, 0)
+}
+
+func (s *MSSuite) TestGame_RevealAll(c *C) {
+	var ended []GameResult
+	game := newTestGame(c, Config{Debug: true, OnEnd: func(g *Game, result GameResult) {
+		ended = append(ended, result)
+	}})
+	_, err := game.Select(4, 2)
+	c.Assert(err, IsNil)
+	c.Assert(game.ToggleFlag(0, 0), IsNil)
+	c.Assert(game.ToggleFlag(1, 0), IsNil)
+
+	var events []Event
+	game.Subscribe(func(event Event) {
+		events = append(events, event)
+	})
+	c.Assert(game.RevealAll(), IsNil)
+	c.Check(game.State(), Equals, Lost)
+	c.Check(game.EndState().Reason, Equals, RevealedAll)
+	c.Check(ended, HasLen, 1)
+	c.Check(events, HasLen, 1)
+
+	display := game.Display()
+	c.Check(display[Position{X: 0, Y: 0}], Equals, Flagged)
+	c.Check(display[Position{X: 1, Y: 0}], Equals, WrongFlag)
+	c.Check(display[Position{X: 4, Y: 0}], Equals, Mine)
+	c.Check(display[Position{X: 0, Y: 4}], Equals, 0)
+
+	// revealing a game that is over does not end it again
+	c.Assert(game.RevealAll(), IsNil)
+	c.Check(ended, HasLen, 1)
+}